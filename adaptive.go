@@ -0,0 +1,155 @@
+package pagination
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// maxAdaptiveLevel bounds how often the maximum page size is halved
+const maxAdaptiveLevel = 16
+
+// adaptivePageSizeKey holds the page size reduction of the current request in the request context
+type adaptivePageSizeKey struct{}
+
+// AdaptivePageSize protects the database under load: when the recent latencies of an
+// endpoint exceed Threshold its maximum page size is halved, and once they drop below
+// half the threshold it is doubled again, up to the configured maximum
+type AdaptivePageSize struct {
+	// Threshold is the p95 latency above which the page size shrinks
+	Threshold time.Duration
+	// MinPageSize is the smallest maximum page size applied, 10 by default
+	MinPageSize int
+	// Samples is the number of requests per decision, 20 by default
+	Samples int
+
+	mu        sync.Mutex
+	endpoints map[string]*adaptiveEndpoint
+}
+
+type adaptiveEndpoint struct {
+	level   int
+	samples []time.Duration
+}
+
+// adaptiveReduction is what a request needs to know to apply the reduced page size
+type adaptiveReduction struct {
+	level int
+	min   int
+}
+
+// NewAdaptivePageSize creates an adaptive page size reacting to latencies above threshold
+func NewAdaptivePageSize(threshold time.Duration) *AdaptivePageSize {
+	return &AdaptivePageSize{Threshold: threshold}
+}
+
+func (a *AdaptivePageSize) minPageSize() int {
+	if a.MinPageSize > 0 {
+		return a.MinPageSize
+	}
+	return 10
+}
+
+func (a *AdaptivePageSize) samples() int {
+	if a.Samples > 0 {
+		return a.Samples
+	}
+	return 20
+}
+
+// MaxPageSize returns the maximum page size currently applied to endpoint out of max
+func (a *AdaptivePageSize) MaxPageSize(endpoint string, max int) int {
+	return a.reduction(endpoint).apply(max)
+}
+
+// Record adds the latency of one request to endpoint and adjusts its page size
+// once enough samples were collected
+func (a *AdaptivePageSize) Record(endpoint string, latency time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.endpoints == nil {
+		a.endpoints = make(map[string]*adaptiveEndpoint)
+	}
+	state, ok := a.endpoints[endpoint]
+	if !ok {
+		state = &adaptiveEndpoint{}
+		a.endpoints[endpoint] = state
+	}
+
+	state.samples = append(state.samples, latency)
+	if len(state.samples) < a.samples() {
+		return
+	}
+
+	sort.Slice(state.samples, func(i, j int) bool { return state.samples[i] < state.samples[j] })
+	p95 := percentile(state.samples, 0.95)
+	switch {
+	case p95 > a.Threshold && state.level < maxAdaptiveLevel:
+		state.level++
+	case p95 < a.Threshold/2 && state.level > 0:
+		state.level--
+	}
+	// Each decision needs fresh evidence of the size it applied
+	state.samples = state.samples[:0]
+}
+
+// Handler reduces the page size bound by the request helpers of each route and records
+// the latency of the handler; endpoints are told apart by the pattern of the ServeMux
+// route, or the path when the request was not routed by one
+func (a *AdaptivePageSize) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		endpoint := r.Pattern
+		if endpoint == "" {
+			endpoint = r.URL.Path
+		}
+
+		r = r.WithContext(withAdaptiveReduction(r.Context(), a.reduction(endpoint)))
+		started := time.Now()
+		next.ServeHTTP(w, r)
+		a.Record(endpoint, time.Since(started))
+	})
+}
+
+func withAdaptiveReduction(ctx context.Context, reduction adaptiveReduction) context.Context {
+	return context.WithValue(ctx, adaptivePageSizeKey{}, reduction)
+}
+
+func (a *AdaptivePageSize) reduction(endpoint string) adaptiveReduction {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	reduction := adaptiveReduction{min: a.minPageSize()}
+	if state, ok := a.endpoints[endpoint]; ok {
+		reduction.level = state.level
+	}
+	return reduction
+}
+
+// apply halves max once per level without going below the minimum
+func (r adaptiveReduction) apply(max int) int {
+	reduced := max >> r.level
+	if reduced < r.min {
+		reduced = r.min
+	}
+	if reduced > max {
+		return max
+	}
+	return reduced
+}
+
+// adaptiveMaxPageSize returns the reduced maximum page size of the request, ok is false
+// when the page size is not reduced
+func adaptiveMaxPageSize(ctx context.Context, max int) (int, bool) {
+	if ctx == nil {
+		return max, false
+	}
+	reduction, ok := ctx.Value(adaptivePageSizeKey{}).(adaptiveReduction)
+	if !ok {
+		return max, false
+	}
+	reduced := reduction.apply(max)
+	return reduced, reduced < max
+}
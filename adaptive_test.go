@@ -0,0 +1,92 @@
+package pagination
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func recordLatencies(sizer *AdaptivePageSize, endpoint string, latency time.Duration) {
+	for i := 0; i < sizer.samples(); i++ {
+		sizer.Record(endpoint, latency)
+	}
+}
+
+func TestAdaptivePageSize_ShrinksAndRecovers(t *testing.T) {
+	sizer := NewAdaptivePageSize(100 * time.Millisecond)
+	assert.Equal(t, 100, sizer.MaxPageSize("/users", 100))
+
+	recordLatencies(sizer, "/users", 300*time.Millisecond)
+	assert.Equal(t, 50, sizer.MaxPageSize("/users", 100))
+	assert.Equal(t, 100, sizer.MaxPageSize("/orders", 100), "endpoints are tracked separately")
+
+	recordLatencies(sizer, "/users", 300*time.Millisecond)
+	recordLatencies(sizer, "/users", 300*time.Millisecond)
+	recordLatencies(sizer, "/users", 300*time.Millisecond)
+	assert.Equal(t, 10, sizer.MaxPageSize("/users", 100), "the page size never drops below the minimum")
+
+	recordLatencies(sizer, "/users", 80*time.Millisecond)
+	assert.Equal(t, 10, sizer.MaxPageSize("/users", 100), "latencies between half the threshold and the threshold hold the size")
+
+	for i := 0; i < 4; i++ {
+		recordLatencies(sizer, "/users", 10*time.Millisecond)
+	}
+	assert.Equal(t, 100, sizer.MaxPageSize("/users", 100))
+}
+
+func TestAdaptivePageSize_IgnoresOutliers(t *testing.T) {
+	sizer := NewAdaptivePageSize(100 * time.Millisecond)
+	sizer.Record("/users", time.Second)
+	recordLatencies(sizer, "/users", 80*time.Millisecond)
+	assert.Equal(t, 100, sizer.MaxPageSize("/users", 100), "a single slow request does not shrink the page")
+}
+
+func TestAdaptivePageSize_Routes(t *testing.T) {
+	sizer := &AdaptivePageSize{Threshold: 100 * time.Millisecond, MinPageSize: 5}
+	recordLatencies(sizer, "GET /users/{team}", 500*time.Millisecond)
+	recordLatencies(sizer, "GET /users/{team}", 500*time.Millisecond)
+
+	var pagination PaginationRequest
+	var warnings []PaginationWarning
+	router := http.NewServeMux()
+	router.Handle("GET /users/{team}", sizer.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pagination, warnings = BindPaginationRequest(r)
+	})))
+
+	serve := func(target string) {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+	}
+
+	serve("/users/red?per_page=80")
+	assert.Equal(t, 25, pagination.PerPage)
+	assert.Equal(t, []PaginationWarning{{
+		Param:   "per_page",
+		Value:   "80",
+		Applied: "25",
+		Message: "per_page is limited to 25 while the endpoint is under load",
+	}}, warnings)
+
+	serve("/users/blue?per_page=20")
+	assert.Equal(t, 20, pagination.PerPage)
+	assert.Empty(t, warnings, "smaller pages are served as requested")
+}
+
+func TestAdaptivePageSize_ReducesDefault(t *testing.T) {
+	useTestConfig(t, map[string]string{EnvDefaultPageSize: "50"})
+	sizer := &AdaptivePageSize{Threshold: 100 * time.Millisecond}
+	recordLatencies(sizer, "/users", time.Second)
+	recordLatencies(sizer, "/users", time.Second)
+	recordLatencies(sizer, "/users", time.Second)
+
+	request := newTestRequest("/users")
+	request = request.WithContext(withAdaptiveReduction(request.Context(), sizer.reduction("/users")))
+	pagination, warnings := BindPaginationRequest(request)
+
+	assert.Equal(t, 12, pagination.PerPage)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, "50", warnings[0].Value)
+	assert.Equal(t, "12", warnings[0].Applied)
+}
@@ -0,0 +1,166 @@
+package pagination
+
+import (
+	"net/http"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// slowQueryLogSize is the number of slow queries kept in memory
+	slowQueryLogSize = 100
+	// adminTopQueries is the number of query shapes reported by Introspect
+	adminTopQueries = 20
+)
+
+// SlowQuery describes a paginated query that exceeded Config.SlowQueryThreshold
+// SQL keeps its placeholders; argument values are never recorded.
+type SlowQuery struct {
+	Table    string        `json:"table"`
+	Kind     string        `json:"kind"`
+	SQL      string        `json:"sql,omitempty"`
+	Page     int           `json:"page"`
+	PerPage  int           `json:"per_page"`
+	Duration time.Duration `json:"duration"`
+	At       time.Time     `json:"at"`
+}
+
+// slowQueryLog is a fixed-size ring buffer of recent slow queries
+type slowQueryLog struct {
+	mu      sync.Mutex
+	entries [slowQueryLogSize]SlowQuery
+	next    int
+	full    bool
+}
+
+var slowQueries slowQueryLog
+
+func (l *slowQueryLog) add(query SlowQuery) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.entries[l.next] = query
+	l.next = (l.next + 1) % slowQueryLogSize
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// recent returns the logged queries, most recent first
+func (l *slowQueryLog) recent() []SlowQuery {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	count := l.next
+	if l.full {
+		count = slowQueryLogSize
+	}
+
+	queries := make([]SlowQuery, 0, count)
+	for i := 1; i <= count; i++ {
+		queries = append(queries, l.entries[(l.next-i+slowQueryLogSize)%slowQueryLogSize])
+	}
+	return queries
+}
+
+// RecentSlowQueries returns the slow paginated queries kept in memory, most recent first
+func RecentSlowQueries() []SlowQuery {
+	return slowQueries.recent()
+}
+
+// observeQuery records an executed paginated query when it was slow
+func observeQuery(kind string, table string, query *gorm.DB, pagination PaginationRequest, duration time.Duration) {
+	recordStatement(kind, query)
+
+	threshold := CurrentConfig().SlowQueryThreshold
+	if threshold <= 0 || duration < threshold {
+		return
+	}
+
+	slowQueries.add(SlowQuery{
+		Table:    table,
+		Kind:     kind,
+		SQL:      renderQuerySQL(kind, query),
+		Page:     pagination.Page,
+		PerPage:  pagination.PerPage,
+		Duration: duration,
+		At:       time.Now(),
+	})
+}
+
+// renderQuerySQL rebuilds the SQL of an executed query with placeholders,
+// GORM clears it once the statement has run
+func renderQuerySQL(kind string, query *gorm.DB) string {
+	dryRun := query.Session(&gorm.Session{DryRun: true}).Set(sqlCommentSetting, "")
+	if kind == "count" {
+		var total int64
+		return dryRun.Count(&total).Statement.SQL.String()
+	}
+	var rows []map[string]interface{}
+	return dryRun.Find(&rows).Statement.SQL.String()
+}
+
+// AdminOptions selects what the introspection endpoint exposes
+type AdminOptions struct {
+	// TotalCaches are reported by name
+	TotalCaches map[string]*TotalCache
+	// PageCaches are reported by name
+	PageCaches map[string]*PageCache
+}
+
+// AdminInfo is the payload of AdminHTTPHandler
+type AdminInfo struct {
+	Config      Config                     `json:"config"`
+	Tables      map[string]TableConfig     `json:"tables"`
+	TotalCaches map[string]TotalCacheStats `json:"total_caches,omitempty"`
+	PageCaches  map[string]PageCacheStats  `json:"page_caches,omitempty"`
+	SlowQueries []SlowQuery                `json:"slow_queries"`
+	TopQueries  []QueryShapeStats          `json:"top_queries"`
+}
+
+// Introspect collects the current pagination configuration and runtime statistics
+func Introspect(options AdminOptions) AdminInfo {
+	info := AdminInfo{
+		Config:      CurrentConfig(),
+		Tables:      registeredTables(),
+		SlowQueries: RecentSlowQueries(),
+		TopQueries:  TopQueryShapes(adminTopQueries),
+	}
+
+	if len(options.TotalCaches) > 0 {
+		info.TotalCaches = make(map[string]TotalCacheStats, len(options.TotalCaches))
+		for name, cache := range options.TotalCaches {
+			info.TotalCaches[name] = cache.Stats()
+		}
+	}
+	if len(options.PageCaches) > 0 {
+		info.PageCaches = make(map[string]PageCacheStats, len(options.PageCaches))
+		for name, cache := range options.PageCaches {
+			info.PageCaches[name] = cache.Stats()
+		}
+	}
+
+	return info
+}
+
+// AdminHTTPHandler serves Introspect as JSON; mount it behind authentication, it reveals
+// table names and query shapes
+func AdminHTTPHandler(options AdminOptions) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, Introspect(options))
+	})
+}
+
+// registeredTables returns a copy of the table registry
+func registeredTables() map[string]TableConfig {
+	tableConfigsMu.RLock()
+	defer tableConfigsMu.RUnlock()
+
+	tables := make(map[string]TableConfig, len(tableConfigs))
+	for name, config := range tableConfigs {
+		tables[name] = config
+	}
+	return tables
+}
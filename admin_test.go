@@ -0,0 +1,54 @@
+package pagination
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlowQueryLog_RingBuffer(t *testing.T) {
+	var log slowQueryLog
+	for i := 0; i < slowQueryLogSize+5; i++ {
+		log.add(SlowQuery{Page: i})
+	}
+
+	recent := log.recent()
+	assert.Len(t, recent, slowQueryLogSize)
+	assert.Equal(t, slowQueryLogSize+4, recent[0].Page)
+	assert.Equal(t, 5, recent[len(recent)-1].Page)
+}
+
+func TestAdminHandler(t *testing.T) {
+	db := setupTestDB()
+	config := DefaultConfig()
+	config.SlowQueryThreshold = time.Nanosecond
+	assert.NoError(t, SetConfig(config))
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+	registerTestTable(t, TableConfig{DefaultSort: "age desc"})
+
+	cache := NewTotalCache(time.Minute)
+	options := PaginatedQueryOptions{Dialect: SQLite, TotalCache: cache}
+	_, _, err := PaginatedQueryWithOptions[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 2, PerPage: 2}, nil, options)
+	assert.NoError(t, err)
+
+	recorder := httptest.NewRecorder()
+	AdminHTTPHandler(AdminOptions{TotalCaches: map[string]*TotalCache{"users": cache}}).ServeHTTP(recorder, httptest.NewRequest("GET", "/admin/pagination", nil))
+
+	var info AdminInfo
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &info))
+	assert.Equal(t, time.Nanosecond, info.Config.SlowQueryThreshold)
+	assert.Equal(t, "age desc", info.Tables["test_users"].DefaultSort)
+	assert.Equal(t, 1, info.TotalCaches["users"].Entries)
+	assert.Equal(t, int64(1), info.TotalCaches["users"].Misses)
+
+	if assert.GreaterOrEqual(t, len(info.SlowQueries), 2) {
+		assert.Equal(t, "count", info.SlowQueries[0].Kind, "a full page runs the count after the data")
+		assert.Equal(t, "data", info.SlowQueries[1].Kind)
+		assert.Equal(t, "test_users", info.SlowQueries[1].Table)
+		assert.Equal(t, 2, info.SlowQueries[1].Page)
+		assert.Contains(t, info.SlowQueries[1].SQL, "LIMIT")
+	}
+}
@@ -0,0 +1,106 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// tierCursor is the decoded form of a tiered cursor: the tier a page starts in and the
+// cursor of that tier
+type tierCursor struct {
+	Tier   int    `json:"t,omitempty"`
+	Cursor string `json:"c,omitempty"`
+}
+
+// TieredPaginate pages tiers such as a hot table followed by its archive as one series.
+// Each tier is read to its end before the next one is consulted, so a page running past
+// the hot rows continues with the archived ones. Every tier must follow the same
+// ordering and hold rows that sort after those of the tiers before it. Rows moved
+// between tiers while a client pages may be skipped or repeated.
+func TieredPaginate[T any](ctx context.Context, tiers []MergeSource[T], cursor string, limit int) (MergeResult[T], error) {
+	if limit <= 0 {
+		limit = 10
+	}
+
+	var position tierCursor
+	if cursor != "" {
+		if err := decodeToken(cursor, &position); err != nil {
+			return MergeResult[T]{}, err
+		}
+		if position.Tier < 0 || position.Tier >= len(tiers) {
+			return MergeResult[T]{}, fmt.Errorf("%w: tier %d out of range", ErrInvalidToken, position.Tier)
+		}
+	}
+
+	result := make([]T, 0, limit)
+	for position.Tier < len(tiers) && len(result) < limit {
+		tier := tiers[position.Tier]
+		items, next, err := tier.Fetch(ctx, position.Cursor, limit-len(result))
+		if err != nil {
+			return MergeResult[T]{}, fmt.Errorf("failed to fetch tier %s: %w", mergeSourceKey(tier, position.Tier), err)
+		}
+		result = append(result, items...)
+
+		position.Cursor = next
+		if next == "" {
+			position.Tier++
+		}
+	}
+
+	response := MergeResult[T]{Data: result}
+	if position.Tier < len(tiers) {
+		token, err := encodeToken(position)
+		if err != nil {
+			return MergeResult[T]{}, err
+		}
+		response.NextCursor = token
+		response.HasMore = true
+	}
+	return response, nil
+}
+
+// TableSource returns a source paging the rows of db by ordering, an ORDER BY clause
+// such as "created_at desc", with the primary key as tiebreaker. Point db at the table,
+// e.g. db.Table("events_archive"), to read an archive of the same model; filter narrows
+// the rows and may be nil.
+func TableSource[T any](name string, db *gorm.DB, ordering string, filter func(*gorm.DB) *gorm.DB) MergeSource[T] {
+	return MergeSource[T]{
+		Name: name,
+		Fetch: func(ctx context.Context, cursor string, limit int) ([]T, string, error) {
+			idColumn, err := primaryKeyColumn[T](db)
+			if err != nil {
+				return nil, "", err
+			}
+			paginator, err := NewCursorPaginator(ordering, idColumn)
+			if err != nil {
+				return nil, "", err
+			}
+			query := db.WithContext(ctx).Model(new(T))
+			if filter != nil {
+				query = filter(query)
+			}
+			paginator.Scope = CursorScope(name, ordering, totalCacheKey(query))
+			query, _, err = paginator.Page(query, cursor, limit)
+			if err != nil {
+				return nil, "", err
+			}
+
+			var rows []T
+			if err := query.Find(&rows).Error; err != nil {
+				return nil, "", fmt.Errorf("failed to fetch %s records: %w", name, err)
+			}
+			if len(rows) <= limit {
+				return rows, "", nil
+			}
+
+			rows = rows[:limit]
+			next, err := paginator.Encode(db, &rows[len(rows)-1], false)
+			if err != nil {
+				return nil, "", err
+			}
+			return rows, next, nil
+		},
+	}
+}
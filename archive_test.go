@@ -0,0 +1,73 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupArchiveDB() *gorm.DB {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	db.AutoMigrate(&TestChange{})
+	db.Table("test_changes_archive").AutoMigrate(&TestChange{})
+
+	at := func(day int) time.Time { return time.Date(2024, 1, day, 0, 0, 0, 0, time.UTC) }
+	db.Create(&[]TestChange{
+		{Name: "e", UpdatedAt: at(5)},
+		{Name: "f", UpdatedAt: at(6)},
+		{Name: "g", UpdatedAt: at(7)},
+	})
+	db.Table("test_changes_archive").Create(&[]TestChange{
+		{Name: "a", UpdatedAt: at(1)},
+		{Name: "b", UpdatedAt: at(2)},
+		{Name: "c", UpdatedAt: at(3)},
+		{Name: "d", UpdatedAt: at(4)},
+	})
+	return db
+}
+
+func TestTieredPaginate(t *testing.T) {
+	db := setupArchiveDB()
+	tiers := []MergeSource[TestChange]{
+		TableSource[TestChange]("hot", db, "updated_at desc", nil),
+		TableSource[TestChange]("archive", db.Table("test_changes_archive"), "updated_at desc", nil),
+	}
+
+	var pages [][]string
+	cursor := ""
+	for {
+		page, err := TieredPaginate(context.Background(), tiers, cursor, 2)
+		assert.NoError(t, err)
+		var names []string
+		for _, change := range page.Data {
+			names = append(names, change.Name)
+		}
+		pages = append(pages, names)
+		if !page.HasMore {
+			assert.Empty(t, page.NextCursor)
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	assert.Equal(t, [][]string{{"g", "f"}, {"e", "d"}, {"c", "b"}, {"a"}}, pages)
+}
+
+func TestTieredPaginate_Errors(t *testing.T) {
+	broken := MergeSource[int]{Name: "archive", Fetch: func(ctx context.Context, cursor string, limit int) ([]int, string, error) {
+		return nil, "", errors.New("archive offline")
+	}}
+	tiers := []MergeSource[int]{sliceMergeSource("hot", []int{1}), broken}
+
+	_, err := TieredPaginate(context.Background(), tiers, "", 5)
+	assert.ErrorContains(t, err, "failed to fetch tier archive")
+
+	cursor, _ := encodeToken(tierCursor{Tier: 7})
+	_, err = TieredPaginate(context.Background(), tiers, cursor, 5)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
@@ -0,0 +1,120 @@
+package pagination
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// BatchByIDsOptions configures fetching records by a list of IDs
+type BatchByIDsOptions struct {
+	TableName string
+	IDColumn  string
+	ChunkSize int
+	Includes  []string
+}
+
+func (o *BatchByIDsOptions) validate() {
+	if o.IDColumn == "" {
+		o.IDColumn = "id"
+	}
+	if o.ChunkSize <= 0 {
+		o.ChunkSize = 500
+	}
+}
+
+// FetchByIDs loads records for the given IDs using chunked IN queries
+// The output follows the order of ids; duplicate and missing IDs are skipped.
+func FetchByIDs[T any, K comparable](db *gorm.DB, ids []K, options BatchByIDsOptions) ([]T, error) {
+	options.validate()
+
+	ids = uniqueIDs(ids)
+	found := make(map[string]T, len(ids))
+
+	for start := 0; start < len(ids); start += options.ChunkSize {
+		end := start + options.ChunkSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+
+		var chunk []T
+		query := db
+		if options.TableName != "" {
+			query = query.Table(options.TableName)
+		} else {
+			query = query.Model(new(T))
+		}
+
+		for _, include := range validateIncludes(nil, options.Includes) {
+			query = query.Preload(include)
+		}
+
+		if err := query.Where(options.IDColumn+" IN ?", ids[start:end]).Find(&chunk).Error; err != nil {
+			return nil, fmt.Errorf("failed to fetch records: %w", err)
+		}
+
+		for i := range chunk {
+			id, err := columnValue(db, &chunk[i], options.IDColumn)
+			if err != nil {
+				return nil, err
+			}
+			found[fmt.Sprint(id)] = chunk[i]
+		}
+	}
+
+	result := make([]T, 0, len(found))
+	for _, id := range ids {
+		if item, ok := found[fmt.Sprint(id)]; ok {
+			result = append(result, item)
+		}
+	}
+
+	return result, nil
+}
+
+// PaginateByIDs paginates over a list of IDs and hydrates only the requested page
+// The total reflects the number of distinct IDs requested.
+func PaginateByIDs[T any, K comparable](
+	db *gorm.DB,
+	ids []K,
+	pagination PaginationRequest,
+	options BatchByIDsOptions,
+) ([]T, PaginationResponse, error) {
+	pagination.Validate()
+
+	ids = uniqueIDs(ids)
+	total := int64(len(ids))
+
+	pageIDs := ids
+	if !pagination.IsDisabled {
+		start := pagination.GetOffset()
+		if start > len(ids) {
+			start = len(ids)
+		}
+		end := start + pagination.GetLimit()
+		if end > len(ids) {
+			end = len(ids)
+		}
+		pageIDs = ids[start:end]
+	}
+
+	data, err := FetchByIDs[T](db, pageIDs, options)
+	if err != nil {
+		return nil, PaginationResponse{}, err
+	}
+
+	return data, CalculatePagination(pagination, total), nil
+}
+
+// uniqueIDs removes duplicate IDs while preserving the first occurrence order
+func uniqueIDs[K comparable](ids []K) []K {
+	seen := make(map[K]bool, len(ids))
+	unique := make([]K, 0, len(ids))
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,35 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchByIDs_PreservesOrder(t *testing.T) {
+	db := setupTestDB()
+
+	users, err := FetchByIDs[TestUser](db, []uint{4, 1, 99, 3, 1}, BatchByIDsOptions{ChunkSize: 2})
+
+	assert.NoError(t, err)
+	assert.Len(t, users, 3)
+	assert.Equal(t, uint(4), users[0].ID)
+	assert.Equal(t, uint(1), users[1].ID)
+	assert.Equal(t, uint(3), users[2].ID)
+}
+
+func TestPaginateByIDs(t *testing.T) {
+	db := setupTestDB()
+	ids := []int{5, 4, 3, 2, 1}
+
+	users, paginationResponse, err := PaginateByIDs[TestUser](
+		db, ids, PaginationRequest{Page: 2, PerPage: 2}, BatchByIDsOptions{TableName: "test_users"},
+	)
+
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, uint(3), users[0].ID)
+	assert.Equal(t, uint(2), users[1].ID)
+	assert.Equal(t, int64(5), paginationResponse.Total)
+	assert.Equal(t, int64(3), paginationResponse.MaxPage)
+}
@@ -0,0 +1,99 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// bigQueryResultTTL matches how long BigQuery keeps the results of a query job
+const bigQueryResultTTL = 24 * time.Hour
+
+// BigQueryJob identifies the query job whose results are paged through
+type BigQueryJob struct {
+	ProjectID string `json:"p"`
+	JobID     string `json:"j"`
+	Location  string `json:"l,omitempty"`
+}
+
+// BigQueryPage is one page of job results as returned by the BigQuery API
+type BigQueryPage[T any] struct {
+	Rows          []T
+	NextPageToken string
+	TotalRows     uint64
+}
+
+// BigQuerySource runs a BigQuery query and reads its results page by page. With the
+// cloud.google.com/go/bigquery client, Run wraps query.Run and Fetch wraps
+// iterator.NewPager(job.Read(ctx), pageSize, pageToken).NextPage.
+type BigQuerySource[T any] struct {
+	// Run starts the query job for the first page
+	Run func(ctx context.Context) (BigQueryJob, error)
+	// Fetch reads the page of the job results at pageToken, "" for the first page
+	Fetch func(ctx context.Context, job BigQueryJob, pageToken string, pageSize int) (BigQueryPage[T], error)
+	// Scope binds cursors to the report parameters, see CursorScope
+	Scope string
+}
+
+// BigQueryResult holds one page of a BigQuery listing
+type BigQueryResult[T any] struct {
+	Data       []T    `json:"data"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	HasMore    bool   `json:"has_more"`
+	Total      int64  `json:"total"`
+}
+
+// bigQueryCursor is the decoded form of a BigQuery cursor
+type bigQueryCursor struct {
+	Job       BigQueryJob `json:"j"`
+	PageToken string      `json:"t"`
+}
+
+// PaginateBigQuery returns the page of source following cursor. The first page runs the
+// query; later pages read the results of the same job with the BigQuery page token kept
+// in the opaque cursor, so every page comes from one consistent snapshot. Cursors expire
+// with the job results after 24 hours.
+func PaginateBigQuery[T any](ctx context.Context, source BigQuerySource[T], cursor string, pageSize int) (BigQueryResult[T], error) {
+	if pageSize <= 0 {
+		pageSize = CurrentConfig().DefaultPageSize
+	}
+
+	var position bigQueryCursor
+	if cursor != "" {
+		if err := decodeScopedToken(cursor, source.Scope, &position); err != nil {
+			return BigQueryResult[T]{}, err
+		}
+	} else {
+		job, err := source.Run(ctx)
+		if err != nil {
+			return BigQueryResult[T]{}, fmt.Errorf("failed to run BigQuery job: %w", err)
+		}
+		position.Job = job
+	}
+
+	page, err := source.Fetch(ctx, position.Job, position.PageToken, pageSize)
+	if err != nil {
+		return BigQueryResult[T]{}, fmt.Errorf("failed to read BigQuery job %s: %w", position.Job.JobID, err)
+	}
+
+	result := BigQueryResult[T]{
+		Data:    page.Rows,
+		HasMore: page.NextPageToken != "",
+		Total:   int64(page.TotalRows),
+	}
+	if result.Data == nil {
+		result.Data = []T{}
+	}
+	if result.HasMore {
+		// A shorter cursor TTL still applies
+		ttl := bigQueryResultTTL
+		if configured := cursorTTL(); configured > 0 && configured < ttl {
+			ttl = configured
+		}
+		next := bigQueryCursor{Job: position.Job, PageToken: page.NextPageToken}
+		if result.NextCursor, err = encodeExpiringToken(next, source.Scope, ttl); err != nil {
+			return BigQueryResult[T]{}, err
+		}
+	}
+	return result, nil
+}
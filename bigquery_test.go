@@ -0,0 +1,95 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeBigQuery serves job results of 0..rows-1 with numeric page tokens like the API
+type fakeBigQuery struct {
+	rows int
+	runs int
+	jobs []BigQueryJob
+}
+
+func (f *fakeBigQuery) source(scope string) BigQuerySource[int] {
+	return BigQuerySource[int]{
+		Scope: scope,
+		Run: func(ctx context.Context) (BigQueryJob, error) {
+			f.runs++
+			return BigQueryJob{ProjectID: "reports", JobID: "job_" + strconv.Itoa(f.runs), Location: "EU"}, nil
+		},
+		Fetch: func(ctx context.Context, job BigQueryJob, pageToken string, pageSize int) (BigQueryPage[int], error) {
+			f.jobs = append(f.jobs, job)
+			start := 0
+			if pageToken != "" {
+				start, _ = strconv.Atoi(pageToken)
+			}
+			page := BigQueryPage[int]{TotalRows: uint64(f.rows)}
+			for i := start; i < f.rows && i < start+pageSize; i++ {
+				page.Rows = append(page.Rows, i)
+			}
+			if start+pageSize < f.rows {
+				page.NextPageToken = strconv.Itoa(start + pageSize)
+			}
+			return page, nil
+		},
+	}
+}
+
+func TestPaginateBigQuery_FollowsPageTokens(t *testing.T) {
+	fake := &fakeBigQuery{rows: 5}
+	source := fake.source("")
+
+	var all []int
+	cursor := ""
+	for pages := 0; ; pages++ {
+		assert.Less(t, pages, 3)
+		result, err := PaginateBigQuery(context.Background(), source, cursor, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), result.Total)
+		all = append(all, result.Data...)
+		if !result.HasMore {
+			assert.Empty(t, result.NextCursor)
+			break
+		}
+		assert.NotContains(t, result.NextCursor, "job_1", "cursors are opaque")
+		cursor = result.NextCursor
+	}
+
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, all)
+	assert.Equal(t, 1, fake.runs, "later pages read the results of the first job")
+	for _, job := range fake.jobs {
+		assert.Equal(t, BigQueryJob{ProjectID: "reports", JobID: "job_1", Location: "EU"}, job)
+	}
+}
+
+func TestPaginateBigQuery_Scope(t *testing.T) {
+	fake := &fakeBigQuery{rows: 5}
+	first, err := PaginateBigQuery(context.Background(), fake.source(CursorScope("2024-01")), "", 2)
+	assert.NoError(t, err)
+
+	_, err = PaginateBigQuery(context.Background(), fake.source(CursorScope("2024-02")), first.NextCursor, 2)
+	assert.ErrorIs(t, err, ErrTokenScope)
+
+	_, err = PaginateBigQuery(context.Background(), fake.source(""), "garbage", 2)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestPaginateBigQuery_Errors(t *testing.T) {
+	source := BigQuerySource[int]{
+		Run: func(ctx context.Context) (BigQueryJob, error) { return BigQueryJob{}, errors.New("quota exceeded") },
+	}
+	_, err := PaginateBigQuery(context.Background(), source, "", 10)
+	assert.ErrorContains(t, err, "failed to run BigQuery job: quota exceeded")
+
+	fake := &fakeBigQuery{rows: 0}
+	result, err := PaginateBigQuery(context.Background(), fake.source(""), "", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{}, result.Data)
+	assert.False(t, result.HasMore)
+}
@@ -0,0 +1,81 @@
+package pagination
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FieldBounds holds the smallest and largest value of a column; both are nil for an empty set
+type FieldBounds struct {
+	Min interface{} `json:"min"`
+	Max interface{} `json:"max"`
+}
+
+// BoundsProvider is implemented by builders declaring numeric or date columns reported with their range
+type BoundsProvider interface {
+	GetBoundsFields() []string
+}
+
+// Bounds returns the MIN and MAX of each column over the filtered set of builder in one query
+func Bounds(db *gorm.DB, builder QueryBuilder, pagination PaginationRequest, columns ...string) (map[string]FieldBounds, error) {
+	if len(columns) == 0 {
+		return nil, nil
+	}
+
+	selects := make([]string, 0, len(columns)*2)
+	for _, column := range columns {
+		if !isValidSortField(column) {
+			return nil, fmt.Errorf("invalid bounds column %q", column)
+		}
+		selects = append(selects, "MIN("+column+")", "MAX("+column+")")
+	}
+
+	values := make([]interface{}, len(selects))
+	targets := make([]interface{}, len(selects))
+	for i := range values {
+		targets[i] = &values[i]
+	}
+	if err := filteredSet(db, builder, pagination).Select(strings.Join(selects, ", ")).Row().Scan(targets...); err != nil {
+		return nil, fmt.Errorf("failed to read bounds: %w", err)
+	}
+
+	bounds := make(map[string]FieldBounds, len(columns))
+	for i, column := range columns {
+		bounds[column] = FieldBounds{Min: boundValue(values[2*i]), Max: boundValue(values[2*i+1])}
+	}
+	return bounds, nil
+}
+
+// FilterBounds computes the bounds declared by a BoundsProvider builder; it returns nil for other builders
+func FilterBounds(db *gorm.DB, builder QueryBuilder, pagination PaginationRequest) (map[string]FieldBounds, error) {
+	provider, ok := builder.(BoundsProvider)
+	if !ok {
+		return nil, nil
+	}
+	return Bounds(db, builder, pagination, provider.GetBoundsFields()...)
+}
+
+// boundValue converts the text some drivers return for aggregates into a number or time
+func boundValue(value interface{}) interface{} {
+	text, ok := value.(string)
+	if raw, isBytes := value.([]byte); isBytes {
+		text, ok = string(raw), true
+	}
+	if !ok {
+		return value
+	}
+
+	if number, err := strconv.ParseFloat(text, 64); err == nil {
+		return number
+	}
+	for _, layout := range lastModifiedLayouts {
+		if parsed, err := time.Parse(layout, text); err == nil {
+			return parsed
+		}
+	}
+	return text
+}
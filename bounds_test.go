@@ -0,0 +1,67 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type boundsUserFilter struct {
+	testUserFilter
+}
+
+func (f *boundsUserFilter) GetBoundsFields() []string {
+	return []string{"age"}
+}
+
+func TestBounds(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users").
+		WithFilters(func(query *gorm.DB) *gorm.DB { return query.Where("age < ?", 35) })
+
+	bounds, err := Bounds(db, builder, PaginationRequest{}, "age", "id")
+	assert.NoError(t, err)
+	assert.EqualValues(t, 25, bounds["age"].Min)
+	assert.EqualValues(t, 32, bounds["age"].Max)
+	assert.EqualValues(t, 5, bounds["id"].Max)
+
+	empty := NewSimpleQueryBuilder("test_users").
+		WithFilters(func(query *gorm.DB) *gorm.DB { return query.Where("age > ?", 100) })
+	bounds, err = Bounds(db, empty, PaginationRequest{}, "age")
+	assert.NoError(t, err)
+	assert.Equal(t, FieldBounds{}, bounds["age"])
+
+	_, err = Bounds(db, builder, PaginationRequest{}, "age); --")
+	assert.Error(t, err)
+}
+
+func TestBounds_Dates(t *testing.T) {
+	db := setupChangesDB()
+
+	bounds, err := FilterBounds(db, NewSimpleQueryBuilder("test_changes").WithBounds("updated_at"), PaginationRequest{})
+	assert.NoError(t, err)
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.True(t, base.Equal(bounds["updated_at"].Min.(time.Time)))
+	assert.True(t, base.Add(2*time.Hour).Equal(bounds["updated_at"].Max.(time.Time)))
+}
+
+func TestPaginateWithCustomFilter_Bounds(t *testing.T) {
+	db := setupTestDB()
+	request := newTestRequest("/users?search=o")
+
+	_, meta, err := PaginateRequest[TestUser](db, request, &boundsUserFilter{})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 25, meta.Bounds["age"].Min)
+	assert.EqualValues(t, 35, meta.Bounds["age"].Max)
+	assert.Nil(t, meta.Histograms)
+}
+
+func TestBoundValue(t *testing.T) {
+	assert.Equal(t, 12.5, boundValue([]byte("12.5")))
+	assert.Equal(t, "abc", boundValue("abc"))
+	assert.Equal(t, int64(3), boundValue(int64(3)))
+	assert.Nil(t, boundValue(nil))
+}
@@ -0,0 +1,165 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ErrBudgetExhausted is returned by paginated queries once the budget of the request is used up
+var ErrBudgetExhausted = errors.New("pagination budget exhausted")
+
+type budgetKey struct{}
+
+// Budget caps the combined cost of the paginated queries of one request, e.g. a
+// dashboard listing several collections. Every PaginatedQuery call running with a
+// context carrying the budget spends from it: all calls share one deadline, and a
+// page is shortened to the rows left. Calls fail with ErrBudgetExhausted once the
+// time or the rows are used up.
+type Budget struct {
+	deadline time.Time
+
+	mu        sync.Mutex
+	rowsLeft  int
+	rowLimit  bool
+	queries   int
+	truncated bool
+}
+
+// NewBudget starts a budget of timeLimit from now and rows fetched rows;
+// zero leaves either unlimited
+func NewBudget(timeLimit time.Duration, rows int) *Budget {
+	budget := &Budget{rowsLeft: rows, rowLimit: rows > 0}
+	if timeLimit > 0 {
+		budget.deadline = time.Now().Add(timeLimit)
+	}
+	return budget
+}
+
+// WithBudget returns a context carrying budget, pass it to the queries with db.WithContext
+func WithBudget(ctx context.Context, budget *Budget) context.Context {
+	return context.WithValue(ctx, budgetKey{}, budget)
+}
+
+// BudgetFromContext returns the budget carried by ctx
+func BudgetFromContext(ctx context.Context) (*Budget, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	budget, ok := ctx.Value(budgetKey{}).(*Budget)
+	return budget, ok && budget != nil
+}
+
+// RemainingRows returns the rows left; ok is false without a row limit
+func (b *Budget) RemainingRows() (rows int, ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.rowsLeft, b.rowLimit
+}
+
+// RemainingTime returns the time left; ok is false without a time limit
+func (b *Budget) RemainingTime() (remaining time.Duration, ok bool) {
+	if b.deadline.IsZero() {
+		return 0, false
+	}
+	if remaining = time.Until(b.deadline); remaining < 0 {
+		remaining = 0
+	}
+	return remaining, true
+}
+
+// Queries returns the number of paginated queries that ran within the budget
+func (b *Budget) Queries() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.queries
+}
+
+// Truncated reports whether a page was shortened to the rows left
+func (b *Budget) Truncated() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.truncated
+}
+
+// reserve admits one more query wanting up to want rows, zero for all of them, and
+// returns the rows it may fetch, zero when unlimited. The rows are taken from the budget
+// right away so concurrent queries cannot spend them twice.
+func (b *Budget) reserve(want int) (int, error) {
+	if remaining, ok := b.RemainingTime(); ok && remaining == 0 {
+		return 0, fmt.Errorf("%w: no time left", ErrBudgetExhausted)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.rowLimit {
+		b.queries++
+		return 0, nil
+	}
+	if b.rowsLeft <= 0 {
+		return 0, fmt.Errorf("%w: no rows left", ErrBudgetExhausted)
+	}
+
+	granted := b.rowsLeft
+	if want > 0 && want < granted {
+		granted = want
+	}
+	b.rowsLeft -= granted
+	b.queries++
+	return granted, nil
+}
+
+// refund returns reserved rows that were not fetched; shortened marks a page cut off
+// by the rows left
+func (b *Budget) refund(rows int, shortened bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.rowLimit {
+		b.rowsLeft += rows
+	}
+	b.truncated = b.truncated || shortened
+}
+
+// budgetedQuery runs a paginated query within the budget of its context
+func budgetedQuery[T any](
+	db *gorm.DB,
+	budget *Budget,
+	builder QueryBuilder,
+	pagination PaginationRequest,
+	includes []string,
+	options PaginatedQueryOptions,
+) ([]T, int64, error) {
+	want := pagination.GetLimit()
+	if pagination.IsDisabled {
+		want = 0
+	}
+	rows, err := budget.reserve(want)
+	if err != nil {
+		return nil, 0, err
+	}
+	options.rowLimit = rows
+
+	if !budget.deadline.IsZero() {
+		ctx, cancel := context.WithDeadline(db.Statement.Context, budget.deadline)
+		defer cancel()
+		db = db.WithContext(ctx)
+	}
+
+	result, total, err := paginatedQuery[T](db, builder, pagination, includes, options)
+	if err != nil {
+		budget.refund(rows, false)
+		if remaining, ok := budget.RemainingTime(); ok && remaining == 0 {
+			return nil, 0, fmt.Errorf("%w: %v", ErrBudgetExhausted, err)
+		}
+		return nil, 0, err
+	}
+
+	// Filling all granted rows of fewer than requested may have cut the page short
+	shortened := rows > 0 && len(result) == rows && rows != want
+	budget.refund(rows-len(result), shortened)
+	return result, total, nil
+}
@@ -0,0 +1,105 @@
+package pagination
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBudget_SharesRowsAcrossQueries(t *testing.T) {
+	budget := NewBudget(0, 7)
+	db := setupTestDB().WithContext(WithBudget(context.Background(), budget))
+	builder := NewSimpleQueryBuilder("test_users")
+	options := PaginatedQueryOptions{Dialect: SQLite}
+
+	first, _, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 3}, nil, options)
+	assert.NoError(t, err)
+	assert.Len(t, first, 3)
+
+	second, _, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 2, PerPage: 3}, nil, options)
+	assert.NoError(t, err)
+	assert.Len(t, second, 2, "only fetched rows are spent")
+	assert.False(t, budget.Truncated())
+
+	third, total, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 3}, nil, options)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.Equal(t, []string{"John Doe", "Jane Smith"}, userNames(third), "the page is cut to the rows left")
+	assert.True(t, budget.Truncated())
+
+	_, _, err = PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 3}, nil, options)
+	assert.ErrorIs(t, err, ErrBudgetExhausted)
+
+	rows, limited := budget.RemainingRows()
+	assert.True(t, limited)
+	assert.Equal(t, 0, rows)
+	assert.Equal(t, 3, budget.Queries())
+}
+
+func TestBudget_CapsDisabledPagination(t *testing.T) {
+	budget := NewBudget(0, 4)
+	db := setupTestDB().WithContext(WithBudget(context.Background(), budget))
+
+	users, _, err := PaginatedQueryWithOptions[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 1, PerPage: 10, IsDisabled: true}, nil, PaginatedQueryOptions{Dialect: SQLite, PrepareStatements: true})
+	assert.NoError(t, err)
+	assert.Len(t, users, 4)
+	assert.True(t, budget.Truncated())
+}
+
+func TestBudget_ConcurrentQueriesDoNotOverspend(t *testing.T) {
+	budget := NewBudget(0, 6)
+	db := setupTestDB()
+	// Every connection to :memory: opens an empty database
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	db = db.WithContext(WithBudget(context.Background(), budget))
+	builder := NewSimpleQueryBuilder("test_users")
+
+	var mu sync.Mutex
+	fetched := 0
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			users, _, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil, PaginatedQueryOptions{Dialect: SQLite})
+			if err != nil {
+				assert.ErrorIs(t, err, ErrBudgetExhausted)
+			}
+			mu.Lock()
+			fetched += len(users)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 6, fetched)
+}
+
+func TestBudget_SharesDeadline(t *testing.T) {
+	budget := NewBudget(time.Millisecond, 0)
+	db := setupTestDB().WithContext(WithBudget(context.Background(), budget))
+	time.Sleep(2 * time.Millisecond)
+
+	_, _, err := PaginatedQueryWithOptions[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 1, PerPage: 2}, nil, PaginatedQueryOptions{Dialect: SQLite})
+	assert.ErrorIs(t, err, ErrBudgetExhausted)
+
+	remaining, ok := budget.RemainingTime()
+	assert.True(t, ok)
+	assert.Zero(t, remaining)
+	_, limited := budget.RemainingRows()
+	assert.False(t, limited)
+}
+
+func TestBudgetFromContext(t *testing.T) {
+	_, ok := BudgetFromContext(context.Background())
+	assert.False(t, ok)
+
+	budget := NewBudget(time.Second, 10)
+	found, ok := BudgetFromContext(WithBudget(context.Background(), budget))
+	assert.True(t, ok)
+	assert.Same(t, budget, found)
+}
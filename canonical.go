@@ -0,0 +1,118 @@
+package pagination
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// CanonicalOptions configures CanonicalHandler
+type CanonicalOptions struct {
+	// Table applies the page size and sort direction defaults registered for the table
+	Table string
+	// Redirect answers non-canonical URLs with RedirectStatus (default 301) instead of
+	// rewriting the request in place
+	Redirect       bool
+	RedirectStatus int
+	// LowercaseParams are filter parameters whose values are case-insensitive
+	LowercaseParams []string
+	// DropParams are removed, e.g. tracking parameters that do not change the listing
+	DropParams []string
+}
+
+// CanonicalQuery returns the canonical form of a listing query: pagination parameters
+// bound and made explicit, a sort sign turned into order, empty and dropped parameters
+// removed, listed values lowercased, and parameters sorted by name
+func CanonicalQuery(request *http.Request, options CanonicalOptions) string {
+	// Bind without the request context so adaptive page sizes, which change with load,
+	// stay out of the canonical URL
+	query := request.URL.Query()
+	var pagination PaginationRequest
+	if options.Table != "" {
+		pagination, _ = bindTablePagination(context.Background(), query, options.Table)
+	} else {
+		pagination, _ = BindPaginationValues(query)
+	}
+
+	for _, name := range options.DropParams {
+		query.Del(name)
+	}
+	for _, name := range options.LowercaseParams {
+		for i, value := range query[name] {
+			query[name][i] = strings.ToLower(value)
+		}
+	}
+	for name, values := range query {
+		kept := values[:0]
+		for _, value := range values {
+			if value != "" {
+				kept = append(kept, value)
+			}
+		}
+		if len(kept) == 0 {
+			query.Del(name)
+		} else {
+			query[name] = kept
+		}
+	}
+
+	pageParam, sizeParam := CurrentConfig().ParamStyle.pageParams()
+	if CurrentConfig().ParamStyle == ParamStyleOffset {
+		query.Set(pageParam, strconv.Itoa(pagination.GetOffset()))
+	} else {
+		query.Set(pageParam, strconv.Itoa(pagination.Page))
+	}
+	query.Set(sizeParam, strconv.Itoa(pagination.PerPage))
+	if pagination.Sort != "" && isValidSort(pagination.Sort) {
+		query.Set("sort", pagination.Sort)
+		query.Set("order", pagination.Order)
+	} else {
+		query.Del("sort")
+		query.Del("order")
+	}
+	if pagination.SearchMode != "" {
+		query.Set("search_mode", string(pagination.SearchMode))
+	} else {
+		query.Del("search_mode")
+	}
+	if pagination.IsDisabled {
+		query.Set("is_disabled", "true")
+	} else {
+		query.Del("is_disabled")
+	}
+
+	// Encode sorts by name
+	return query.Encode()
+}
+
+// CanonicalHandler normalizes listing queries so equivalent URLs share one CDN cache
+// entry. Mount it before anything reading the query, on public listings only.
+func CanonicalHandler(options CanonicalOptions) func(http.Handler) http.Handler {
+	status := options.RedirectStatus
+	if status == 0 {
+		status = http.StatusMovedPermanently
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			canonical := CanonicalQuery(r, options)
+			if canonical == r.URL.RawQuery {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			target := *r.URL
+			target.RawQuery = canonical
+			if options.Redirect {
+				http.Redirect(w, r, target.RequestURI(), status)
+				return
+			}
+
+			r = r.Clone(r.Context())
+			r.URL = &target
+			r.RequestURI = target.RequestURI()
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package pagination
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanonicalQuery(t *testing.T) {
+	options := CanonicalOptions{LowercaseParams: []string{"status"}, DropParams: []string{"utm_source"}}
+	canonical := func(target string) string {
+		return CanonicalQuery(httptest.NewRequest("GET", target, nil), options)
+	}
+
+	assert.Equal(t, "page=1&per_page=10", canonical("/users"))
+	assert.Equal(t, canonical("/users?status=Active&page=2&sort=-age"), canonical("/users?sort=age&order=desc&page=2&status=active&utm_source=mail&search="))
+	assert.Equal(t, "order=desc&page=2&per_page=10&sort=age&status=active", canonical("/users?status=Active&page=2&sort=-age"))
+	assert.Equal(t, "page=1&per_page=10", canonical("/users?page=0&per_page=-3&order=sideways"), "invalid values take their defaults")
+	assert.Equal(t, "page=1&per_page=10&tag=b&tag=a", canonical("/users?tag=b&tag=a"), "repeated values keep their order")
+}
+
+func TestCanonicalQuery_TableDefaults(t *testing.T) {
+	registerTestTable(t, TableConfig{DefaultPageSize: 25, SortDirections: map[string]string{"age": "desc"}})
+
+	query := CanonicalQuery(httptest.NewRequest("GET", "/users?sort=age", nil), CanonicalOptions{Table: "test_users"})
+	assert.Equal(t, "order=desc&page=1&per_page=25&sort=age", query)
+}
@@ -0,0 +1,183 @@
+package pagination
+
+import (
+	"net/http"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// SortableFieldsProvider is implemented by filters declaring the fields clients may sort by
+type SortableFieldsProvider interface {
+	GetSortableFields() []string
+}
+
+// FilterCapabilities describes the query parameters a filter understands, so frontends
+// can build filter UIs without hard-coding them
+type FilterCapabilities struct {
+	Table       string             `json:"table"`
+	Filters     []FilterCapability `json:"filters"`
+	Sortable    []string           `json:"sortable,omitempty"`
+	SortPresets []string           `json:"sort_presets,omitempty"`
+	Searchable  []string           `json:"searchable"`
+	Includes    []string           `json:"includes,omitempty"`
+	DefaultSort string             `json:"default_sort"`
+	// SortDirections are the directions of sort fields given without a sign
+	SortDirections map[string]string `json:"sort_directions,omitempty"`
+}
+
+// FilterCapability is one filterable field with the operators it supports
+type FilterCapability struct {
+	Field     string           `json:"field"`
+	Type      string           `json:"type"`
+	Operators []FilterOperator `json:"operators"`
+}
+
+// FilterOperator names the query parameter applying an operator to a field
+type FilterOperator struct {
+	Operator string `json:"operator"`
+	Param    string `json:"param"`
+}
+
+// Capabilities describes filter from its form tags and the provider interfaces it
+// implements. Each form field is an operator on a field: the filter tag names both, as in
+// `form:"min_age" filter:"column=age,op=gte"`; without it the parameter is its own field,
+// compared with "eq", or "in" for slices. Fields tagged `filter:"-"` are left out.
+func Capabilities(filter QueryBuilder) FilterCapabilities {
+	capabilities := FilterCapabilities{
+		Table:       filter.GetTableName(),
+		Filters:     []FilterCapability{},
+		Searchable:  append([]string{}, filter.GetSearchFields()...),
+		DefaultSort: filter.GetDefaultSort(),
+	}
+
+	index := map[string]int{}
+	for _, param := range filterParams(reflect.ValueOf(filter), nil) {
+		i, ok := index[param.field]
+		if !ok {
+			i = len(capabilities.Filters)
+			index[param.field] = i
+			capabilities.Filters = append(capabilities.Filters, FilterCapability{Field: param.field, Type: param.kind})
+		}
+		capabilities.Filters[i].Operators = append(capabilities.Filters[i].Operators, FilterOperator{Operator: param.operator, Param: param.name})
+	}
+
+	if provider, ok := filter.(SortableFieldsProvider); ok {
+		capabilities.Sortable = provider.GetSortableFields()
+	}
+	if provider, ok := filter.(SortPresetProvider); ok {
+		capabilities.SortPresets = sortedKeys(provider.GetSortPresets())
+	}
+	if provider, ok := filter.(SortDirectionProvider); ok {
+		capabilities.SortDirections = provider.GetSortDirections()
+	}
+
+	if provider, ok := filter.(AllowedIncludesProvider); ok {
+		capabilities.Includes = sortedKeys(provider.GetAllowedIncludes())
+	} else if config, ok := LookupTable(filter.GetTableName()); ok {
+		capabilities.Includes = config.AllowedIncludes
+	}
+	return capabilities
+}
+
+// CapabilitiesHTTPHandler serves Capabilities of filter as JSON
+func CapabilitiesHTTPHandler(filter QueryBuilder) http.Handler {
+	capabilities := Capabilities(filter)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, capabilities)
+	})
+}
+
+// filterParam is a form field of a filter struct
+type filterParam struct {
+	name     string
+	field    string
+	operator string
+	kind     string
+	// value is the field of the filter holding the bound parameter
+	value reflect.Value
+	// tagged is set when the filter tag names the column, which then must exist
+	tagged bool
+}
+
+// filterParams collects the form fields of a filter struct, including embedded structs
+func filterParams(value reflect.Value, params []filterParam) []filterParam {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value = reflect.New(value.Type().Elem())
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return params
+	}
+
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if name == "" || name == "-" {
+			if field.Anonymous {
+				params = filterParams(value.Field(i), params)
+			}
+			continue
+		}
+		if field.Tag.Get("filter") == "-" {
+			continue
+		}
+
+		param := filterParam{name: name, field: name, operator: "eq", kind: filterFieldKind(field.Type), value: value.Field(i)}
+		if field.Type.Kind() == reflect.Slice {
+			param.operator = "in"
+		}
+		for _, option := range strings.Split(field.Tag.Get("filter"), ",") {
+			key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+			switch key {
+			case "column":
+				param.field, param.tagged = value, true
+			case "op":
+				param.operator = value
+			}
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// filterFieldKind names the JSON type of a filter field
+func filterFieldKind(fieldType reflect.Type) string {
+	for fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType == reflect.TypeOf(time.Time{}) {
+		return "datetime"
+	}
+
+	switch fieldType.Kind() {
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "integer"
+	case reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.Slice, reflect.Array:
+		return filterFieldKind(fieldType.Elem())
+	}
+	return "string"
+}
+
+// sortedKeys returns the keys of a map in order
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,68 @@
+package pagination
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type catalogFilter struct {
+	BaseFilter
+	Name     string    `form:"name"`
+	MinAge   int       `form:"min_age" filter:"column=age,op=gte"`
+	MaxAge   int       `form:"max_age" filter:"column=age,op=lte"`
+	Plans    []string  `form:"plan"`
+	Verified *bool     `form:"verified"`
+	Since    time.Time `form:"since" filter:"column=created_at,op=gte"`
+	Internal string    `form:"-"`
+}
+
+func (f *catalogFilter) ApplyFilters(query *gorm.DB) *gorm.DB { return query }
+func (f *catalogFilter) GetTableName() string                 { return "test_users" }
+func (f *catalogFilter) GetDefaultSort() string               { return "id asc" }
+func (f *catalogFilter) GetSearchFields() []string            { return []string{"name", "email"} }
+func (f *catalogFilter) GetSortableFields() []string          { return []string{"name", "age"} }
+func (f *catalogFilter) GetSortPresets() map[string]string {
+	return map[string]string{"oldest": "age desc", "newest": "created_at desc"}
+}
+func (f *catalogFilter) GetAllowedIncludes() map[string]bool {
+	return map[string]bool{"orders": true, "profile": true}
+}
+
+func TestCapabilities(t *testing.T) {
+	capabilities := Capabilities(&catalogFilter{})
+
+	assert.Equal(t, "test_users", capabilities.Table)
+	assert.Equal(t, []FilterCapability{
+		{Field: "name", Type: "string", Operators: []FilterOperator{{"eq", "name"}}},
+		{Field: "age", Type: "integer", Operators: []FilterOperator{{"gte", "min_age"}, {"lte", "max_age"}}},
+		{Field: "plan", Type: "string", Operators: []FilterOperator{{"in", "plan"}}},
+		{Field: "verified", Type: "boolean", Operators: []FilterOperator{{"eq", "verified"}}},
+		{Field: "created_at", Type: "datetime", Operators: []FilterOperator{{"gte", "since"}}},
+	}, capabilities.Filters)
+	assert.Equal(t, []string{"name", "age"}, capabilities.Sortable)
+	assert.Equal(t, []string{"newest", "oldest"}, capabilities.SortPresets)
+	assert.Equal(t, []string{"name", "email"}, capabilities.Searchable)
+	assert.Equal(t, []string{"orders", "profile"}, capabilities.Includes)
+}
+
+func TestCapabilitiesHTTPHandler(t *testing.T) {
+	registerTestTable(t, TableConfig{AllowedIncludes: []string{"Orders"}})
+	router := http.NewServeMux()
+	router.Handle("GET /users/capabilities", CapabilitiesHTTPHandler(&testUserFilter{}))
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("GET", "/users/capabilities", nil))
+
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Equal(t, "test_users", body["table"])
+	assert.Equal(t, []interface{}{"Orders"}, body["includes"])
+	assert.Len(t, body["filters"], 2)
+	assert.NotContains(t, body, "sortable")
+}
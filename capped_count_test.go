@@ -0,0 +1,47 @@
+package pagination
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestPaginatedQuery_CountLimit(t *testing.T) {
+	db := setupTestDB()
+	statements := captureSQL(db)
+	builder := NewSimpleQueryBuilder("test_users")
+
+	users, total, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil, PaginatedQueryOptions{Dialect: SQLite, CountLimit: 3})
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, int64(3), total)
+	assert.Contains(t, *statements, "SELECT count(*) FROM (SELECT 1 FROM `test_users` LIMIT 3) capped")
+
+	_, total, err = PaginatedQueryWithOptions[TestUser](db, builder.WithFilters(func(query *gorm.DB) *gorm.DB {
+		return query.Where("age > ?", 30)
+	}), PaginationRequest{Page: 1, PerPage: 2}, nil, PaginatedQueryOptions{Dialect: SQLite, CountLimit: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total, "counts below the limit stay exact")
+}
+
+func TestCalculateCappedPagination(t *testing.T) {
+	meta := CalculateCappedPagination(PaginationRequest{Page: 1, PerPage: 10}, 10000, 10000)
+	assert.Equal(t, TotalRelationGTE, meta.TotalRelation)
+	assert.Equal(t, int64(1000), meta.MaxPage)
+
+	encoded, err := json.Marshal(meta)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"page":1,"per_page":10,"max_page":1000,"total":10000,"last_page":1000,"total_relation":"gte"}`, string(encoded))
+
+	assert.Equal(t, TotalRelationEQ, CalculateCappedPagination(PaginationRequest{Page: 1, PerPage: 10}, 42, 10000).TotalRelation)
+	assert.Empty(t, CalculateCappedPagination(PaginationRequest{Page: 1, PerPage: 10}, 42, 0).TotalRelation)
+}
+
+func TestLoadConfig_CountLimit(t *testing.T) {
+	useTestConfig(t, map[string]string{EnvCountLimit: "5000"})
+	assert.Equal(t, int64(5000), CurrentConfig().CountLimit)
+
+	assert.Error(t, LoadConfig(map[string]string{EnvCountLimit: "lots"}))
+}
@@ -0,0 +1,120 @@
+package pagination
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ChangeFeedOptions configures a change-feed query
+type ChangeFeedOptions struct {
+	TableName       string
+	UpdatedAtColumn string
+	IDColumn        string
+	Limit           int
+	FilterFunc      func(*gorm.DB) *gorm.DB
+	// Scope binds tokens to the filters in use, see CursorScope
+	Scope string
+}
+
+// ChangeFeedResult holds one batch of changed rows and the token to resume from
+type ChangeFeedResult[T any] struct {
+	Data      []T    `json:"data"`
+	NextToken string `json:"next_token"`
+	HasMore   bool   `json:"has_more"`
+}
+
+// changeToken is the decoded form of a change-feed token
+type changeToken struct {
+	UpdatedAt time.Time   `json:"u"`
+	ID        interface{} `json:"i"`
+}
+
+func (o *ChangeFeedOptions) validate() {
+	if o.UpdatedAtColumn == "" {
+		o.UpdatedAtColumn = "updated_at"
+	}
+	if o.IDColumn == "" {
+		o.IDColumn = "id"
+	}
+	if o.Limit <= 0 {
+		o.Limit = 100
+	}
+}
+
+// Changes returns rows created or updated after sinceToken ordered by (updated_at, id)
+// An empty sinceToken starts from the beginning of the table. When no rows changed
+// the returned NextToken equals sinceToken so consumers can poll with it again.
+func Changes[T any](db *gorm.DB, sinceToken string, options ChangeFeedOptions) (ChangeFeedResult[T], error) {
+	options.validate()
+
+	var result []T
+
+	query := db
+	if options.TableName != "" {
+		query = query.Table(options.TableName)
+	} else {
+		query = query.Model(new(T))
+	}
+
+	if options.FilterFunc != nil {
+		query = options.FilterFunc(query)
+	}
+
+	columns := []KeysetColumn{
+		{Name: options.UpdatedAtColumn},
+		{Name: options.IDColumn},
+	}
+
+	var values []interface{}
+	if sinceToken != "" {
+		var token changeToken
+		if err := decodeScopedToken(sinceToken, options.Scope, &token); err != nil {
+			return ChangeFeedResult[T]{}, err
+		}
+		values = []interface{}{token.UpdatedAt, normalizeTokenValue(token.ID)}
+	}
+
+	// Fetch one extra row to know whether more changes are pending
+	query = applyKeyset(query, columns, values).Limit(options.Limit + 1)
+	if err := query.Find(&result).Error; err != nil {
+		return ChangeFeedResult[T]{}, fmt.Errorf("failed to fetch changes: %w", err)
+	}
+
+	hasMore := len(result) > options.Limit
+	if hasMore {
+		result = result[:options.Limit]
+	}
+
+	nextToken := sinceToken
+	if len(result) > 0 {
+		keys, err := keysetValues(db, &result[len(result)-1], columns)
+		if err != nil {
+			return ChangeFeedResult[T]{}, err
+		}
+
+		token := changeToken{ID: keys[1]}
+		switch updatedAt := keys[0].(type) {
+		case time.Time:
+			token.UpdatedAt = updatedAt
+		case *time.Time:
+			if updatedAt != nil {
+				token.UpdatedAt = *updatedAt
+			}
+		default:
+			return ChangeFeedResult[T]{}, fmt.Errorf("column %s must be a time.Time", options.UpdatedAtColumn)
+		}
+
+		nextToken, err = encodeScopedToken(token, options.Scope)
+		if err != nil {
+			return ChangeFeedResult[T]{}, err
+		}
+	}
+
+	return ChangeFeedResult[T]{
+		Data:      result,
+		NextToken: nextToken,
+		HasMore:   hasMore,
+	}, nil
+}
@@ -0,0 +1,89 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type TestChange struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func setupChangesDB() *gorm.DB {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	db.AutoMigrate(&TestChange{})
+
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	changes := []TestChange{
+		{Name: "a", UpdatedAt: base},
+		{Name: "b", UpdatedAt: base.Add(time.Hour)},
+		{Name: "c", UpdatedAt: base},
+		{Name: "d", UpdatedAt: base.Add(2 * time.Hour)},
+	}
+
+	for _, change := range changes {
+		db.Create(&change)
+	}
+
+	return db
+}
+
+func TestChanges(t *testing.T) {
+	db := setupChangesDB()
+	options := ChangeFeedOptions{TableName: "test_changes", Limit: 2}
+
+	first, err := Changes[TestChange](db, "", options)
+	assert.NoError(t, err)
+	assert.True(t, first.HasMore)
+	assert.Len(t, first.Data, 2)
+	assert.Equal(t, "a", first.Data[0].Name)
+	assert.Equal(t, "c", first.Data[1].Name)
+
+	second, err := Changes[TestChange](db, first.NextToken, options)
+	assert.NoError(t, err)
+	assert.False(t, second.HasMore)
+	assert.Len(t, second.Data, 2)
+	assert.Equal(t, "b", second.Data[0].Name)
+	assert.Equal(t, "d", second.Data[1].Name)
+
+	empty, err := Changes[TestChange](db, second.NextToken, options)
+	assert.NoError(t, err)
+	assert.Len(t, empty.Data, 0)
+	assert.Equal(t, second.NextToken, empty.NextToken)
+
+	// Updating a row makes it reappear after the last token
+	db.Model(&TestChange{}).Where("name = ?", "a").Update("updated_at", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	updated, err := Changes[TestChange](db, second.NextToken, options)
+	assert.NoError(t, err)
+	assert.Len(t, updated.Data, 1)
+	assert.Equal(t, "a", updated.Data[0].Name)
+}
+
+func TestChanges_InvalidToken(t *testing.T) {
+	db := setupChangesDB()
+
+	_, err := Changes[TestChange](db, "not-a-token!", ChangeFeedOptions{TableName: "test_changes"})
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestChanges_ScopeMismatch(t *testing.T) {
+	db := setupChangesDB()
+	options := ChangeFeedOptions{TableName: "test_changes", Limit: 2, Scope: CursorScope("name", "a")}
+
+	first, err := Changes[TestChange](db, "", options)
+	assert.NoError(t, err)
+
+	_, err = Changes[TestChange](db, first.NextToken, options)
+	assert.NoError(t, err)
+
+	options.Scope = CursorScope("name", "b")
+	_, err = Changes[TestChange](db, first.NextToken, options)
+	assert.ErrorIs(t, err, ErrTokenScope)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
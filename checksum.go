@@ -0,0 +1,90 @@
+package pagination
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// PageChecksum hashes the primary keys and versions of the rows of a page into
+// meta.checksum, so clients resuming a listing can tell that a page they fetched
+// before has changed and refetch it. The checksum only changes when a row of the page
+// is added, removed, reordered or gets a new version.
+type PageChecksum struct {
+	// Columns are hashed for every row; by default the primary key and, when the model
+	// tracks update times, its update time column
+	Columns []string
+
+	sum string
+}
+
+// Sum returns the checksum of the page after the query ran
+func (c *PageChecksum) Sum() string {
+	if c == nil {
+		return ""
+	}
+	return c.sum
+}
+
+// ChecksumOf returns the checksum PageChecksum computes for items, hashing the given
+// columns or the default ones when none are given
+func ChecksumOf[T any](db *gorm.DB, items []T, columns ...string) (string, error) {
+	if len(columns) == 0 {
+		var err error
+		if columns, err = versionColumns[T](db); err != nil {
+			return "", err
+		}
+	}
+
+	rows := make([][]interface{}, len(items))
+	for i := range items {
+		rows[i] = make([]interface{}, len(columns))
+		for j, column := range columns {
+			value, err := columnValue(db, &items[i], column)
+			if err != nil {
+				return "", err
+			}
+			rows[i][j] = value
+		}
+	}
+
+	encoded, err := json.Marshal(rows)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode page checksum: %w", err)
+	}
+	hash := sha256.Sum256(encoded)
+	return hex.EncodeToString(hash[:16]), nil
+}
+
+// checksumPage sets the checksum of the rows of result
+func checksumPage[T any](db *gorm.DB, c *PageChecksum, result []T) error {
+	sum, err := ChecksumOf(db, result, c.Columns...)
+	if err != nil {
+		return err
+	}
+	c.sum = sum
+	return nil
+}
+
+// versionColumns returns the primary key and update time columns of model T
+func versionColumns[T any](db *gorm.DB) ([]string, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, fmt.Errorf("failed to parse model schema: %w", err)
+	}
+
+	columns := []string{"id"}
+	if field := stmt.Schema.PrioritizedPrimaryField; field != nil {
+		columns[0] = field.DBName
+	}
+	for _, field := range stmt.Schema.Fields {
+		if field.AutoUpdateTime > 0 && field.DBName != "" {
+			columns = append(columns, field.DBName)
+			break
+		}
+	}
+	return columns, nil
+}
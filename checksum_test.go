@@ -0,0 +1,54 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPageChecksum(t *testing.T) {
+	db := setupChangesDB()
+	builder := NewSimpleQueryBuilder("test_changes").WithDefaultSort("id asc")
+	request := PaginationRequest{Page: 1, PerPage: 2}
+	checksum := func() string {
+		options := PaginatedQueryOptions{Dialect: SQLite, Checksum: &PageChecksum{}}
+		_, _, err := PaginatedQueryWithOptions[TestChange](db, builder, request, nil, options)
+		assert.NoError(t, err)
+		return options.Checksum.Sum()
+	}
+
+	first := checksum()
+	assert.Len(t, first, 32)
+	assert.Equal(t, first, checksum(), "unchanged pages keep their checksum")
+
+	db.Model(&TestChange{}).Where("name = ?", "d").Update("name", "e")
+	assert.Equal(t, first, checksum(), "rows of other pages do not count")
+
+	db.Model(&TestChange{}).Where("name = ?", "b").Update("updated_at", time.Now())
+	updated := checksum()
+	assert.NotEqual(t, first, updated, "a new version of a row changes the checksum")
+
+	db.Where("name = ?", "a").Delete(&TestChange{})
+	assert.NotEqual(t, updated, checksum(), "a removed row changes the checksum")
+}
+
+func TestChecksumOfColumns(t *testing.T) {
+	db := setupTestDB()
+	var users []TestUser
+	db.Order("id").Limit(2).Find(&users)
+
+	byID, err := ChecksumOf(db, users)
+	assert.NoError(t, err)
+	byAge, err := ChecksumOf(db, users, "id", "age")
+	assert.NoError(t, err)
+	assert.NotEqual(t, byID, byAge)
+
+	users[0].Name = "Johnny"
+	renamed, err := ChecksumOf(db, users)
+	assert.NoError(t, err)
+	assert.Equal(t, byID, renamed, "models without an update time hash their keys only")
+
+	_, err = ChecksumOf(db, users, "missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,91 @@
+package pagination
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// clickHouseSettingsClause is built after every other clause of ClickHouse queries
+const clickHouseSettingsClause = "PAGINATION:SETTINGS"
+
+// clickHouseSettings renders a ClickHouse SETTINGS clause, sorted so identical
+// settings produce identical SQL
+type clickHouseSettings map[string]string
+
+// Name implements clause.Interface
+func (clickHouseSettings) Name() string {
+	return clickHouseSettingsClause
+}
+
+// Build implements clause.Expression
+func (s clickHouseSettings) Build(builder clause.Builder) {
+	keys := make([]string, 0, len(s))
+	for key := range s {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	builder.WriteString("SETTINGS ")
+	for i, key := range keys {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		builder.WriteString(key + " = " + clickHouseSettingValue(s[key]))
+	}
+}
+
+// MergeClause implements clause.Interface
+func (s clickHouseSettings) MergeClause(c *clause.Clause) {
+	c.Name = ""
+	c.Expression = s
+}
+
+// clickHouseSettingValue keeps numbers as they are and quotes everything else
+func clickHouseSettingValue(value string) string {
+	if _, err := strconv.ParseFloat(value, 64); err == nil {
+		return value
+	}
+	return "'" + strings.ReplaceAll(strings.ReplaceAll(value, `\`, `\\`), "'", `\'`) + "'"
+}
+
+// withClickHouseSettings appends the SETTINGS clause to a ClickHouse query;
+// invalid setting names are reported as query errors
+func withClickHouseSettings(query *gorm.DB, settings map[string]string) *gorm.DB {
+	if len(settings) == 0 {
+		return query
+	}
+	for key := range settings {
+		if !isValidSortField(key) {
+			query.AddError(fmt.Errorf("invalid setting name: %s", key))
+			return query
+		}
+	}
+	return replaceBuildClause(query.Clauses(clickHouseSettings(settings)), "", clickHouseSettingsClause)
+}
+
+// ApproximateClickHouseCount returns hints making the count of ClickHouse listings stop
+// after maxTime or maxRows rows read and return what it counted so far; the total is then
+// a lower bound. Zero leaves either limit off.
+func ApproximateClickHouseCount(maxTime time.Duration, maxRows int64) QueryHints {
+	settings := map[string]string{}
+	if maxTime > 0 {
+		settings["max_execution_time"] = clickHouseSeconds(maxTime)
+		settings["timeout_overflow_mode"] = "break"
+	}
+	if maxRows > 0 {
+		settings["max_rows_to_read"] = strconv.FormatInt(maxRows, 10)
+		settings["read_overflow_mode"] = "break"
+	}
+	return QueryHints{CountSettings: settings}
+}
+
+// clickHouseSeconds renders a duration in the seconds ClickHouse time limits take
+func clickHouseSeconds(duration time.Duration) string {
+	return strconv.FormatFloat(duration.Seconds(), 'f', -1, 64)
+}
@@ -0,0 +1,60 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginatedSQL_ClickHouseSettings(t *testing.T) {
+	db := openNamedDialect(t, "clickhouse")
+	assert.Equal(t, ClickHouse, DetectDialect(db))
+
+	hints := ApproximateClickHouseCount(1500*time.Millisecond, 1000000)
+	hints.Settings = map[string]string{"max_threads": "4"}
+	builder := NewSimpleQueryBuilder("test_users").WithSearchFields("name")
+
+	statements, err := PaginatedSQL[TestUser](db, builder, PaginationRequest{Page: 2, PerPage: 10, Search: "ann"}, nil, PaginatedQueryOptions{Dialect: ClickHouse, Hints: hints})
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT count(*) FROM `test_users` WHERE (name ILIKE ?) SETTINGS max_execution_time = 1.5, max_rows_to_read = 1000000, "+
+		"max_threads = 4, read_overflow_mode = 'break', timeout_overflow_mode = 'break'", statements.Count)
+	assert.Equal(t, "SELECT * FROM `test_users` WHERE (name ILIKE ?) ORDER BY id asc LIMIT 10 OFFSET 10 SETTINGS max_threads = 4", statements.Data,
+		"count settings only limit the count")
+}
+
+func TestPaginatedSQL_ClickHouseStatementTimeout(t *testing.T) {
+	db := openNamedDialect(t, "clickhouse")
+
+	statements, err := PaginatedSQL[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 1, PerPage: 10}, nil, PaginatedQueryOptions{
+		Dialect:           ClickHouse,
+		StatementTimeout:  3 * time.Second,
+		PrepareStatements: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT * FROM `test_users` ORDER BY id asc LIMIT ? OFFSET ? SETTINGS max_execution_time = 3", statements.Data)
+}
+
+func TestClickHouseSettings_Validation(t *testing.T) {
+	db := openNamedDialect(t, "clickhouse")
+
+	_, err := PaginatedSQL[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 1, PerPage: 10}, nil, PaginatedQueryOptions{
+		Dialect: ClickHouse,
+		Hints:   QueryHints{Settings: map[string]string{"max_threads = 1; DROP": "1"}},
+	})
+	assert.ErrorContains(t, err, "invalid setting name")
+
+	assert.Equal(t, `'it\'s'`, clickHouseSettingValue("it's"))
+	assert.Equal(t, "0.25", clickHouseSettingValue("0.25"))
+}
+
+func TestPaginatedQuery_SettingsIgnoredOnOtherDialects(t *testing.T) {
+	db := setupTestDB()
+	users, total, err := PaginatedQueryWithOptions[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 1, PerPage: 2}, nil, PaginatedQueryOptions{
+		Dialect: SQLite,
+		Hints:   ApproximateClickHouseCount(time.Second, 10),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.Len(t, users, 2)
+}
@@ -0,0 +1,162 @@
+package pagination
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"iter"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// ErrCrossOriginLink is returned when an upstream next link points to another origin
+var ErrCrossOriginLink = errors.New("next link leaves the origin of the page")
+
+// Client consumes paginated endpoints that respond with this package's envelope
+type Client struct {
+	HTTPClient  *http.Client
+	Header      http.Header
+	PageParam   string
+	CursorParam string
+	MaxPages    int
+}
+
+// ClientPage is one decoded page fetched by the Client
+type ClientPage[T any] struct {
+	Data       []T
+	Pagination PaginationResponse
+	NextURL    string
+}
+
+// clientEnvelope mirrors PaginatedResponse and the cursor-style responses
+type clientEnvelope[T any] struct {
+	Code       int                 `json:"code"`
+	Status     string              `json:"status"`
+	Message    string              `json:"message"`
+	Data       []T                 `json:"data"`
+	Pagination *PaginationResponse `json:"pagination"`
+	Links      struct {
+		Next string `json:"next"`
+	} `json:"links"`
+	NextCursor string `json:"next_cursor"`
+	NextToken  string `json:"next_token"`
+}
+
+// NewClient creates a Client using the given HTTP client, or http.DefaultClient when nil
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		HTTPClient:  httpClient,
+		Header:      http.Header{},
+		PageParam:   "page",
+		CursorParam: "cursor",
+	}
+}
+
+// FetchPage requests a single page and resolves the URL of the following page
+// The next page is taken from links.next, then from next_cursor/next_token, and
+// finally from pagination.next_cursor or the page/max_page metadata. NextURL is
+// empty on the last page. A links.next on another scheme or host is rejected with
+// ErrCrossOriginLink, so Client.Header is only ever sent to the origin of pageURL.
+func FetchPage[T any](ctx context.Context, client *Client, pageURL string) (ClientPage[T], error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return ClientPage[T]{}, fmt.Errorf("failed to build request: %w", err)
+	}
+	for key, values := range client.Header {
+		for _, value := range values {
+			request.Header.Add(key, value)
+		}
+	}
+
+	response, err := client.HTTPClient.Do(request)
+	if err != nil {
+		return ClientPage[T]{}, fmt.Errorf("failed to fetch page: %w", err)
+	}
+	defer response.Body.Close()
+
+	var envelope clientEnvelope[T]
+	if err := json.NewDecoder(response.Body).Decode(&envelope); err != nil {
+		return ClientPage[T]{}, fmt.Errorf("failed to decode page: %w", err)
+	}
+
+	if response.StatusCode >= 400 || envelope.Status == "error" {
+		return ClientPage[T]{}, fmt.Errorf("upstream returned %d: %s", response.StatusCode, envelope.Message)
+	}
+
+	page := ClientPage[T]{Data: envelope.Data}
+	if envelope.Pagination != nil {
+		page.Pagination = *envelope.Pagination
+	}
+
+	page.NextURL, err = client.nextURL(pageURL, envelope.Links.Next, envelope.NextCursor+envelope.NextToken, envelope.Pagination)
+	if err != nil {
+		return ClientPage[T]{}, err
+	}
+
+	return page, nil
+}
+
+func (c *Client) nextURL(current, linkNext, cursor string, meta *PaginationResponse) (string, error) {
+	base, err := url.Parse(current)
+	if err != nil {
+		return "", fmt.Errorf("invalid page url: %w", err)
+	}
+
+	if linkNext != "" {
+		next, err := base.Parse(linkNext)
+		if err != nil {
+			return "", fmt.Errorf("invalid next link: %w", err)
+		}
+		if next.Scheme != base.Scheme || next.Host != base.Host {
+			return "", fmt.Errorf("%w: %s", ErrCrossOriginLink, next.Redacted())
+		}
+		return next.String(), nil
+	}
+
+	query := base.Query()
+	switch {
+	case cursor != "":
+		query.Set(c.CursorParam, cursor)
+	case meta != nil && meta.NextCursor != "":
+		query.Set(c.CursorParam, meta.NextCursor)
+	case meta != nil && !meta.IsDisabled && int64(meta.Page) < meta.MaxPage:
+		query.Set(c.PageParam, strconv.Itoa(meta.Page+1))
+	default:
+		return "", nil
+	}
+
+	base.RawQuery = query.Encode()
+	return base.String(), nil
+}
+
+// Iterate yields every item of every page starting at startURL, following next pages
+// Iteration stops at the first error, which is yielded together with a zero item.
+func Iterate[T any](ctx context.Context, client *Client, startURL string) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		pageURL := startURL
+		for pages := 0; pageURL != ""; pages++ {
+			if client.MaxPages > 0 && pages >= client.MaxPages {
+				return
+			}
+
+			page, err := FetchPage[T](ctx, client, pageURL)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			for _, item := range page.Data {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			pageURL = page.NextURL
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package pagination
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientIterate(t *testing.T) {
+	db := setupTestDB()
+
+	router := http.NewServeMux()
+	router.HandleFunc("GET /users", func(w http.ResponseWriter, r *http.Request) {
+		response := PaginateResponse[TestUser](db, r, "ok", WithTable("test_users"), WithSearchFields("name"))
+		writeJSON(w, response.Code, response)
+	})
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	client := NewClient(server.Client())
+
+	var names []string
+	for user, err := range Iterate[TestUser](context.Background(), client, server.URL+"/users?per_page=2") {
+		assert.NoError(t, err)
+		names = append(names, user.Name)
+	}
+
+	assert.Len(t, names, 5)
+	assert.Equal(t, "John Doe", names[0])
+	assert.Equal(t, "Charlie Wilson", names[4])
+}
+
+func TestClient_NextURL(t *testing.T) {
+	client := NewClient(nil)
+
+	next, err := client.nextURL("http://api.local/changes?limit=5", "", "abc", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://api.local/changes?cursor=abc&limit=5", next)
+
+	next, err = client.nextURL("http://api.local/users?page=1", "/users?page=2", "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "http://api.local/users?page=2", next)
+
+	next, err = client.nextURL("http://api.local/users?page=3", "", "", &PaginationResponse{Page: 3, MaxPage: 3})
+	assert.NoError(t, err)
+	assert.Empty(t, next)
+
+	_, err = client.nextURL("http://api.local/users?page=1", "http://attacker.local/users?page=2", "", nil)
+	assert.ErrorIs(t, err, ErrCrossOriginLink)
+
+	_, err = client.nextURL("https://api.local/users?page=1", "http://api.local/users?page=2", "", nil)
+	assert.ErrorIs(t, err, ErrCrossOriginLink)
+}
+
+func TestClient_CrossOriginLink(t *testing.T) {
+	leaked := make(chan string, 1)
+	attacker := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked <- r.Header.Get("Authorization")
+	}))
+	defer attacker.Close()
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, 200, map[string]interface{}{
+			"data":  []TestUser{{Name: "John Doe"}},
+			"links": map[string]string{"next": attacker.URL + "/users?page=2"},
+		})
+	}))
+	defer upstream.Close()
+
+	client := NewClient(nil)
+	client.Header.Set("Authorization", "Bearer secret")
+
+	_, err := FetchPage[TestUser](context.Background(), client, upstream.URL+"/users")
+	assert.ErrorIs(t, err, ErrCrossOriginLink)
+
+	for _, err := range Iterate[TestUser](context.Background(), client, upstream.URL+"/users") {
+		assert.ErrorIs(t, err, ErrCrossOriginLink)
+	}
+	assert.Empty(t, leaked)
+}
@@ -0,0 +1,122 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CursorToken is the content of an opaque pagination token before encoding
+type CursorToken struct {
+	// ExpiresAt is zero for tokens that never expire
+	ExpiresAt time.Time
+	// Scope fingerprints the query the token was issued for, see CursorScope
+	Scope string
+	// Payload is the JSON encoded position
+	Payload json.RawMessage
+}
+
+// CursorCodec turns tokens into strings and back. Expiry and scope are checked by the
+// package after Decode; Decode errors should wrap ErrInvalidToken.
+type CursorCodec interface {
+	Encode(token CursorToken) (string, error)
+	Decode(token string) (CursorToken, error)
+}
+
+// DefaultCursorCodec encodes tokens as a versioned JSON envelope in base64url,
+// encrypted when UseCursorEncryption set a keyring
+type DefaultCursorCodec struct{}
+
+// tokenEnvelope is the JSON layout of DefaultCursorCodec
+type tokenEnvelope struct {
+	ExpiresAt int64           `json:"x,omitempty"`
+	Scope     string          `json:"q,omitempty"`
+	Payload   json.RawMessage `json:"p"`
+}
+
+var (
+	cursorCodecMu sync.RWMutex
+	cursorCodec   CursorCodec = DefaultCursorCodec{}
+)
+
+// SetCursorCodec replaces the codec of every token issued and accepted by the package;
+// nil restores DefaultCursorCodec
+func SetCursorCodec(codec CursorCodec) {
+	if codec == nil {
+		codec = DefaultCursorCodec{}
+	}
+
+	cursorCodecMu.Lock()
+	defer cursorCodecMu.Unlock()
+	cursorCodec = codec
+}
+
+func activeCursorCodec() CursorCodec {
+	cursorCodecMu.RLock()
+	defer cursorCodecMu.RUnlock()
+	return cursorCodec
+}
+
+// Encode implements CursorCodec
+func (DefaultCursorCodec) Encode(token CursorToken) (string, error) {
+	envelope := tokenEnvelope{Scope: token.Scope, Payload: token.Payload}
+	if !token.ExpiresAt.IsZero() {
+		envelope.ExpiresAt = token.ExpiresAt.Unix()
+	}
+
+	framed, err := json.Marshal(envelope)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode token: %w", err)
+	}
+	framed = append([]byte{tokenVersion}, framed...)
+
+	if keyring := activeCursorKeyring(); keyring != nil {
+		return keyring.Seal(framed)
+	}
+	return base64.RawURLEncoding.EncodeToString(framed), nil
+}
+
+// Decode implements CursorCodec
+func (DefaultCursorCodec) Decode(token string) (CursorToken, error) {
+	framed, err := tokenPayload(token)
+	if err != nil {
+		return CursorToken{}, err
+	}
+
+	if len(framed) == 0 || framed[0] != tokenVersion {
+		version := byte(0)
+		if len(framed) > 0 {
+			version = framed[0]
+		}
+		return CursorToken{}, &TokenError{Version: version, err: ErrTokenVersion}
+	}
+
+	var envelope tokenEnvelope
+	if err := json.Unmarshal(framed[1:], &envelope); err != nil {
+		return CursorToken{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	decoded := CursorToken{Scope: envelope.Scope, Payload: envelope.Payload}
+	if envelope.ExpiresAt > 0 {
+		decoded.ExpiresAt = time.Unix(envelope.ExpiresAt, 0)
+	}
+	return decoded, nil
+}
+
+// tokenPayload returns the raw JSON payload of a token, decrypting it when a keyring is active
+// Encrypted tokens contain a '.' which never appears in the base64url alphabet.
+func tokenPayload(token string) ([]byte, error) {
+	keyring := activeCursorKeyring()
+	if keyring != nil && (strings.Contains(token, ".") || !keyring.AllowPlaintext) {
+		return keyring.Open(token)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return payload, nil
+}
@@ -0,0 +1,95 @@
+package pagination
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// legacyCodec stores tokens as "v0:<hex json>" like an older API did
+type legacyCodec struct{}
+
+type legacyToken struct {
+	Expires int64           `json:"expires,omitempty"`
+	Scope   string          `json:"scope,omitempty"`
+	Data    json.RawMessage `json:"data"`
+}
+
+func (legacyCodec) Encode(token CursorToken) (string, error) {
+	legacy := legacyToken{Scope: token.Scope, Data: token.Payload}
+	if !token.ExpiresAt.IsZero() {
+		legacy.Expires = token.ExpiresAt.Unix()
+	}
+	encoded, err := json.Marshal(legacy)
+	return "v0:" + hex.EncodeToString(encoded), err
+}
+
+func (legacyCodec) Decode(token string) (CursorToken, error) {
+	raw, err := hex.DecodeString(strings.TrimPrefix(token, "v0:"))
+	if err != nil || !strings.HasPrefix(token, "v0:") {
+		return CursorToken{}, fmt.Errorf("%w: not a legacy token", ErrInvalidToken)
+	}
+	var legacy legacyToken
+	if err := json.Unmarshal(raw, &legacy); err != nil {
+		return CursorToken{}, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	decoded := CursorToken{Scope: legacy.Scope, Payload: legacy.Data}
+	if legacy.Expires > 0 {
+		decoded.ExpiresAt = time.Unix(legacy.Expires, 0)
+	}
+	return decoded, nil
+}
+
+func TestSetCursorCodec(t *testing.T) {
+	SetCursorCodec(legacyCodec{})
+	defer SetCursorCodec(nil)
+
+	token, err := encodeScopedToken(map[string]int{"id": 7}, "scope")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, "v0:"))
+
+	var decoded map[string]interface{}
+	assert.NoError(t, decodeScopedToken(token, "scope", &decoded))
+	assert.Equal(t, int64(7), normalizeTokenValue(decoded["id"]))
+
+	// Scope and expiry checks apply to every codec
+	assert.ErrorIs(t, decodeScopedToken(token, "other", &decoded), ErrTokenScope)
+	expired, _ := legacyCodec{}.Encode(CursorToken{ExpiresAt: time.Unix(1, 0), Payload: json.RawMessage(`{}`)})
+	assert.ErrorIs(t, decodeToken(expired, &decoded), ErrTokenExpired)
+
+	SetCursorCodec(nil)
+	assert.ErrorIs(t, decodeToken(token, &decoded), ErrInvalidToken)
+}
+
+func TestSetCursorCodec_Changes(t *testing.T) {
+	SetCursorCodec(legacyCodec{})
+	defer SetCursorCodec(nil)
+
+	db := setupChangesDB()
+	options := ChangeFeedOptions{TableName: "test_changes", Limit: 2}
+
+	first, err := Changes[TestChange](db, "", options)
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(first.NextToken, "v0:"))
+
+	second, err := Changes[TestChange](db, first.NextToken, options)
+	assert.NoError(t, err)
+	assert.Len(t, second.Data, 2)
+}
+
+func TestDefaultCursorCodec_RoundTrip(t *testing.T) {
+	expires := time.Unix(2000000000, 0)
+	token, err := DefaultCursorCodec{}.Encode(CursorToken{ExpiresAt: expires, Scope: "s", Payload: json.RawMessage(`{"a":1}`)})
+	assert.NoError(t, err)
+
+	decoded, err := DefaultCursorCodec{}.Decode(token)
+	assert.NoError(t, err)
+	assert.True(t, expires.Equal(decoded.ExpiresAt))
+	assert.Equal(t, "s", decoded.Scope)
+	assert.JSONEq(t, `{"a":1}`, string(decoded.Payload))
+}
@@ -0,0 +1,294 @@
+package pagination
+
+import (
+	"encoding"
+	"fmt"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParamError describes a query parameter that could not be coerced into its filter field
+type ParamError struct {
+	Param   string `json:"param"`
+	Value   string `json:"value"`
+	Type    string `json:"type"`
+	Message string `json:"message"`
+}
+
+// CoercionError lists every query parameter that could not be coerced into a filter;
+// the response helpers answer it with 422 and the list under errors
+type CoercionError struct {
+	Params []ParamError
+}
+
+func (e *CoercionError) Error() string {
+	problems := make([]string, len(e.Params))
+	for i, param := range e.Params {
+		problems[i] = param.Message
+	}
+	return strings.Join(problems, "; ")
+}
+
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	durationType      = reflect.TypeOf(time.Duration(0))
+	baseFilterType    = reflect.TypeOf(BaseFilter{})
+	textUnmarshalType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// CoerceFilterValues sets the fields of filter, a pointer to a struct, from query and
+// returns a *CoercionError listing every value that does not fit its field. Fields are
+// named by their form tag, or their name without one, and may set a default with
+// `form:"status,default=active"`. Supported types are strings, integers, floats, bools,
+// time.Time (RFC 3339, 2006-01-02 or the time_format tag), time.Duration, types
+// implementing encoding.TextUnmarshaler such as uuid.UUID, pointers and slices of
+// them; slices read repeated parameters and the array syntax name[], or comma-separated
+// ones with `collection_format:"csv"` or `filter:"op=in"`. The enum tag restricts values, as in `enum:"active,archived"`,
+// and `format:"uuid"` requires a UUID in a string field. The pagination fields of an
+// embedded BaseFilter are left to its own binding.
+func CoerceFilterValues(query url.Values, filter interface{}) error {
+	value := reflect.ValueOf(filter)
+	if value.Kind() != reflect.Ptr || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("filter must be a pointer to a struct, got %T", filter)
+	}
+
+	var params []ParamError
+	coerceStruct(query, value.Elem(), &params)
+	if len(params) > 0 {
+		return &CoercionError{Params: params}
+	}
+	return nil
+}
+
+func coerceStruct(query url.Values, value reflect.Value, params *[]ParamError) {
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Type().Field(i)
+		// Embedded structs of unexported types still promote their exported fields
+		embedded := field.Anonymous && field.Type.Kind() == reflect.Struct
+		if (!field.IsExported() && !embedded) || field.Type == baseFilterType {
+			continue
+		}
+
+		tag := field.Tag.Get("form")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		if tag == "" && fieldType.Kind() == reflect.Struct && !isScalarType(fieldType) {
+			coerceStruct(query, indirect(value.Field(i)), params)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		values := query[name]
+		slice := fieldType.Kind() == reflect.Slice && !isScalarType(fieldType)
+		if slice {
+			// Slices also read the array syntax name[]
+			values = append(values[:len(values):len(values)], query[name+"[]"]...)
+		}
+		if len(values) == 0 {
+			defaultValue, found := strings.CutPrefix(options, "default=")
+			if !found {
+				continue
+			}
+			values = []string{defaultValue}
+		}
+		if field.Tag.Get("collection_format") == "csv" || (slice && multiValueOperator(field)) {
+			if values = splitMultiValue(values); len(values) == 0 {
+				continue
+			}
+		}
+
+		if problem := coerceField(value.Field(i), field, values); problem != nil {
+			problem.Param = name
+			problem.Message = name + " " + problem.Message
+			*params = append(*params, *problem)
+		}
+	}
+}
+
+// coerceField sets field from values, returning the problem with the first bad value
+func coerceField(target reflect.Value, field reflect.StructField, values []string) *ParamError {
+	elemType := target.Type()
+	for elemType.Kind() == reflect.Ptr {
+		elemType = elemType.Elem()
+	}
+
+	if elemType.Kind() == reflect.Slice && !isScalarType(elemType) {
+		slice := reflect.MakeSlice(elemType, 0, len(values))
+		for _, raw := range values {
+			elem := reflect.New(elemType.Elem()).Elem()
+			if problem := coerceValue(elem, field, strings.TrimSpace(raw)); problem != nil {
+				return problem
+			}
+			slice = reflect.Append(slice, elem)
+		}
+		indirect(target).Set(slice)
+		return nil
+	}
+	return coerceValue(indirect(target), field, values[0])
+}
+
+// coerceValue parses raw into target; empty values leave target at its zero value
+func coerceValue(target reflect.Value, field reflect.StructField, raw string) *ParamError {
+	if raw == "" {
+		target.Set(reflect.Zero(target.Type()))
+		return nil
+	}
+	if target.Kind() == reflect.Ptr {
+		target = indirect(target)
+	}
+	problem := func(kind, message string) *ParamError {
+		return &ParamError{Value: raw, Type: kind, Message: message}
+	}
+
+	if enum := field.Tag.Get("enum"); enum != "" {
+		allowed := strings.Split(enum, ",")
+		if !slices.Contains(allowed, raw) {
+			return problem("enum", "must be one of "+strings.Join(allowed, ", "))
+		}
+	}
+	if field.Tag.Get("format") == "uuid" && !isUUID(raw) {
+		return problem("uuid", "must be a UUID")
+	}
+
+	// Times accept more layouts than their UnmarshalText
+	switch {
+	case target.Type() == timeType:
+		parsed, err := parseFilterTime(raw, field)
+		if err != nil {
+			return problem("date", "must be a date ("+timeLayoutsOf(field)+")")
+		}
+		target.Set(reflect.ValueOf(parsed))
+		return nil
+	case target.Type() == durationType:
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return problem("duration", "must be a duration such as 90s or 1h")
+		}
+		target.SetInt(int64(parsed))
+		return nil
+	}
+
+	if target.CanAddr() && target.Addr().Type().Implements(textUnmarshalType) {
+		if err := target.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(raw)); err != nil {
+			kind := "text"
+			if strings.Contains(strings.ToLower(target.Type().Name()), "uuid") {
+				kind = "uuid"
+			}
+			return problem(kind, "must be a valid "+strings.ToLower(target.Type().Name()))
+		}
+		return nil
+	}
+
+	switch target.Kind() {
+	case reflect.String:
+		target.SetString(raw)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(raw, 10, target.Type().Bits())
+		if err != nil {
+			return problem("integer", "must be an integer")
+		}
+		target.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(raw, 10, target.Type().Bits())
+		if err != nil {
+			return problem("integer", "must be a non-negative integer")
+		}
+		target.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(raw, target.Type().Bits())
+		if err != nil {
+			return problem("number", "must be a number")
+		}
+		target.SetFloat(parsed)
+	case reflect.Bool:
+		parsed, ok := parseFilterBool(raw)
+		if !ok {
+			return problem("boolean", "must be true or false")
+		}
+		target.SetBool(parsed)
+	default:
+		return problem(target.Kind().String(), "has an unsupported type "+target.Type().String())
+	}
+	return nil
+}
+
+func parseFilterBool(raw string) (bool, bool) {
+	switch strings.ToLower(raw) {
+	case "1", "t", "true", "yes", "y", "on":
+		return true, true
+	case "0", "f", "false", "no", "n", "off":
+		return false, true
+	}
+	return false, false
+}
+
+// parseFilterTime parses raw with the time_format tag of field, or as RFC 3339 or a date
+func parseFilterTime(raw string, field reflect.StructField) (time.Time, error) {
+	location := time.Local
+	if field.Tag.Get("time_utc") == "true" || field.Tag.Get("time_utc") == "1" {
+		location = time.UTC
+	}
+	if layout := field.Tag.Get("time_format"); layout != "" {
+		return time.ParseInLocation(layout, raw, location)
+	}
+	if parsed, err := time.Parse(time.RFC3339, raw); err == nil {
+		return parsed, nil
+	}
+	return time.ParseInLocation(time.DateOnly, raw, location)
+}
+
+func timeLayoutsOf(field reflect.StructField) string {
+	if layout := field.Tag.Get("time_format"); layout != "" {
+		return layout
+	}
+	return "2006-01-02 or RFC 3339"
+}
+
+// isUUID reports whether s is a UUID in its canonical 8-4-4-4-12 hex form
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i, char := range s {
+		switch i {
+		case 8, 13, 18, 23:
+			if char != '-' {
+				return false
+			}
+		default:
+			if !strings.ContainsRune("0123456789abcdefABCDEF", char) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// isScalarType reports whether values of t are parsed from a single parameter value
+func isScalarType(t reflect.Type) bool {
+	return t == timeType || reflect.PointerTo(t).Implements(textUnmarshalType)
+}
+
+// indirect allocates the nil pointers of value and returns what it points to
+func indirect(value reflect.Value) reflect.Value {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		value = value.Elem()
+	}
+	return value
+}
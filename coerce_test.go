@@ -0,0 +1,101 @@
+package pagination
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testColor string
+
+func (c *testColor) UnmarshalText(text []byte) error {
+	if string(text) != "red" && string(text) != "blue" {
+		return errors.New("unknown color")
+	}
+	*c = testColor(text)
+	return nil
+}
+
+type typedUserFilter struct {
+	testUserFilter
+	Active  *bool         `form:"active"`
+	Since   time.Time     `form:"since"`
+	Until   time.Time     `form:"until" time_format:"02/01/2006" time_utc:"1"`
+	Status  string        `form:"status,default=active" enum:"active,archived"`
+	OwnerID string        `form:"owner_id" format:"uuid"`
+	Ages    []int         `form:"age" collection_format:"csv"`
+	Color   testColor     `form:"color"`
+	Timeout time.Duration `form:"timeout"`
+	Score   float64       `form:"score"`
+}
+
+func TestCoerceFilterValues(t *testing.T) {
+	filter := &typedUserFilter{}
+	err := CoerceFilterValues(url.Values{
+		"min_age":  {"30"},
+		"active":   {"yes"},
+		"since":    {"2024-03-01"},
+		"until":    {"15/03/2024"},
+		"owner_id": {"4f1c2a7e-8b9d-4c3e-a1f2-0123456789ab"},
+		"age":      {"25,35", "40"},
+		"color":    {"red"},
+		"timeout":  {"90s"},
+		"score":    {"4.5"},
+	}, filter)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 30, filter.MinAge)
+	if assert.NotNil(t, filter.Active) {
+		assert.True(t, *filter.Active)
+	}
+	assert.Equal(t, 2024, filter.Since.Year())
+	assert.Equal(t, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC), filter.Until)
+	assert.Equal(t, "active", filter.Status, "defaults apply to missing parameters")
+	assert.Equal(t, []int{25, 35, 40}, filter.Ages)
+	assert.Equal(t, testColor("red"), filter.Color)
+	assert.Equal(t, 90*time.Second, filter.Timeout)
+	assert.Equal(t, 4.5, filter.Score)
+}
+
+func TestCoerceFilterValues_AggregatesErrors(t *testing.T) {
+	err := CoerceFilterValues(url.Values{
+		"min_age":  {"old"},
+		"active":   {"maybe"},
+		"since":    {"yesterday"},
+		"status":   {"deleted"},
+		"owner_id": {"42"},
+		"age":      {"25,x"},
+		"color":    {"green"},
+	}, &typedUserFilter{})
+
+	var coercion *CoercionError
+	if assert.ErrorAs(t, err, &coercion) {
+		assert.Equal(t, []ParamError{
+			{Param: "min_age", Value: "old", Type: "integer", Message: "min_age must be an integer"},
+			{Param: "active", Value: "maybe", Type: "boolean", Message: "active must be true or false"},
+			{Param: "since", Value: "yesterday", Type: "date", Message: "since must be a date (2006-01-02 or RFC 3339)"},
+			{Param: "status", Value: "deleted", Type: "enum", Message: "status must be one of active, archived"},
+			{Param: "owner_id", Value: "42", Type: "uuid", Message: "owner_id must be a UUID"},
+			{Param: "age", Value: "x", Type: "integer", Message: "age must be an integer"},
+			{Param: "color", Value: "green", Type: "text", Message: "color must be a valid testcolor"},
+		}, coercion.Params)
+	}
+}
+
+func TestPaginateRequest_CoercionResponse(t *testing.T) {
+	db := setupTestDB()
+
+	response := PaginatedAPIResponseRequest[TestUser](db, httptest.NewRequest("GET", "/users?min_age=old&active=maybe", nil), &typedUserFilter{}, "ok")
+	assert.Equal(t, 422, response.Code)
+	assert.Len(t, response.Errors, 2)
+
+	body, err := json.Marshal(response)
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(body), `"errors":[{"param":"min_age","value":"old","type":"integer","message":"min_age must be an integer"}`))
+}
@@ -0,0 +1,160 @@
+// Package compat keeps the Gin signatures of the pagination helpers that the root
+// package no longer provides. Each function is a shorthand for its ginadapter
+// counterpart, and BaseFilter restores the BindPagination method for filters embedding
+// it, so code written against the old helpers can switch its import to compat and move
+// to ginadapter one call site at a time.
+package compat
+
+import (
+	pagination "github.com/Caknoooo/go-pagination"
+	"github.com/Caknoooo/go-pagination/ginadapter"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// BaseFilter is pagination.BaseFilter with the Gin BindPagination method; embed it in
+// place of pagination.BaseFilter
+//
+// Deprecated: embed pagination.BaseFilter and bind the filter with ginadapter.BindFilter.
+type BaseFilter struct {
+	pagination.BaseFilter
+}
+
+// BindPagination binds the pagination parameters of the request
+//
+// Deprecated: bind the filter with ginadapter.BindFilter.
+func (f *BaseFilter) BindPagination(ctx *gin.Context) {
+	f.Pagination = BindPagination(ctx)
+}
+
+// BindPagination reads the pagination parameters of the request
+//
+// Deprecated: use ginadapter.BindPagination, which also returns the warnings.
+func BindPagination(ctx *gin.Context) pagination.PaginationRequest {
+	request, _ := ginadapter.BindPagination(ctx)
+	return request
+}
+
+// BindAndValidateFilter binds pagination and query parameters, then validates the filter
+//
+// Deprecated: use ginadapter.BindAndValidateFilter.
+func BindAndValidateFilter(ctx *gin.Context, filter pagination.IncludableQueryBuilder) error {
+	return ginadapter.BindAndValidateFilter(ctx, filter)
+}
+
+// PaginatedAPIResponseWithQueryLayer creates a complete API response using query layer pattern
+//
+// Deprecated: use ginadapter.PaginatedAPIResponseWithQueryLayer.
+func PaginatedAPIResponseWithQueryLayer[T any](
+	ctx *gin.Context,
+	filter pagination.IncludableQueryBuilder,
+	message string,
+	queryFunc func(pagination.IncludableQueryBuilder) ([]T, int64, error),
+) pagination.PaginatedResponse {
+	return ginadapter.PaginatedAPIResponseWithQueryLayer(ctx, filter, message, queryFunc)
+}
+
+// PaginateModel paginates a table, searching searchFields
+//
+// Deprecated: use ginadapter.Paginate with WithTable and WithSearchFields.
+func PaginateModel[T any](
+	db *gorm.DB,
+	ctx *gin.Context,
+	tableName string,
+	searchFields []string,
+) ([]T, pagination.PaginationResponse, error) {
+	return ginadapter.Paginate[T](db, ctx, tableOptions(tableName, searchFields)...)
+}
+
+// PaginateWithIncludes paginates a table with preloaded relationships
+//
+// Deprecated: use ginadapter.Paginate with WithTable, WithSearchFields and WithIncludes.
+func PaginateWithIncludes[T any](
+	db *gorm.DB,
+	ctx *gin.Context,
+	tableName string,
+	searchFields []string,
+	includes []string,
+) ([]T, pagination.PaginationResponse, error) {
+	options := append(tableOptions(tableName, searchFields), pagination.WithIncludes(includes...))
+	return ginadapter.Paginate[T](db, ctx, options...)
+}
+
+// PaginateWithFilter paginates a table with a custom condition
+//
+// Deprecated: use ginadapter.Paginate with WithTable, WithSearchFields and WithFilter.
+func PaginateWithFilter[T any](
+	db *gorm.DB,
+	ctx *gin.Context,
+	tableName string,
+	searchFields []string,
+	filterFunc func(*gorm.DB) *gorm.DB,
+) ([]T, pagination.PaginationResponse, error) {
+	options := append(tableOptions(tableName, searchFields), pagination.WithFilter(filterFunc))
+	return ginadapter.Paginate[T](db, ctx, options...)
+}
+
+// QuickPaginate paginates a table with its registered defaults
+//
+// Deprecated: use ginadapter.Paginate with WithTable.
+func QuickPaginate[T any](db *gorm.DB, ctx *gin.Context, tableName string) ([]T, pagination.PaginationResponse, error) {
+	return ginadapter.Paginate[T](db, ctx, pagination.WithTable(tableName))
+}
+
+// PaginateWithCustomFilter binds filter from the query string and paginates it
+//
+// Deprecated: use ginadapter.Paginate with WithCustomFilter.
+func PaginateWithCustomFilter[T any](
+	db *gorm.DB,
+	ctx *gin.Context,
+	filter pagination.Filterable,
+) ([]T, pagination.PaginationResponse, error) {
+	return ginadapter.Paginate[T](db, ctx, pagination.WithCustomFilter(filter))
+}
+
+// PaginatedAPIResponse creates a complete API response for a table
+//
+// Deprecated: use ginadapter.PaginateResponse with WithTable and WithSearchFields.
+func PaginatedAPIResponse[T any](
+	db *gorm.DB,
+	ctx *gin.Context,
+	tableName string,
+	searchFields []string,
+	message string,
+) pagination.PaginatedResponse {
+	return ginadapter.PaginateResponse[T](db, ctx, message, tableOptions(tableName, searchFields)...)
+}
+
+// PaginatedAPIResponseWithIncludes creates a complete API response for a table with
+// preloaded relationships
+//
+// Deprecated: use ginadapter.PaginateResponse with WithTable, WithSearchFields and WithIncludes.
+func PaginatedAPIResponseWithIncludes[T any](
+	db *gorm.DB,
+	ctx *gin.Context,
+	tableName string,
+	searchFields []string,
+	includes []string,
+	message string,
+) pagination.PaginatedResponse {
+	options := append(tableOptions(tableName, searchFields), pagination.WithIncludes(includes...))
+	return ginadapter.PaginateResponse[T](db, ctx, message, options...)
+}
+
+// PaginatedAPIResponseWithCustomFilter creates a complete API response for a custom
+// filter, answering 400 or 422 when the query string does not bind to it
+//
+// Deprecated: use ginadapter.PaginateResponse with WithCustomFilter.
+func PaginatedAPIResponseWithCustomFilter[T any](
+	db *gorm.DB,
+	ctx *gin.Context,
+	filter pagination.Filterable,
+	message string,
+) pagination.PaginatedResponse {
+	return ginadapter.PaginateResponse[T](db, ctx, message, pagination.WithCustomFilter(filter))
+}
+
+// tableOptions are the options of the table helpers
+func tableOptions(tableName string, searchFields []string) []pagination.PaginateOption {
+	return []pagination.PaginateOption{pagination.WithTable(tableName), pagination.WithSearchFields(searchFields...)}
+}
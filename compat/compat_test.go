@@ -0,0 +1,131 @@
+package compat
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	pagination "github.com/Caknoooo/go-pagination"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type testUser struct {
+	ID   uint   `json:"id" gorm:"primaryKey"`
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+type testUserFilter struct {
+	pagination.BaseFilter
+	MinAge int `form:"min_age"`
+}
+
+func (f *testUserFilter) ApplyFilters(query *gorm.DB) *gorm.DB {
+	if f.MinAge > 0 {
+		query = query.Where("age >= ?", f.MinAge)
+	}
+	return query
+}
+func (f *testUserFilter) GetTableName() string      { return "test_users" }
+func (f *testUserFilter) GetSearchFields() []string { return []string{"name"} }
+func (f *testUserFilter) GetDefaultSort() string    { return "id asc" }
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&testUser{}))
+	for _, user := range []testUser{{Name: "John", Age: 25}, {Name: "Jane", Age: 30}, {Name: "Bob", Age: 35}} {
+		db.Create(&user)
+	}
+	return db
+}
+
+func newTestContext(target string) *gin.Context {
+	gin.SetMode(gin.TestMode)
+	ctx, _ := gin.CreateTestContext(httptest.NewRecorder())
+	ctx.Request = httptest.NewRequest("GET", target, nil)
+	return ctx
+}
+
+func TestTableHelpers(t *testing.T) {
+	db := setupTestDB(t)
+
+	users, meta, err := PaginateModel[testUser](db, newTestContext("/users?search=j"), "test_users", []string{"name"})
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, int64(2), meta.Total)
+
+	users, _, err = PaginateWithFilter[testUser](db, newTestContext("/users"), "test_users", nil, func(query *gorm.DB) *gorm.DB {
+		return query.Where("age > ?", 30)
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "Bob", users[0].Name)
+
+	_, meta, err = QuickPaginate[testUser](db, newTestContext("/users?per_page=1"), "test_users")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), meta.MaxPage)
+
+	response := PaginatedAPIResponse[testUser](db, newTestContext("/users"), "test_users", nil, "ok")
+	assert.Equal(t, 200, response.Code)
+	assert.Equal(t, "ok", response.Message)
+}
+
+func TestCustomFilterHelpers(t *testing.T) {
+	db := setupTestDB(t)
+
+	users, meta, err := PaginateWithCustomFilter[testUser](db, newTestContext("/users?min_age=30"), &testUserFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, int64(2), meta.Total)
+
+	response := PaginatedAPIResponseWithCustomFilter[testUser](db, newTestContext("/users?min_age=old"), &testUserFilter{}, "ok")
+	assert.Equal(t, 422, response.Code)
+}
+
+type legacyUserFilter struct {
+	BaseFilter
+	MinAge int `form:"min_age"`
+}
+
+func (f *legacyUserFilter) ApplyFilters(query *gorm.DB) *gorm.DB {
+	if f.MinAge > 0 {
+		query = query.Where("age >= ?", f.MinAge)
+	}
+	return query
+}
+func (f *legacyUserFilter) GetTableName() string      { return "test_users" }
+func (f *legacyUserFilter) GetSearchFields() []string { return []string{"name"} }
+func (f *legacyUserFilter) GetDefaultSort() string    { return "id asc" }
+func (f *legacyUserFilter) Validate()                 { f.ValidatePagination() }
+
+func TestBindingHelpers(t *testing.T) {
+	db := setupTestDB(t)
+
+	request := BindPagination(newTestContext("/users?page=2&per_page=1&order=desc"))
+	assert.Equal(t, 2, request.Page)
+	assert.Equal(t, 1, request.PerPage)
+	assert.Equal(t, "desc", request.Order)
+
+	filter := &legacyUserFilter{}
+	filter.BindPagination(newTestContext("/users?per_page=2"))
+	assert.Equal(t, 2, filter.Pagination.PerPage)
+	users, total, err := pagination.PaginatedQuery[testUser](db, filter, filter.Pagination, nil)
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, int64(3), total)
+
+	filter = &legacyUserFilter{}
+	assert.NoError(t, BindAndValidateFilter(newTestContext("/users?min_age=30&per_page=5"), filter))
+	assert.Equal(t, 30, filter.MinAge)
+	assert.Equal(t, 5, filter.Pagination.PerPage)
+	assert.Error(t, BindAndValidateFilter(newTestContext("/users?min_age=old"), &legacyUserFilter{}))
+
+	response := PaginatedAPIResponseWithQueryLayer(newTestContext("/users?min_age=30"), &legacyUserFilter{}, "ok",
+		func(filter pagination.IncludableQueryBuilder) ([]testUser, int64, error) {
+			return pagination.PaginatedQuery[testUser](db, filter, filter.GetPagination(), filter.GetIncludes())
+		})
+	assert.Equal(t, 200, response.Code)
+	assert.Equal(t, int64(2), response.Pagination.Total)
+}
@@ -0,0 +1,171 @@
+package pagination
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// lastModifiedLayouts are the text formats drivers use for aggregated timestamps
+var lastModifiedLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02 15:04:05",
+}
+
+// LastModified returns the most recent value of column over the filtered set of builder
+// MAX over an indexed column is cheap; rows removed by hard deletes or moved out of the
+// filter are not reflected, see ReadListingVersion. A zero time is returned when the set
+// is empty.
+func LastModified(db *gorm.DB, builder QueryBuilder, pagination PaginationRequest, column string) (time.Time, error) {
+	if !isValidSortField(column) {
+		return time.Time{}, fmt.Errorf("invalid last modified column %q", column)
+	}
+
+	var value interface{}
+	if err := filteredSet(db, builder, pagination).Select("MAX(" + column + ")").Row().Scan(&value); err != nil {
+		return time.Time{}, fmt.Errorf("failed to read last modified: %w", err)
+	}
+
+	return parseLastModified(value)
+}
+
+// ListingVersion identifies the state of a filtered set by its most recent modification
+// and its number of rows, so rows removed from the set change it as well
+type ListingVersion struct {
+	LastModified time.Time
+	Count        int64
+}
+
+// ETag returns the version as a weak entity tag
+func (v ListingVersion) ETag() string {
+	var modified int64
+	if !v.LastModified.IsZero() {
+		modified = v.LastModified.UnixNano()
+	}
+	return fmt.Sprintf(`W/"%x-%x"`, modified, v.Count)
+}
+
+// ReadListingVersion reads the most recent value of column and the number of rows of the
+// filtered set of builder in one query
+func ReadListingVersion(db *gorm.DB, builder QueryBuilder, pagination PaginationRequest, column string) (ListingVersion, error) {
+	if !isValidSortField(column) {
+		return ListingVersion{}, fmt.Errorf("invalid last modified column %q", column)
+	}
+
+	var value interface{}
+	var version ListingVersion
+	if err := filteredSet(db, builder, pagination).Select("MAX("+column+"), COUNT(*)").Row().Scan(&value, &version.Count); err != nil {
+		return ListingVersion{}, fmt.Errorf("failed to read listing version: %w", err)
+	}
+
+	var err error
+	version.LastModified, err = parseLastModified(value)
+	return version, err
+}
+
+// parseLastModified converts the driver representation of a MAX(timestamp) result
+func parseLastModified(value interface{}) (time.Time, error) {
+	switch typed := value.(type) {
+	case nil:
+		return time.Time{}, nil
+	case time.Time:
+		return typed, nil
+	case []byte:
+		return parseLastModified(string(typed))
+	case string:
+		for _, layout := range lastModifiedLayouts {
+			if parsed, err := time.Parse(layout, typed); err == nil {
+				return parsed, nil
+			}
+		}
+		return time.Time{}, fmt.Errorf("unsupported last modified value %q", typed)
+	default:
+		return time.Time{}, fmt.Errorf("unsupported last modified type %T", value)
+	}
+}
+
+// CheckNotModified sets the Last-Modified header and answers 304 when the
+// If-Modified-Since of r is not older than lastModified; it reports whether the response
+// was written
+func CheckNotModified(w http.ResponseWriter, r *http.Request, lastModified time.Time) bool {
+	if lastModified.IsZero() {
+		return false
+	}
+
+	// HTTP dates have second precision
+	lastModified = lastModified.UTC().Truncate(time.Second)
+	w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+
+	since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil || lastModified.After(since) {
+		return false
+	}
+
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// CheckListingNotModified sets the ETag and Last-Modified headers of version and answers
+// 304 when the If-None-Match of r matches the ETag; it reports whether the response was
+// written. If-Modified-Since alone never answers 304, a date cannot tell that rows left
+// the set.
+func CheckListingNotModified(w http.ResponseWriter, r *http.Request, version ListingVersion) bool {
+	etag := version.ETag()
+	w.Header().Set("ETag", etag)
+	if !version.LastModified.IsZero() {
+		w.Header().Set("Last-Modified", version.LastModified.UTC().Truncate(time.Second).Format(http.TimeFormat))
+	}
+
+	if !etagMatches(r.Header.Get("If-None-Match"), etag) {
+		return false
+	}
+	w.WriteHeader(http.StatusNotModified)
+	return true
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, comparing weakly
+func etagMatches(header, etag string) bool {
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// PaginatedAPIResponseIfModified creates a paginated API response unless nothing in scope
+// changed since the ETag the client sent in If-None-Match; notModified reports that a 304
+// was already written
+func PaginatedAPIResponseIfModified[T any](
+	db *gorm.DB,
+	w http.ResponseWriter,
+	r *http.Request,
+	builder QueryBuilder,
+	column string,
+	message string,
+) (response PaginatedResponse, notModified bool) {
+	db = withRequestContext(db, r)
+	pagination, _ := BindPaginationRequest(r)
+
+	version, err := ReadListingVersion(db, builder, pagination, column)
+	if err != nil {
+		return NewPaginatedResponse(500, "Internal Server Error: "+err.Error(), nil, PaginationResponse{}).withRequestID(r), false
+	}
+	if CheckListingNotModified(w, r, version) {
+		return PaginatedResponse{}, true
+	}
+
+	data, total, err := PaginatedQuery[T](db, builder, pagination, []string{})
+	if err != nil {
+		return NewPaginatedResponse(500, "Internal Server Error: "+err.Error(), nil, PaginationResponse{}).withRequestID(r), false
+	}
+
+	return NewPaginatedResponse(200, message, data, CalculatePagination(pagination, total)), false
+}
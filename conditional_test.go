@@ -0,0 +1,97 @@
+package pagination
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestLastModified(t *testing.T) {
+	db := setupChangesDB()
+
+	builder := NewSimpleQueryBuilder("test_changes")
+	lastModified, err := LastModified(db, builder, PaginationRequest{}, "updated_at")
+	assert.NoError(t, err)
+	assert.True(t, lastModified.Equal(time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)))
+
+	filtered := NewSimpleQueryBuilder("test_changes").WithFilters(func(query *gorm.DB) *gorm.DB {
+		return query.Where("name IN ?", []string{"a", "c"})
+	})
+	lastModified, err = LastModified(db, filtered, PaginationRequest{}, "updated_at")
+	assert.NoError(t, err)
+	assert.True(t, lastModified.Equal(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	empty := NewSimpleQueryBuilder("test_changes").WithFilters(func(query *gorm.DB) *gorm.DB {
+		return query.Where("name = ?", "missing")
+	})
+	lastModified, err = LastModified(db, empty, PaginationRequest{}, "updated_at")
+	assert.NoError(t, err)
+	assert.True(t, lastModified.IsZero())
+
+	_, err = LastModified(db, builder, PaginationRequest{}, "updated_at; DROP TABLE x")
+	assert.Error(t, err)
+}
+
+func TestReadListingVersion(t *testing.T) {
+	db := setupChangesDB()
+	builder := NewSimpleQueryBuilder("test_changes")
+
+	version, err := ReadListingVersion(db, builder, PaginationRequest{}, "updated_at")
+	assert.NoError(t, err)
+	assert.True(t, version.LastModified.Equal(time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)))
+	assert.Equal(t, int64(4), version.Count)
+
+	db.Where("name = ?", "a").Delete(&TestChange{})
+	deleted, err := ReadListingVersion(db, builder, PaginationRequest{}, "updated_at")
+	assert.NoError(t, err)
+	assert.True(t, deleted.LastModified.Equal(version.LastModified), "deleting an older row keeps MAX")
+	assert.NotEqual(t, version.ETag(), deleted.ETag())
+
+	_, err = ReadListingVersion(db, builder, PaginationRequest{}, "updated_at; DROP TABLE x")
+	assert.Error(t, err)
+}
+
+func TestPaginatedAPIResponseIfModified(t *testing.T) {
+	db := setupChangesDB()
+	builder := NewSimpleQueryBuilder("test_changes")
+
+	request := func(header, value string) (*httptest.ResponseRecorder, PaginatedResponse, bool) {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, "/changes", nil)
+		if header != "" {
+			request.Header.Set(header, value)
+		}
+		response, notModified := PaginatedAPIResponseIfModified[TestChange](db, recorder, request, builder, "updated_at", "ok")
+		return recorder, response, notModified
+	}
+
+	recorder, response, notModified := request("", "")
+	assert.False(t, notModified)
+	assert.Equal(t, 200, response.Code)
+	assert.Equal(t, "Mon, 01 Jan 2024 02:00:00 GMT", recorder.Header().Get("Last-Modified"))
+	etag := recorder.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+
+	recorder, _, notModified = request("If-None-Match", etag)
+	assert.True(t, notModified)
+	assert.Equal(t, http.StatusNotModified, recorder.Code)
+
+	_, _, notModified = request("If-Modified-Since", "Mon, 01 Jan 2024 02:00:00 GMT")
+	assert.False(t, notModified, "a date alone cannot tell that rows were removed")
+
+	db.Model(&TestChange{}).Where("name = ?", "a").Update("updated_at", time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC))
+	recorder, response, notModified = request("If-None-Match", etag)
+	assert.False(t, notModified)
+	assert.Equal(t, 200, response.Code)
+
+	etag = recorder.Header().Get("ETag")
+	db.Where("name = ?", "b").Delete(&TestChange{})
+	_, response, notModified = request("If-None-Match", etag)
+	assert.False(t, notModified, "deleting a row changes the listing")
+	assert.Equal(t, 200, response.Code)
+	assert.Equal(t, int64(3), response.Pagination.Total)
+}
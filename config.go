@@ -0,0 +1,215 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ParamStyle selects the query parameters used to request a page
+type ParamStyle string
+
+const (
+	// ParamStylePage reads page and per_page
+	ParamStylePage ParamStyle = "page"
+	// ParamStyleOffset reads offset and limit
+	ParamStyleOffset ParamStyle = "offset"
+	// ParamStyleJSONAPI reads page[number] and page[size], as JSON:API clients send them
+	ParamStyleJSONAPI ParamStyle = "jsonapi"
+)
+
+// pageParams returns the names of the page number and page size parameters of the style
+func (s ParamStyle) pageParams() (string, string) {
+	switch s {
+	case ParamStyleOffset:
+		return "offset", "limit"
+	case ParamStyleJSONAPI:
+		return "page[number]", "page[size]"
+	}
+	return "page", "per_page"
+}
+
+// Environment variables read by LoadConfigFromEnv
+const (
+	EnvDefaultPageSize = "PAGINATION_DEFAULT_PAGE_SIZE"
+	EnvMaxPageSize     = "PAGINATION_MAX_PAGE_SIZE"
+	EnvParamStyle      = "PAGINATION_PARAM_STYLE"
+	EnvStrict          = "PAGINATION_STRICT"
+	EnvSlowQuery       = "PAGINATION_SLOW_QUERY_THRESHOLD"
+	EnvTotalTokenTTL   = "PAGINATION_TOTAL_TOKEN_TTL"
+	EnvCountLimit      = "PAGINATION_COUNT_LIMIT"
+	EnvCountMode       = "PAGINATION_COUNT_MODE"
+	EnvSearchMode      = "PAGINATION_SEARCH_MODE"
+)
+
+// ErrInvalidPagination is returned by ParsePaginationRequest in strict mode
+var ErrInvalidPagination = errors.New("invalid pagination parameters")
+
+// Config holds the package-wide pagination defaults
+type Config struct {
+	DefaultPageSize int        `json:"default_page_size"`
+	MaxPageSize     int        `json:"max_page_size"`
+	ParamStyle      ParamStyle `json:"param_style"`
+	// Strict makes ParsePaginationRequest reject invalid parameters instead of falling back to defaults
+	Strict bool `json:"strict"`
+	// SlowQueryThreshold is the duration from which paginated queries are kept in the
+	// slow query log; zero disables the log
+	SlowQueryThreshold time.Duration `json:"slow_query_threshold"`
+	// TotalTokenTTL makes the helpers issue total tokens valid for this long, so clients
+	// echoing total_token skip the COUNT on later pages; zero disables total reuse
+	TotalTokenTTL time.Duration `json:"total_token_ttl"`
+	// CountLimit makes the helpers stop counting after this many rows and report such
+	// totals as lower bounds; zero counts every row
+	CountLimit int64 `json:"count_limit"`
+	// CountMode makes the helpers skip counts or estimate them, see CountMode
+	CountMode CountMode `json:"count_mode,omitempty"`
+	// SearchMode compares search terms with the search fields of builders without their
+	// own mode, see SearchMode; empty searches for the term anywhere in the fields
+	SearchMode SearchMode `json:"search_mode,omitempty"`
+}
+
+// DefaultConfig returns the built-in defaults
+func DefaultConfig() Config {
+	return Config{
+		DefaultPageSize:    10,
+		MaxPageSize:        100,
+		ParamStyle:         ParamStylePage,
+		SlowQueryThreshold: time.Second,
+	}
+}
+
+var (
+	configMu      sync.RWMutex
+	currentConfig = DefaultConfig()
+)
+
+// Environment defaults are applied at init; invalid values keep the built-in defaults,
+// call LoadConfigFromEnv at startup to surface them
+func init() {
+	_ = LoadConfigFromEnv()
+}
+
+func (c *Config) validate() error {
+	defaults := DefaultConfig()
+	if c.DefaultPageSize <= 0 {
+		c.DefaultPageSize = defaults.DefaultPageSize
+	}
+	if c.MaxPageSize <= 0 {
+		c.MaxPageSize = defaults.MaxPageSize
+	}
+	if c.ParamStyle == "" {
+		c.ParamStyle = defaults.ParamStyle
+	}
+	if c.DefaultPageSize > c.MaxPageSize {
+		return fmt.Errorf("default page size %d exceeds max page size %d", c.DefaultPageSize, c.MaxPageSize)
+	}
+	if c.ParamStyle != ParamStylePage && c.ParamStyle != ParamStyleOffset && c.ParamStyle != ParamStyleJSONAPI {
+		return fmt.Errorf("unsupported param style %q", c.ParamStyle)
+	}
+	if !validCountMode(c.CountMode) {
+		return fmt.Errorf("unsupported count mode %q", c.CountMode)
+	}
+	if c.SearchMode != "" && !validSearchMode(c.SearchMode) {
+		return fmt.Errorf("unsupported search mode %q", c.SearchMode)
+	}
+	return nil
+}
+
+// SetConfig replaces the package-wide defaults
+func SetConfig(config Config) error {
+	if err := config.validate(); err != nil {
+		return err
+	}
+
+	configMu.Lock()
+	defer configMu.Unlock()
+	currentConfig = config
+	return nil
+}
+
+// CurrentConfig returns the package-wide defaults
+func CurrentConfig() Config {
+	configMu.RLock()
+	defer configMu.RUnlock()
+	return currentConfig
+}
+
+// LoadConfig applies defaults from a map keyed by the Env* names; missing keys keep built-in defaults
+func LoadConfig(values map[string]string) error {
+	config := DefaultConfig()
+
+	if value := values[EnvDefaultPageSize]; value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvDefaultPageSize, err)
+		}
+		config.DefaultPageSize = size
+	}
+
+	if value := values[EnvMaxPageSize]; value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvMaxPageSize, err)
+		}
+		config.MaxPageSize = size
+	}
+
+	if value := values[EnvParamStyle]; value != "" {
+		config.ParamStyle = ParamStyle(strings.ToLower(value))
+	}
+
+	if value := values[EnvStrict]; value != "" {
+		strict, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvStrict, err)
+		}
+		config.Strict = strict
+	}
+
+	if value := values[EnvSlowQuery]; value != "" {
+		threshold, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvSlowQuery, err)
+		}
+		config.SlowQueryThreshold = threshold
+	}
+
+	if value := values[EnvTotalTokenTTL]; value != "" {
+		ttl, err := time.ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvTotalTokenTTL, err)
+		}
+		config.TotalTokenTTL = ttl
+	}
+
+	if value := values[EnvCountLimit]; value != "" {
+		limit, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid %s: %w", EnvCountLimit, err)
+		}
+		config.CountLimit = limit
+	}
+
+	if value := values[EnvCountMode]; value != "" {
+		config.CountMode = CountMode(strings.ToLower(value))
+	}
+
+	if value := values[EnvSearchMode]; value != "" {
+		config.SearchMode = SearchMode(strings.ToLower(value))
+	}
+
+	return SetConfig(config)
+}
+
+// LoadConfigFromEnv applies defaults from the PAGINATION_* environment variables
+func LoadConfigFromEnv() error {
+	values := make(map[string]string)
+	for _, name := range []string{EnvDefaultPageSize, EnvMaxPageSize, EnvParamStyle, EnvStrict, EnvSlowQuery, EnvTotalTokenTTL, EnvCountLimit, EnvCountMode, EnvSearchMode} {
+		values[name] = os.Getenv(name)
+	}
+	return LoadConfig(values)
+}
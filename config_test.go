@@ -0,0 +1,67 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func useTestConfig(t *testing.T, values map[string]string) {
+	assert.NoError(t, LoadConfig(values))
+	t.Cleanup(func() { SetConfig(DefaultConfig()) })
+}
+
+func TestLoadConfig(t *testing.T) {
+	useTestConfig(t, map[string]string{
+		EnvDefaultPageSize: "25",
+		EnvMaxPageSize:     "500",
+		EnvParamStyle:      "OFFSET",
+		EnvStrict:          "true",
+	})
+
+	config := CurrentConfig()
+	assert.Equal(t, 25, config.DefaultPageSize)
+	assert.Equal(t, 500, config.MaxPageSize)
+	assert.Equal(t, ParamStyleOffset, config.ParamStyle)
+	assert.True(t, config.Strict)
+}
+
+func TestLoadConfig_Invalid(t *testing.T) {
+	assert.Error(t, LoadConfig(map[string]string{EnvDefaultPageSize: "ten"}))
+	assert.Error(t, LoadConfig(map[string]string{EnvDefaultPageSize: "200", EnvMaxPageSize: "100"}))
+	assert.Error(t, LoadConfig(map[string]string{EnvParamStyle: "cursor"}))
+	assert.Equal(t, DefaultConfig(), CurrentConfig())
+}
+
+func TestBindPagination_Config(t *testing.T) {
+	useTestConfig(t, map[string]string{EnvDefaultPageSize: "20", EnvMaxPageSize: "300"})
+
+	assert.Equal(t, 20, bindTestPagination("/users").PerPage)
+	assert.Equal(t, 250, bindTestPagination("/users?per_page=250").PerPage)
+	assert.Equal(t, 300, bindTestPagination("/users?per_page=301").PerPage)
+}
+
+func TestBindPagination_OffsetStyle(t *testing.T) {
+	useTestConfig(t, map[string]string{EnvParamStyle: "offset"})
+
+	pagination := bindTestPagination("/users?offset=40&limit=20")
+	assert.Equal(t, 3, pagination.Page)
+	assert.Equal(t, 20, pagination.PerPage)
+	assert.Equal(t, 40, pagination.GetOffset())
+}
+
+func TestParsePagination_Strict(t *testing.T) {
+	_, err := ParsePaginationRequest(newTestRequest("/users?per_page=1000"))
+	assert.NoError(t, err)
+
+	useTestConfig(t, map[string]string{EnvStrict: "1"})
+
+	_, err = ParsePaginationRequest(newTestRequest("/users?page=2&per_page=50&order=desc"))
+	assert.NoError(t, err)
+
+	_, err = ParsePaginationRequest(newTestRequest("/users?page=0&per_page=1000&order=up"))
+	assert.ErrorIs(t, err, ErrInvalidPagination)
+	assert.Contains(t, err.Error(), "page must be a positive integer")
+	assert.Contains(t, err.Error(), "per_page must be between 1 and 100")
+	assert.Contains(t, err.Error(), "order must be asc or desc")
+}
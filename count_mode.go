@@ -0,0 +1,119 @@
+package pagination
+
+import (
+	"database/sql"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// CountMode selects how PaginatedQueryWithOptions obtains the total
+type CountMode string
+
+const (
+	// CountExact counts the matching rows
+	CountExact CountMode = ""
+	// CountSkip runs no count; one row past the page is fetched instead to tell whether
+	// another page follows, and the total is that lower bound
+	CountSkip CountMode = "skip"
+	// CountEstimate reads the row estimate of the table statistics for listings without
+	// filters or search on PostgreSQL and MySQL, counting exactly otherwise
+	CountEstimate CountMode = "estimate"
+)
+
+// Total statuses of the count modes, see CalculateCountModePagination
+const (
+	TotalStatusSkipped   = "skipped"
+	TotalStatusEstimated = "estimated"
+)
+
+// CountReport receives the count mode a query actually used, since CountEstimate falls
+// back to an exact count for filtered listings
+type CountReport struct {
+	mode CountMode
+}
+
+// Mode returns the count mode of the total after the query ran
+func (r *CountReport) Mode() CountMode {
+	if r == nil {
+		return CountExact
+	}
+	return r.mode
+}
+
+func (r *CountReport) record(mode CountMode) {
+	if r != nil {
+		r.mode = mode
+	}
+}
+
+// validCountMode reports whether mode is one of the CountMode constants
+func validCountMode(mode CountMode) bool {
+	return mode == CountExact || mode == CountSkip || mode == CountEstimate
+}
+
+// CalculateCountModePagination is CalculatePagination for totals obtained with mode.
+// Skipped counts render total, max_page and last_page as null with has_more telling
+// whether another page follows; estimates are reported with total_status "estimated".
+func CalculateCountModePagination(pagination PaginationRequest, totalCount int64, mode CountMode) PaginationResponse {
+	if pagination.IsDisabled || totalCount == TotalPending {
+		return CalculatePagination(pagination, totalCount)
+	}
+
+	switch mode {
+	case CountSkip:
+		hasMore := totalCount > int64(pagination.Page*pagination.PerPage)
+		return PaginationResponse{
+			Page:        pagination.Page,
+			PerPage:     pagination.PerPage,
+			TotalStatus: TotalStatusSkipped,
+			HasMore:     &hasMore,
+		}
+	case CountEstimate:
+		response := CalculatePagination(pagination, totalCount)
+		response.TotalStatus = TotalStatusEstimated
+		return response
+	}
+	return CalculatePagination(pagination, totalCount)
+}
+
+// estimateCountSQL returns the query reading the row estimate of a table, empty for
+// dialects without one
+func estimateCountSQL(dialect DatabaseDialect) string {
+	switch dialect {
+	case PostgreSQL:
+		return "SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass(?)"
+	case MySQL:
+		return "SELECT TABLE_ROWS FROM information_schema.TABLES WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?"
+	}
+	return ""
+}
+
+// unfilteredCount reports whether countQuery counts every row of its table
+func unfilteredCount(countQuery *gorm.DB) bool {
+	for _, name := range []string{"WHERE", "GROUP BY"} {
+		if _, ok := countQuery.Statement.Clauses[name]; ok {
+			return false
+		}
+	}
+	return len(countQuery.Statement.Joins) == 0
+}
+
+// estimatedCount reads the row estimate of table; ok is false when the dialect has none
+// or the table has no statistics yet
+func estimatedCount(db *gorm.DB, table string) (int64, bool, error) {
+	query := estimateCountSQL(DetectDialect(db))
+	if query == "" {
+		return 0, false, nil
+	}
+
+	var estimate sql.NullInt64
+	if err := db.Session(&gorm.Session{NewDB: true}).Raw(query, baseTableName(table)).Scan(&estimate).Error; err != nil {
+		return 0, false, fmt.Errorf("failed to estimate count: %w", err)
+	}
+	// PostgreSQL reports -1 for tables never analyzed, MySQL NULL for views
+	if !estimate.Valid || estimate.Int64 < 0 {
+		return 0, false, nil
+	}
+	return estimate.Int64, true, nil
+}
@@ -0,0 +1,82 @@
+package pagination
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestPaginatedQuery_CountSkip(t *testing.T) {
+	db := setupTestDB()
+	statements := captureSQL(db)
+	builder := NewSimpleQueryBuilder("test_users")
+	report := &CountReport{}
+	options := PaginatedQueryOptions{Dialect: SQLite, CountMode: CountSkip, CountReport: report}
+
+	users, total, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil, options)
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, int64(3), total, "one row past the page")
+	assert.Equal(t, CountSkip, report.Mode())
+	for _, statement := range *statements {
+		assert.NotContains(t, statement, "count(*)")
+	}
+	assert.Contains(t, (*statements)[0], "LIMIT 3")
+
+	users, total, err = PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 3, PerPage: 2}, nil, options)
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, int64(5), total)
+}
+
+func TestPaginatedQuery_CountEstimateFallsBack(t *testing.T) {
+	db := setupTestDB()
+	report := &CountReport{}
+	options := PaginatedQueryOptions{Dialect: SQLite, CountMode: CountEstimate, CountReport: report, AlwaysCount: true}
+
+	_, total, err := PaginatedQueryWithOptions[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 1, PerPage: 2}, nil, options)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.Equal(t, CountExact, report.Mode(), "SQLite keeps no row estimate")
+
+	filtered := NewSimpleQueryBuilder("test_users").WithFilters(func(query *gorm.DB) *gorm.DB {
+		return query.Where("age > ?", 30)
+	})
+	assert.False(t, unfilteredCount(filtered.ApplyFilters(db.Table("test_users"))))
+	assert.True(t, unfilteredCount(db.Table("test_users")))
+}
+
+func TestEstimateCountSQL(t *testing.T) {
+	assert.Contains(t, estimateCountSQL(PostgreSQL), "pg_class")
+	assert.Contains(t, estimateCountSQL(MySQL), "information_schema.TABLES")
+	assert.Empty(t, estimateCountSQL(SQLite))
+}
+
+func TestCalculateCountModePagination(t *testing.T) {
+	meta := CalculateCountModePagination(PaginationRequest{Page: 1, PerPage: 2}, 3, CountSkip)
+	encoded, err := json.Marshal(meta)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"page":1,"per_page":2,"max_page":null,"total":null,"last_page":null,"total_status":"skipped","has_more":true}`, string(encoded))
+
+	meta = CalculateCountModePagination(PaginationRequest{Page: 3, PerPage: 2}, 5, CountSkip)
+	assert.False(t, *meta.HasMore)
+
+	meta = CalculateCountModePagination(PaginationRequest{Page: 1, PerPage: 10}, 1000, CountEstimate)
+	assert.Equal(t, TotalStatusEstimated, meta.TotalStatus)
+	assert.Equal(t, int64(100), meta.MaxPage)
+}
+
+func TestLoadConfig_CountMode(t *testing.T) {
+	useTestConfig(t, map[string]string{EnvCountMode: "SKIP"})
+	assert.Equal(t, CountSkip, CurrentConfig().CountMode)
+
+	request := newTestRequest("/users?page=1&per_page=2")
+	_, meta, err := Paginate[TestUser](setupTestDB(), request, WithTable("test_users"))
+	assert.NoError(t, err)
+	assert.Equal(t, TotalStatusSkipped, meta.TotalStatus)
+	assert.True(t, *meta.HasMore)
+
+	assert.Error(t, LoadConfig(map[string]string{EnvCountMode: "guess"}))
+}
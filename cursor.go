@@ -0,0 +1,189 @@
+package pagination
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// CursorPaginator pages an ordering by the sort key of the last row seen instead of an
+// offset, so deep pages cost as much as the first one. Cursors are opaque tokens holding
+// the sort key values of the row a page starts after (or before, paging backwards).
+// Sort columns must not be NULL.
+type CursorPaginator struct {
+	// Columns is the ordering; the last column must be unique, e.g. the primary key
+	Columns []KeysetColumn
+	// Scope binds cursors to the filters in use, see CursorScope
+	Scope string
+}
+
+// cursorPosition is the decoded form of a keyset cursor
+type cursorPosition struct {
+	Values []interface{} `json:"v"`
+	// Times lists the values that are timestamps, decoded back into time.Time
+	Times    []int `json:"t,omitempty"`
+	Backward bool  `json:"b,omitempty"`
+}
+
+// NewCursorPaginator orders by an ORDER BY clause such as "age desc, name", appending
+// tiebreaker as the last column unless the clause already sorts by it
+func NewCursorPaginator(ordering string, tiebreaker string) (CursorPaginator, error) {
+	columns, err := parseSortColumns(ordering, tiebreaker)
+	if err != nil {
+		return CursorPaginator{}, err
+	}
+	return CursorPaginator{Columns: columns}, nil
+}
+
+// Encode returns the cursor of the page after item, or before it when backward is set
+func (p CursorPaginator) Encode(db *gorm.DB, item interface{}, backward bool) (string, error) {
+	values, err := keysetValues(db, item, p.Columns)
+	if err != nil {
+		return "", err
+	}
+
+	position := cursorPosition{Values: values, Backward: backward}
+	for i, value := range values {
+		switch v := value.(type) {
+		case time.Time:
+			position.Times = append(position.Times, i)
+		case *time.Time:
+			if v != nil {
+				position.Values[i] = *v
+				position.Times = append(position.Times, i)
+			}
+		}
+	}
+	return encodeScopedToken(position, p.Scope)
+}
+
+// Decode returns the sort key values of cursor and whether it pages backwards
+func (p CursorPaginator) Decode(cursor string) ([]interface{}, bool, error) {
+	var position cursorPosition
+	if err := decodeScopedToken(cursor, p.Scope, &position); err != nil {
+		return nil, false, err
+	}
+	if len(position.Values) != len(p.Columns) {
+		return nil, false, fmt.Errorf("%w: cursor has %d sort keys, the ordering %d", ErrInvalidToken, len(position.Values), len(p.Columns))
+	}
+
+	values := normalizeTokenValues(position.Values)
+	for _, i := range position.Times {
+		if i < 0 || i >= len(values) {
+			return nil, false, ErrInvalidToken
+		}
+		text, ok := values[i].(string)
+		if !ok {
+			return nil, false, ErrInvalidToken
+		}
+		t, err := time.Parse(time.RFC3339Nano, text)
+		if err != nil {
+			return nil, false, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+		}
+		values[i] = t
+	}
+	return values, position.Backward, nil
+}
+
+// Page orders query and selects up to limit+1 rows following cursor, the extra row
+// telling whether another page follows; backward cursors select in reverse order
+func (p CursorPaginator) Page(query *gorm.DB, cursor string, limit int) (*gorm.DB, bool, error) {
+	columns := p.Columns
+	var values []interface{}
+	backward := false
+	if cursor != "" {
+		var err error
+		if values, backward, err = p.Decode(cursor); err != nil {
+			return nil, false, err
+		}
+	}
+	if backward {
+		columns = make([]KeysetColumn, len(p.Columns))
+		for i, column := range p.Columns {
+			columns[i] = KeysetColumn{Name: column.Name, Desc: !column.Desc}
+		}
+	}
+	return applyKeyset(query, columns, values).Limit(limit + 1), backward, nil
+}
+
+// PaginatedCursorQuery returns the page of the builder's listing following cursor, an
+// empty cursor starting at the beginning. It uses the filters, search and sort of the
+// request, ordered by the primary key last, and pagination.PerPage rows per page. The
+// metadata carries next_cursor and prev_cursor and, as cursors skip counting, no total.
+func PaginatedCursorQuery[T any](
+	db *gorm.DB,
+	builder QueryBuilder,
+	pagination PaginationRequest,
+	includes []string,
+	cursor string,
+) ([]T, PaginationResponse, error) {
+	limit := pagination.PerPage
+	if limit <= 0 {
+		limit = CurrentConfig().DefaultPageSize
+	}
+
+	idColumn, err := primaryKeyColumn[T](db)
+	if err != nil {
+		return nil, PaginationResponse{}, err
+	}
+	ordering := sortClause(builder, pagination)
+	paginator, err := NewCursorPaginator(ordering, idColumn)
+	if err != nil {
+		return nil, PaginationResponse{}, err
+	}
+	// The scope covers the filters and search, so cursors cannot be replayed under others
+	filtered := filteredSet(db, builder, pagination)
+	paginator.Scope = CursorScope(builder.GetTableName(), ordering, totalCacheKey(filtered))
+
+	query, backward, err := paginator.Page(filtered, cursor, limit)
+	if err != nil {
+		return nil, PaginationResponse{}, err
+	}
+	validatedIncludes := validateIncludes(builder, includes)
+	loaders := includeLoaders(builder)
+	for _, include := range validatedIncludes {
+		if _, ok := loaders[include]; ok {
+			continue
+		}
+		if query, err = preloadInclude[T](query, builder, include); err != nil {
+			return nil, PaginationResponse{}, err
+		}
+	}
+
+	var result []T
+	if err := query.Find(&result).Error; err != nil {
+		return nil, PaginationResponse{}, fmt.Errorf("failed to fetch records: %w", err)
+	}
+
+	more := len(result) > limit
+	if more {
+		result = result[:limit]
+	}
+	hasNext, hasPrev := more, cursor != ""
+	if backward {
+		for i, j := 0, len(result)-1; i < j; i, j = i+1, j-1 {
+			result[i], result[j] = result[j], result[i]
+		}
+		hasNext, hasPrev = true, more
+	}
+	if result == nil {
+		result = []T{}
+	}
+	if err := runIncludeLoaders(query, loaders, validatedIncludes, &result); err != nil {
+		return nil, PaginationResponse{}, err
+	}
+
+	meta := PaginationResponse{PerPage: limit, TotalStatus: TotalStatusUnknown}
+	if len(result) > 0 && hasNext {
+		if meta.NextCursor, err = paginator.Encode(db, &result[len(result)-1], false); err != nil {
+			return nil, PaginationResponse{}, err
+		}
+	}
+	if len(result) > 0 && hasPrev {
+		if meta.PrevCursor, err = paginator.Encode(db, &result[0], true); err != nil {
+			return nil, PaginationResponse{}, err
+		}
+	}
+	return result, meta, nil
+}
@@ -0,0 +1,53 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorKeyring_Rotation(t *testing.T) {
+	oldKey := bytes.Repeat([]byte{1}, 32)
+	newKey := bytes.Repeat([]byte{2}, 32)
+
+	oldKeyring, err := NewCursorKeyring("v1", map[string][]byte{"v1": oldKey})
+	assert.NoError(t, err)
+	rotated, err := NewCursorKeyring("v2", map[string][]byte{"v1": oldKey, "v2": newKey})
+	assert.NoError(t, err)
+
+	token, err := oldKeyring.Seal([]byte(`{"id":42}`))
+	assert.NoError(t, err)
+	assert.True(t, len(token) > 3 && token[:3] == "v1.")
+	assert.NotContains(t, token, base64.RawURLEncoding.EncodeToString([]byte(`{"id":42}`)))
+
+	payload, err := rotated.Open(token)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":42}`, string(payload))
+
+	_, err = rotated.Open(token[:len(token)-2] + "xx")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	_, err = NewCursorKeyring("v3", map[string][]byte{"v1": oldKey})
+	assert.Error(t, err)
+}
+
+func TestUseCursorEncryption(t *testing.T) {
+	keyring, _ := NewCursorKeyring("k1", map[string][]byte{"k1": bytes.Repeat([]byte{7}, 16)})
+	plainToken, _ := encodeToken(map[string]int{"id": 3})
+
+	UseCursorEncryption(keyring)
+	defer UseCursorEncryption(nil)
+
+	token, err := encodeToken(map[string]int{"id": 3})
+	assert.NoError(t, err)
+	assert.Contains(t, token, "k1.")
+
+	var decoded map[string]interface{}
+	assert.NoError(t, decodeToken(token, &decoded))
+	assert.ErrorIs(t, decodeToken(plainToken, &decoded), ErrInvalidToken)
+
+	keyring.AllowPlaintext = true
+	assert.NoError(t, decodeToken(plainToken, &decoded))
+}
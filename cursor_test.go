@@ -0,0 +1,110 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginatedCursorQuery(t *testing.T) {
+	db := setupTestDB()
+	db.Create(&TestUser{Name: "Dora Twin", Age: 30})
+	builder := NewSimpleQueryBuilder("test_users")
+	request := PaginationRequest{PerPage: 2, Sort: "age", Order: "desc"}
+
+	var pages [][]string
+	var last PaginationResponse
+	cursor := ""
+	for {
+		users, meta, err := PaginatedCursorQuery[TestUser](db, builder, request, nil, cursor)
+		assert.NoError(t, err)
+		assert.Equal(t, TotalStatusUnknown, meta.TotalStatus)
+		assert.Equal(t, cursor != "", meta.PrevCursor != "")
+		pages = append(pages, userNames(users))
+		last = meta
+		if meta.NextCursor == "" {
+			break
+		}
+		cursor = meta.NextCursor
+	}
+	assert.Equal(t, [][]string{
+		{"Bob Johnson", "Charlie Wilson"},
+		{"Jane Smith", "Dora Twin"},
+		{"Alice Brown", "John Doe"},
+	}, pages, "ties on age are broken by id")
+
+	users, meta, err := PaginatedCursorQuery[TestUser](db, builder, request, nil, last.PrevCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Jane Smith", "Dora Twin"}, userNames(users), "backward pages keep the listing order")
+	assert.NotEmpty(t, meta.NextCursor)
+
+	users, meta, err = PaginatedCursorQuery[TestUser](db, builder, request, nil, meta.PrevCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bob Johnson", "Charlie Wilson"}, userNames(users))
+	assert.Empty(t, meta.PrevCursor, "the first page has no previous page")
+	assert.NotEmpty(t, meta.NextCursor)
+}
+
+func TestPaginatedCursorQuery_FiltersAndScope(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users").WithSearchFields("name").WithFilters(Where(testUserFields.Age.Gt(26)))
+	request := PaginationRequest{PerPage: 1, Search: "o"}
+
+	users, meta, err := PaginatedCursorQuery[TestUser](db, builder, request, nil, "")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bob Johnson"}, userNames(users))
+
+	users, _, err = PaginatedCursorQuery[TestUser](db, builder, request, nil, meta.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Alice Brown"}, userNames(users))
+
+	request.Sort = "age"
+	_, _, err = PaginatedCursorQuery[TestUser](db, builder, request, nil, meta.NextCursor)
+	assert.True(t, errors.Is(err, ErrTokenScope), "cursors are bound to the sort")
+
+	request.Sort = ""
+	other := NewSimpleQueryBuilder("test_users").WithSearchFields("name").WithFilters(Where(testUserFields.Age.Gt(30)))
+	_, _, err = PaginatedCursorQuery[TestUser](db, other, request, nil, meta.NextCursor)
+	assert.ErrorIs(t, err, ErrTokenScope, "cursors are bound to the filter values")
+
+	unscoped, err := encodeToken(cursorPosition{})
+	assert.NoError(t, err)
+	_, _, err = PaginatedCursorQuery[TestUser](db, builder, request, nil, unscoped)
+	assert.ErrorIs(t, err, ErrTokenScope, "scoped endpoints reject tokens without a scope")
+
+	_, _, err = PaginatedCursorQuery[TestUser](db, builder, request, nil, "garbage")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestCursorPaginator_Timestamps(t *testing.T) {
+	db := setupChangesDB()
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, name := range []string{"a", "b", "c"} {
+		db.Create(&TestChange{Name: name, UpdatedAt: base.Add(time.Duration(i) * time.Hour)})
+	}
+
+	paginator, err := NewCursorPaginator("updated_at desc", "id")
+	assert.NoError(t, err)
+	assert.Equal(t, []KeysetColumn{{Name: "updated_at", Desc: true}, {Name: "id"}}, paginator.Columns)
+
+	var first []TestChange
+	query, _, err := paginator.Page(db.Model(&TestChange{}), "", 1)
+	assert.NoError(t, err)
+	assert.NoError(t, query.Find(&first).Error)
+	assert.Len(t, first, 2, "one extra row tells another page follows")
+
+	cursor, err := paginator.Encode(db, &first[0], false)
+	assert.NoError(t, err)
+	values, backward, err := paginator.Decode(cursor)
+	assert.NoError(t, err)
+	assert.False(t, backward)
+	assert.True(t, base.Add(2*time.Hour).Equal(values[0].(time.Time)), "timestamps decode as time.Time")
+
+	var next []TestChange
+	query, _, err = paginator.Page(db.Model(&TestChange{}), cursor, 5)
+	assert.NoError(t, err)
+	assert.NoError(t, query.Find(&next).Error)
+	assert.Equal(t, []string{"b", "a"}, []string{next[0].Name, next[1].Name})
+}
@@ -0,0 +1,49 @@
+package pagination
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// InRange filters column to start <= column < end; like the other date helpers it compiles
+// to a range predicate that works on every dialect and can use an index on column
+func InRange(column string, start, end time.Time) func(*gorm.DB) *gorm.DB {
+	return func(query *gorm.DB) *gorm.DB {
+		if !isValidSortField(column) {
+			query.AddError(fmt.Errorf("invalid date column %q", column))
+			return query
+		}
+		return query.Where(column+" >= ? AND "+column+" < ?", start, end)
+	}
+}
+
+// InYear replaces YEAR(column) = year, with the year in UTC
+func InYear(column string, year int) func(*gorm.DB) *gorm.DB {
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return InRange(column, start, start.AddDate(1, 0, 0))
+}
+
+// InMonth replaces YEAR(column) = year AND MONTH(column) = month, in UTC
+func InMonth(column string, year int, month time.Month) func(*gorm.DB) *gorm.DB {
+	start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return InRange(column, start, start.AddDate(0, 1, 0))
+}
+
+// OnDate replaces DATE(column) = date, for the calendar day of date in its location
+func OnDate(column string, date time.Time) func(*gorm.DB) *gorm.DB {
+	start := startOfDay(date)
+	return InRange(column, start, start.AddDate(0, 0, 1))
+}
+
+// BetweenDates filters column to the calendar days from through to, both included,
+// in the location of each date
+func BetweenDates(column string, from, to time.Time) func(*gorm.DB) *gorm.DB {
+	return InRange(column, startOfDay(from), startOfDay(to).AddDate(0, 0, 1))
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, t.Location())
+}
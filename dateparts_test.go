@@ -0,0 +1,41 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func signupPlans(t *testing.T, db *gorm.DB, scope func(*gorm.DB) *gorm.DB) int64 {
+	var count int64
+	assert.NoError(t, db.Model(&TestSignup{}).Scopes(scope).Count(&count).Error)
+	return count
+}
+
+func TestDateParts(t *testing.T) {
+	db := setupSignupsDB()
+
+	assert.Equal(t, int64(7), signupPlans(t, db, InYear("created_at", 2024)))
+	assert.Equal(t, int64(0), signupPlans(t, db, InYear("created_at", 2023)))
+	assert.Equal(t, int64(7), signupPlans(t, db, InMonth("created_at", 2024, time.January)))
+	assert.Equal(t, int64(0), signupPlans(t, db, InMonth("created_at", 2024, time.February)))
+	assert.Equal(t, int64(3), signupPlans(t, db, OnDate("created_at", time.Date(2024, 1, 4, 15, 0, 0, 0, time.UTC))))
+	assert.Equal(t, int64(4), signupPlans(t, db, BetweenDates("created_at", time.Date(2024, 1, 3, 12, 0, 0, 0, time.UTC), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC))))
+}
+
+func TestDateParts_SQL(t *testing.T) {
+	db := setupSignupsDB()
+
+	sql := db.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var signups []TestSignup
+		return tx.Scopes(InYear("created_at", 2024)).Find(&signups)
+	})
+	assert.Contains(t, sql, "created_at >= \"2024-01-01 00:00:00\" AND created_at < \"2025-01-01 00:00:00\"")
+	assert.NotContains(t, sql, "YEAR(")
+
+	var signups []TestSignup
+	err := db.Scopes(InYear("created_at) OR (1=1", 2024)).Find(&signups).Error
+	assert.Error(t, err)
+}
@@ -0,0 +1,128 @@
+package pagination
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// DeadlineOptions configures client-supplied query deadlines
+type DeadlineOptions struct {
+	// Headers are checked in order; defaults to X-Request-Timeout and Grpc-Timeout
+	Headers []string
+	// Max caps any timeout and also applies when no timeout was requested
+	Max time.Duration
+	// Default applies when the client sends no usable header; zero means no deadline
+	Default time.Duration
+}
+
+func (o *DeadlineOptions) validate() {
+	if len(o.Headers) == 0 {
+		o.Headers = []string{"X-Request-Timeout", "Grpc-Timeout"}
+	}
+}
+
+// ParseTimeoutHeader parses a timeout header value
+// Accepted formats are Go durations ("1500ms", "2s") and plain seconds ("5", "0.5").
+func ParseTimeoutHeader(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+
+	if duration, err := time.ParseDuration(value); err == nil {
+		return duration, duration > 0
+	}
+
+	if seconds, err := strconv.ParseFloat(value, 64); err == nil {
+		duration := time.Duration(seconds * float64(time.Second))
+		return duration, duration > 0
+	}
+
+	return 0, false
+}
+
+// ParseGRPCTimeout parses a grpc-timeout header value: up to 8 digits followed by
+// one of the units H, M, S, m (milliseconds), u or n
+func ParseGRPCTimeout(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if len(value) < 2 || len(value) > 9 {
+		return 0, false
+	}
+
+	units := map[byte]time.Duration{
+		'H': time.Hour,
+		'M': time.Minute,
+		'S': time.Second,
+		'm': time.Millisecond,
+		'u': time.Microsecond,
+		'n': time.Nanosecond,
+	}
+	unit, ok := units[value[len(value)-1]]
+	if !ok {
+		return 0, false
+	}
+
+	amount, err := strconv.ParseInt(value[:len(value)-1], 10, 64)
+	if err != nil || amount <= 0 {
+		return 0, false
+	}
+	return time.Duration(amount) * unit, true
+}
+
+// RequestDeadline returns the timeout requested by the client headers, capped by options.Max
+func RequestDeadline(header http.Header, options DeadlineOptions) (time.Duration, bool) {
+	options.validate()
+
+	timeout := options.Default
+	for _, name := range options.Headers {
+		parse := ParseTimeoutHeader
+		if strings.EqualFold(name, "Grpc-Timeout") {
+			parse = ParseGRPCTimeout
+		}
+		if parsed, ok := parse(header.Get(name)); ok {
+			timeout = parsed
+			break
+		}
+	}
+
+	if options.Max > 0 && (timeout <= 0 || timeout > options.Max) {
+		timeout = options.Max
+	}
+
+	return timeout, timeout > 0
+}
+
+// WithRequestDeadline derives a context whose deadline follows the client timeout headers
+func WithRequestDeadline(ctx context.Context, header http.Header, options DeadlineOptions) (context.Context, context.CancelFunc) {
+	timeout, ok := RequestDeadline(header, options)
+	if !ok {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, timeout)
+}
+
+// DeadlineHandler applies the client-supplied deadline to the request context, which
+// the request helpers pass on to their queries
+func DeadlineHandler(options DeadlineOptions) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := WithRequestDeadline(r.Context(), r.Header, options)
+			defer cancel()
+
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// withRequestContext binds the database session to the context of a request
+func withRequestContext(db *gorm.DB, r *http.Request) *gorm.DB {
+	if db == nil || r == nil {
+		return db
+	}
+	return db.WithContext(r.Context())
+}
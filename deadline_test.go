@@ -0,0 +1,102 @@
+package pagination
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTimeoutHeaders(t *testing.T) {
+	duration, ok := ParseTimeoutHeader("1500ms")
+	assert.True(t, ok)
+	assert.Equal(t, 1500*time.Millisecond, duration)
+
+	duration, ok = ParseTimeoutHeader("2.5")
+	assert.True(t, ok)
+	assert.Equal(t, 2500*time.Millisecond, duration)
+
+	_, ok = ParseTimeoutHeader("soon")
+	assert.False(t, ok)
+
+	duration, ok = ParseGRPCTimeout("100m")
+	assert.True(t, ok)
+	assert.Equal(t, 100*time.Millisecond, duration)
+
+	_, ok = ParseGRPCTimeout("123456789S")
+	assert.False(t, ok)
+}
+
+func TestRequestDeadline(t *testing.T) {
+	header := http.Header{}
+	header.Set("Grpc-Timeout", "3S")
+
+	timeout, ok := RequestDeadline(header, DeadlineOptions{Max: 10 * time.Second})
+	assert.True(t, ok)
+	assert.Equal(t, 3*time.Second, timeout)
+
+	header.Set("X-Request-Timeout", "1m")
+	timeout, _ = RequestDeadline(header, DeadlineOptions{Max: 10 * time.Second})
+	assert.Equal(t, 10*time.Second, timeout)
+
+	_, ok = RequestDeadline(http.Header{}, DeadlineOptions{})
+	assert.False(t, ok)
+}
+
+func TestDeadlineHandler(t *testing.T) {
+	var remaining time.Duration
+	router := DeadlineHandler(DeadlineOptions{Max: 5 * time.Second})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		deadline, ok := r.Context().Deadline()
+		assert.True(t, ok)
+		remaining = time.Until(deadline)
+	}))
+
+	request, _ := http.NewRequest("GET", "/", nil)
+	request.Header.Set("X-Request-Timeout", "200ms")
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	assert.True(t, remaining > 0 && remaining <= 200*time.Millisecond)
+}
+
+func TestPaginatedQueryContext(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users").WithDialect(SQLite)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, _, err := PaginatedQueryContext[TestUser](ctx, db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, _, err = PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil, PaginatedQueryOptions{Dialect: SQLite, Context: ctx})
+	assert.ErrorIs(t, err, context.Canceled)
+
+	users, total, err := PaginatedQueryContext[TestUser](context.Background(), db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, int64(5), total)
+}
+
+type includableUserFilter struct {
+	testUserFilter
+}
+
+func (f *includableUserFilter) Validate() { f.ValidatePagination() }
+
+func TestPaginatedQueryWithIncludable_RequestContext(t *testing.T) {
+	db := setupTestDB()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	filter := &includableUserFilter{}
+	assert.NoError(t, BindFilterRequest(httptest.NewRequest("GET", "/users?per_page=2", nil).WithContext(ctx), filter))
+	assert.Equal(t, ctx, filter.RequestContext())
+
+	_, _, err := PaginatedQueryWithIncludable[TestUser](db, filter)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	_, _, err = PaginatedQueryWithIncludableContext[TestUser](context.Background(), db, filter)
+	assert.NoError(t, err)
+}
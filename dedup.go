@@ -0,0 +1,108 @@
+package pagination
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultBoundaryTokenTTL bounds how long a page boundary is remembered
+const defaultBoundaryTokenTTL = 10 * time.Minute
+
+// BoundaryDedup keeps rows of an offset listing from showing up twice while rows are
+// inserted ahead of the pages being read: each page issues a token with the primary keys
+// of its last rows, and the next page, when the client echoes the token, fetches that
+// many extra rows and drops the ones already shown. Rows deleted meanwhile can still be
+// skipped; cursors avoid both. Invalid or expired tokens are ignored.
+type BoundaryDedup struct {
+	// Token is the boundary_token echoed by the client, empty on the first page
+	Token string
+	// Size is how many trailing rows of a page the token carries, 20 by default;
+	// it bounds how many inserted rows between two requests are absorbed
+	Size int
+	// TTL is the lifetime of issued tokens, ten minutes by default
+	TTL time.Duration
+
+	issued  string
+	dropped int
+}
+
+type boundaryToken struct {
+	Page int           `json:"p"`
+	IDs  []interface{} `json:"i"`
+}
+
+// IssuedToken returns the token to send back to the client after the query ran
+func (d *BoundaryDedup) IssuedToken() string {
+	if d == nil {
+		return ""
+	}
+	return d.issued
+}
+
+// Dropped returns how many rows of the page were dropped as already shown
+func (d *BoundaryDedup) Dropped() int {
+	if d == nil {
+		return 0
+	}
+	return d.dropped
+}
+
+// seen returns the keys of the rows shown at the end of the page before page
+func (d *BoundaryDedup) seen(scope string, page int) map[string]bool {
+	if d == nil || d.Token == "" {
+		return nil
+	}
+
+	var decoded boundaryToken
+	if err := decodeScopedToken(d.Token, scope, &decoded); err != nil || decoded.Page != page-1 {
+		return nil
+	}
+	seen := make(map[string]bool, len(decoded.IDs))
+	for _, id := range normalizeTokenValues(decoded.IDs) {
+		seen[fmt.Sprint(id)] = true
+	}
+	return seen
+}
+
+// dedupPage drops the rows of result whose keys were seen, keeping at most limit rows,
+// and issues the token of the page
+func dedupPage[T any](db *gorm.DB, d *BoundaryDedup, scope string, page, limit int, seen map[string]bool, result []T) ([]T, error) {
+	idColumn, err := primaryKeyColumn[T](db)
+	if err != nil {
+		return nil, err
+	}
+
+	kept := result[:0]
+	var ids []interface{}
+	for i := range result {
+		id, err := columnValue(db, &result[i], idColumn)
+		if err != nil {
+			return nil, err
+		}
+		if seen[fmt.Sprint(id)] {
+			d.dropped++
+			continue
+		}
+		if len(kept) == limit {
+			break
+		}
+		kept = append(kept, result[i])
+		ids = append(ids, id)
+	}
+
+	size := d.Size
+	if size <= 0 {
+		size = 20
+	}
+	ttl := d.TTL
+	if ttl <= 0 {
+		ttl = defaultBoundaryTokenTTL
+	}
+	token, err := encodeExpiringToken(boundaryToken{Page: page, IDs: ids[max(len(ids)-size, 0):]}, scope, ttl)
+	if err == nil {
+		d.issued = token
+	}
+	return kept, nil
+}
@@ -0,0 +1,50 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBoundaryDedup(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users").WithDefaultSort("id desc")
+	page := func(number int, dedup *BoundaryDedup) []string {
+		users, _, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: number, PerPage: 2}, nil, PaginatedQueryOptions{Dialect: SQLite, Dedup: dedup})
+		assert.NoError(t, err)
+		return userNames(users)
+	}
+
+	first := &BoundaryDedup{}
+	assert.Equal(t, []string{"Charlie Wilson", "Alice Brown"}, page(1, first))
+	assert.NotEmpty(t, first.IssuedToken())
+
+	// A row inserted ahead of the listing shifts Alice onto the second page
+	db.Create(&TestUser{Name: "Dave", Age: 40})
+	assert.Equal(t, []string{"Alice Brown", "Bob Johnson"}, page(2, nil))
+
+	second := &BoundaryDedup{Token: first.IssuedToken()}
+	assert.Equal(t, []string{"Bob Johnson", "Jane Smith"}, page(2, second))
+	assert.Equal(t, 1, second.Dropped())
+
+	third := &BoundaryDedup{Token: second.IssuedToken()}
+	assert.Equal(t, []string{"John Doe"}, page(3, third), "the shift carries on through later pages")
+	assert.Equal(t, 1, third.Dropped())
+
+	stale := &BoundaryDedup{Token: first.IssuedToken()}
+	assert.Equal(t, []string{"Jane Smith", "John Doe"}, page(3, stale), "tokens only apply to the following page")
+	assert.Equal(t, 0, stale.Dropped())
+}
+
+func TestBoundaryDedup_Size(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users")
+	dedup := &BoundaryDedup{Size: 1}
+	_, _, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 3}, nil, PaginatedQueryOptions{Dialect: SQLite, Dedup: dedup})
+	assert.NoError(t, err)
+
+	var token boundaryToken
+	assert.NoError(t, decodeToken(dedup.IssuedToken(), &token))
+	assert.Equal(t, 1, token.Page)
+	assert.Equal(t, []interface{}{int64(3)}, normalizeTokenValues(token.IDs))
+}
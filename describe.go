@@ -0,0 +1,106 @@
+package pagination
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// describeMethods are the methods answered on a described resource
+var describeMethods = []string{http.MethodGet, http.MethodOptions}
+
+// ResourceDescription describes a paginated resource to clients discovering it with an
+// OPTIONS request: the Capabilities of its filter, its page size limits and HAL links
+// to example pages
+type ResourceDescription struct {
+	FilterCapabilities
+	Methods         []string            `json:"methods"`
+	PageSize        PageSizeDescription `json:"page_size"`
+	DefaultIncludes []string            `json:"default_includes,omitempty"`
+	MaxIncludes     int                 `json:"max_includes,omitempty"`
+	Links           map[string]HALLink  `json:"_links"`
+}
+
+// PageSizeDescription names the page size parameter with its default and maximum
+type PageSizeDescription struct {
+	Param   string `json:"param"`
+	Default int    `json:"default"`
+	Max     int    `json:"max"`
+}
+
+// HALLink is a link of a HAL document; templated links are RFC 6570 URI templates
+type HALLink struct {
+	Href      string `json:"href"`
+	Templated bool   `json:"templated,omitempty"`
+}
+
+// Describe returns the description of the resource served at path by filter. The page
+// size limits and includes are those registered for its table, and the links are self,
+// the first two pages and a "find" template listing every query parameter.
+func Describe(filter QueryBuilder, path string) ResourceDescription {
+	pageParam, sizeParam := CurrentConfig().ParamStyle.pageParams()
+	limits := tablePageSizeLimits(filter.GetTableName())
+	description := ResourceDescription{
+		FilterCapabilities: Capabilities(filter),
+		Methods:            describeMethods,
+		PageSize:           PageSizeDescription{Param: sizeParam, Default: limits.Default, Max: limits.Max},
+	}
+	if config, ok := LookupTable(filter.GetTableName()); ok {
+		description.DefaultIncludes = config.DefaultIncludes
+		description.MaxIncludes = config.MaxIncludes
+	}
+
+	pageLink := func(page int) HALLink {
+		query := url.Values{}
+		if CurrentConfig().ParamStyle == ParamStyleOffset {
+			query.Set(pageParam, strconv.Itoa((page-1)*limits.Default))
+		} else {
+			query.Set(pageParam, strconv.Itoa(page))
+		}
+		query.Set(sizeParam, strconv.Itoa(limits.Default))
+		return HALLink{Href: path + "?" + query.Encode()}
+	}
+
+	params := []string{pageParam, sizeParam, "sort", "order"}
+	if len(description.Searchable) > 0 {
+		params = append(params, "search", "search_mode")
+	}
+	if len(description.Includes) > 0 {
+		params = append(params, "includes")
+	}
+	for _, capability := range description.Filters {
+		for _, operator := range capability.Operators {
+			params = append(params, operator.Param)
+		}
+	}
+	for i, param := range params {
+		// Brackets are reserved in URI template variable names
+		params[i] = url.QueryEscape(param)
+	}
+
+	description.Links = map[string]HALLink{
+		"self":  {Href: path},
+		"first": pageLink(1),
+		"next":  pageLink(2),
+		"find":  {Href: path + "{?" + strings.Join(params, ",") + "}", Templated: true},
+	}
+	return description
+}
+
+// DescribeHTTPHandler answers OPTIONS requests on a resource served by filter with its
+// Describe; register it for OPTIONS on the path of the resource. Responses list the methods of
+// the resource in Allow and Access-Control-Allow-Methods; CORS preflight requests are
+// answered with 204 and no body.
+func DescribeHTTPHandler(filter QueryBuilder) http.Handler {
+	allow := strings.Join(describeMethods, ", ")
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Allow", allow)
+		w.Header().Set("Access-Control-Allow-Methods", allow)
+		if r.Header.Get("Access-Control-Request-Method") != "" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, http.StatusOK, Describe(filter, r.URL.Path))
+	})
+}
@@ -0,0 +1,57 @@
+package pagination
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribe(t *testing.T) {
+	registerTestTable(t, TableConfig{DefaultPageSize: 20, MaxPageSize: 200, DefaultIncludes: []string{"profile"}, MaxIncludes: 2})
+	description := Describe(&catalogFilter{}, "/users")
+
+	assert.Equal(t, "test_users", description.Table)
+	assert.Equal(t, []string{"GET", "OPTIONS"}, description.Methods)
+	assert.Equal(t, PageSizeDescription{Param: "per_page", Default: 20, Max: 200}, description.PageSize)
+	assert.Equal(t, []string{"profile"}, description.DefaultIncludes)
+	assert.Equal(t, 2, description.MaxIncludes)
+	assert.Equal(t, HALLink{Href: "/users"}, description.Links["self"])
+	assert.Equal(t, HALLink{Href: "/users?page=2&per_page=20"}, description.Links["next"])
+	assert.Equal(t, HALLink{
+		Href:      "/users{?page,per_page,sort,order,search,search_mode,includes,name,min_age,max_age,plan,verified,since}",
+		Templated: true,
+	}, description.Links["find"])
+
+	useTestConfig(t, map[string]string{EnvParamStyle: "offset"})
+	offsets := Describe(&catalogFilter{}, "/users")
+	assert.Equal(t, "limit", offsets.PageSize.Param)
+	assert.Equal(t, HALLink{Href: "/users?limit=20&offset=20"}, offsets.Links["next"])
+}
+
+func TestDescribeHTTPHandler(t *testing.T) {
+	router := http.NewServeMux()
+	router.Handle("OPTIONS /users", DescribeHTTPHandler(&testUserFilter{}))
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest("OPTIONS", "/users", nil))
+	assert.Equal(t, 200, recorder.Code)
+	assert.Equal(t, "GET, OPTIONS", recorder.Header().Get("Allow"))
+
+	var body map[string]interface{}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	assert.Equal(t, "test_users", body["table"])
+	assert.Contains(t, body, "page_size")
+	assert.Contains(t, body["_links"], "find")
+
+	preflight := httptest.NewRequest("OPTIONS", "/users", nil)
+	preflight.Header.Set("Origin", "https://app.example.com")
+	preflight.Header.Set("Access-Control-Request-Method", "GET")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, preflight)
+	assert.Equal(t, 204, recorder.Code)
+	assert.Equal(t, "GET, OPTIONS", recorder.Header().Get("Access-Control-Allow-Methods"))
+	assert.Empty(t, recorder.Body.String())
+}
@@ -0,0 +1,85 @@
+package pagination
+
+import (
+	"context"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// QuoteIdentifier quotes each part of a possibly qualified identifier for the dialect,
+// e.g. for columns named like reserved words ("order", "user"); embedded quotes are doubled
+func QuoteIdentifier(dialect DatabaseDialect, identifier string) string {
+	open, close := `"`, `"`
+	switch dialect {
+	case MySQL:
+		open, close = "`", "`"
+	case SQLServer:
+		open, close = "[", "]"
+	}
+
+	parts := strings.Split(identifier, ".")
+	for i, part := range parts {
+		parts[i] = open + strings.ReplaceAll(part, close, close+close) + close
+	}
+	return strings.Join(parts, ".")
+}
+
+// rowNumPage pages an ordered query with ROWNUM. ROWNUM is assigned before the ORDER BY
+// of its own query, so the ordered query is numbered one level up and filtered one more.
+func rowNumPage(db *gorm.DB, query *gorm.DB, offset, limit int) *gorm.DB {
+	numbered := db.Table("(?) paged", query).
+		Select("paged.*, ROWNUM pagination_rownum").
+		Where("ROWNUM <= ?", offset+limit)
+	return db.Table("(?) numbered", numbered).
+		Where("pagination_rownum > ?", offset).
+		Order("pagination_rownum")
+}
+
+// PaginatedStatements holds the SQL of a paginated query, with placeholders
+type PaginatedStatements struct {
+	// Count is empty when the options skip the count, e.g. a reused total
+	Count string
+	Data  string
+}
+
+type statementRecorderKey struct{}
+
+// PaginatedSQL renders the count and data statements PaginatedQueryWithOptions runs on
+// the connection without executing them, e.g. to check the SQL generated for SQL Server
+// or Oracle in CI. Includes loaded by separate queries are not rendered.
+func PaginatedSQL[T any](
+	db *gorm.DB,
+	builder QueryBuilder,
+	pagination PaginationRequest,
+	includes []string,
+	options PaginatedQueryOptions,
+) (PaginatedStatements, error) {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	statements := &PaginatedStatements{}
+	dryRun := db.Session(&gorm.Session{DryRun: true, Context: context.WithValue(ctx, statementRecorderKey{}, statements)})
+	_, _, err := paginatedQuery[T](dryRun, builder, pagination, includes, options)
+	return *statements, err
+}
+
+// recordStatement keeps the SQL of a query rendered by PaginatedSQL
+func recordStatement(kind string, query *gorm.DB) {
+	if query.Statement.Context == nil {
+		return
+	}
+	statements, ok := query.Statement.Context.Value(statementRecorderKey{}).(*PaginatedStatements)
+	if !ok {
+		return
+	}
+
+	sql := renderQuerySQL(kind, query)
+	if kind == "count" {
+		statements.Count = sql
+	} else {
+		statements.Data = sql
+	}
+}
@@ -0,0 +1,95 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// namedDialector renders SQL through SQLite while reporting another dialect, so the
+// dialect-specific code paths can be checked without a SQL Server or Oracle instance
+type namedDialector struct {
+	gorm.Dialector
+	name string
+}
+
+func (d namedDialector) Name() string {
+	return d.name
+}
+
+func openNamedDialect(t *testing.T, name string) *gorm.DB {
+	db, err := gorm.Open(namedDialector{Dialector: sqlite.Open(":memory:"), name: name}, &gorm.Config{})
+	assert.NoError(t, err)
+	return db
+}
+
+func TestDetectDialect_Oracle(t *testing.T) {
+	assert.Equal(t, Oracle, DetectDialect(openNamedDialect(t, "oracle")))
+	assert.Equal(t, Oracle, DetectDialect(openNamedDialect(t, "godror")))
+	assert.Equal(t, SQLServer, DetectDialect(openNamedDialect(t, "sqlserver")))
+}
+
+func TestQuoteIdentifier(t *testing.T) {
+	assert.Equal(t, "`users`.`order`", QuoteIdentifier(MySQL, "users.order"))
+	assert.Equal(t, "[users].[order]", QuoteIdentifier(SQLServer, "users.order"))
+	assert.Equal(t, `"users"."order"`, QuoteIdentifier(Oracle, "users.order"))
+	assert.Equal(t, `"user"`, QuoteIdentifier(PostgreSQL, "user"))
+	assert.Equal(t, "[odd]]name]", QuoteIdentifier(SQLServer, "odd]name"))
+}
+
+func TestPaginatedSQL(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users").WithSearchFields("name").WithFilters(func(query *gorm.DB) *gorm.DB {
+		return query.Where("age > ?", 26)
+	})
+
+	statements, err := PaginatedSQL[TestUser](db, builder, PaginationRequest{Page: 3, PerPage: 10, Search: "o"}, nil, PaginatedQueryOptions{Dialect: SQLite})
+	assert.NoError(t, err)
+	assert.Equal(t, "SELECT count(*) FROM `test_users` WHERE age > ? AND (name LIKE ?)", statements.Count)
+	assert.Equal(t, "SELECT * FROM `test_users` WHERE age > ? AND (name LIKE ?) ORDER BY id asc LIMIT 10 OFFSET 20", statements.Data)
+}
+
+func TestPaginatedSQL_SQLServerPreparedPage(t *testing.T) {
+	db := openNamedDialect(t, "sqlserver")
+
+	statements, err := PaginatedSQL[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 2, PerPage: 10}, nil, PaginatedQueryOptions{Dialect: SQLServer, PrepareStatements: true})
+	assert.NoError(t, err)
+	assert.Contains(t, statements.Data, "ORDER BY id asc OFFSET ? ROWS FETCH NEXT ? ROWS ONLY")
+	assert.NotContains(t, statements.Data, "LIMIT")
+}
+
+func TestPaginatedSQL_OracleRowNumPaging(t *testing.T) {
+	db := openNamedDialect(t, "oracle")
+
+	statements, err := PaginatedSQL[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 3, PerPage: 10}, nil, PaginatedQueryOptions{Dialect: Oracle, RowNumPaging: true})
+	assert.NoError(t, err)
+	assert.Equal(t,
+		"SELECT * FROM (SELECT paged.*, ROWNUM pagination_rownum FROM (SELECT * FROM `test_users` ORDER BY id asc) paged WHERE ROWNUM <= ?) numbered"+
+			" WHERE pagination_rownum > ? ORDER BY pagination_rownum",
+		statements.Data)
+	assert.NotContains(t, statements.Data, "LIMIT")
+}
+
+func TestBuildTreeQuery_OracleConnectBy(t *testing.T) {
+	options := TreeOptions{TableName: "regions", MaxDepth: 2, Limit: 5}
+	assert.NoError(t, options.validate())
+
+	query, args := buildTreeQuery(Oracle, options, "/0000000001")
+	assert.Equal(t, "SELECT * FROM (SELECT * FROM ("+
+		"SELECT t.*, LEVEL - 1 AS tree_depth, SYS_CONNECT_BY_PATH(LPAD(TO_CHAR(t.id), 10, '0'), '/') AS tree_path"+
+		" FROM regions t START WITH t.parent_id IS NULL CONNECT BY PRIOR t.id = t.parent_id AND LEVEL <= 3"+
+		") tree WHERE tree_path > ? ORDER BY tree_path) WHERE ROWNUM <= 6", query)
+	assert.Equal(t, []interface{}{"/0000000001"}, args)
+
+	options.RootID = 7
+	query, args = buildTreeQuery(Oracle, options, "")
+	assert.Contains(t, query, "START WITH t.parent_id = ?")
+	assert.Equal(t, []interface{}{7}, args)
+}
+
+func TestOracleSampleAndRandom(t *testing.T) {
+	assert.Equal(t, " SAMPLE (12.5000)", tableSampleClause(Oracle, 12.5))
+	assert.Equal(t, "DBMS_RANDOM.VALUE", getRandomFunction(Oracle))
+}
@@ -0,0 +1,46 @@
+package pagination
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// multipliesRows reports whether the filters joined other tables, which repeats rows of
+// the main table for one-to-many relations; grouped queries already return one row per group
+func multipliesRows(query *gorm.DB) bool {
+	if len(query.Statement.Joins) == 0 {
+		return false
+	}
+	_, grouped := query.Statement.Clauses["GROUP BY"]
+	return !grouped
+}
+
+// tableQualifier returns the name columns of the main table are qualified with,
+// the alias of "users u" or "users AS u"
+func tableQualifier(table string) string {
+	fields := strings.Fields(table)
+	if len(fields) == 0 {
+		return table
+	}
+	return fields[len(fields)-1]
+}
+
+// distinctPrimaryKey returns the qualified primary key counted once per row of T;
+// result types GORM cannot parse fall back to id
+func distinctPrimaryKey[T any](db *gorm.DB, table string) string {
+	column, err := primaryKeyColumn[T](db)
+	if err != nil {
+		column = "id"
+	}
+	return tableQualifier(table) + "." + column
+}
+
+// distinctRows removes the duplicates joins introduce into the data query; without an
+// explicit select only the columns of the main table are fetched
+func distinctRows(query *gorm.DB, table string) *gorm.DB {
+	if len(query.Statement.Selects) > 0 {
+		return query.Distinct()
+	}
+	return query.Distinct(tableQualifier(table) + ".*")
+}
@@ -0,0 +1,62 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func joinedTeamsBuilder(table string) *SimpleQueryBuilder {
+	qualifier := tableQualifier(table)
+	return NewSimpleQueryBuilder(table).
+		WithDefaultSort(qualifier + ".id asc").
+		WithFilters(func(query *gorm.DB) *gorm.DB {
+			return query.Joins("JOIN test_members m ON m.team_id = "+qualifier+".id").Where("m.bio = ?", "long bio")
+		})
+}
+
+func TestJoinDeduplication(t *testing.T) {
+	db := setupTeamsDB()
+	db.Create(&TestTeam{Name: "Blue", Members: []TestMember{{Name: "Cid", Bio: "long bio"}}})
+	request := PaginationRequest{Page: 1, PerPage: 10}
+
+	for _, table := range []string{"test_teams", "test_teams t"} {
+		teams, total, err := PaginatedQueryWithOptions[TestTeam](db, joinedTeamsBuilder(table), request, nil, PaginatedQueryOptions{Dialect: SQLite})
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2), total, table)
+		assert.Len(t, teams, 2, table)
+		assert.Equal(t, "Red", teams[0].Name)
+		assert.Equal(t, "Blue", teams[1].Name)
+	}
+
+	teams, total, err := PaginatedQueryWithOptions[TestTeam](db, joinedTeamsBuilder("test_teams"), request, nil, PaginatedQueryOptions{
+		Dialect:                  SQLite,
+		DisableJoinDeduplication: true,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Len(t, teams, 3)
+}
+
+func TestJoinDeduplication_ExplicitSelect(t *testing.T) {
+	db := setupTeamsDB()
+	builder := NewChainableQueryBuilder("test_teams").
+		Select("test_teams.id", "test_teams.name").
+		Join("JOIN test_members ON test_members.team_id = test_teams.id")
+	builder.WithDefaultSort("test_teams.id asc")
+
+	teams, total, err := PaginatedQueryWithOptions[TestTeam](db, builder, PaginationRequest{Page: 1, PerPage: 10}, nil, PaginatedQueryOptions{Dialect: SQLite})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, teams, 1)
+	assert.Empty(t, teams[0].Motto, "only the selected columns are fetched")
+}
+
+func TestMultipliesRows(t *testing.T) {
+	db := setupTeamsDB()
+
+	assert.False(t, multipliesRows(db.Table("test_teams").Where("id = ?", 1)))
+	assert.True(t, multipliesRows(db.Table("test_teams").Joins("JOIN test_members ON test_members.team_id = test_teams.id")))
+	assert.False(t, multipliesRows(db.Table("test_teams").Joins("JOIN test_members ON test_members.team_id = test_teams.id").Group("test_teams.id")))
+}
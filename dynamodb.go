@@ -0,0 +1,73 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+)
+
+// DynamoDBAttribute is a key attribute value in DynamoDB's JSON form; key attributes
+// are always strings, numbers or binary
+type DynamoDBAttribute struct {
+	S string `json:"S,omitempty"`
+	N string `json:"N,omitempty"`
+	B []byte `json:"B,omitempty"`
+}
+
+// DynamoDBKey is a LastEvaluatedKey or ExclusiveStartKey
+type DynamoDBKey map[string]DynamoDBAttribute
+
+// DynamoDBSource queries a DynamoDB table or index page by page. Query passes startKey
+// as ExclusiveStartKey, nil for the first page, and limit as Limit, and returns the
+// items with the LastEvaluatedKey of the response.
+type DynamoDBSource[T any] struct {
+	Query func(ctx context.Context, startKey DynamoDBKey, limit int) ([]T, DynamoDBKey, error)
+	// Scope binds cursors to the key condition and filters, see CursorScope
+	Scope string
+	// MaxRequests caps the queries made to fill one page when a filter expression
+	// leaves responses short (default 10)
+	MaxRequests int
+}
+
+// PaginateDynamoDB returns the page of source following cursor in the standard
+// envelope metadata. LastEvaluatedKey becomes the opaque pagination.next_cursor; DynamoDB
+// cannot count, so the total is reported as unknown. Short responses are topped up with
+// further queries, so a page only ends early once MaxRequests is reached. When the last
+// item of a table ends a page DynamoDB still returns a key, and the page after it is empty.
+func PaginateDynamoDB[T any](ctx context.Context, source DynamoDBSource[T], cursor string, pageSize int) ([]T, PaginationResponse, error) {
+	if pageSize <= 0 {
+		pageSize = CurrentConfig().DefaultPageSize
+	}
+	if source.MaxRequests <= 0 {
+		source.MaxRequests = 10
+	}
+
+	var startKey DynamoDBKey
+	if cursor != "" {
+		if err := decodeScopedToken(cursor, source.Scope, &startKey); err != nil {
+			return nil, PaginationResponse{}, err
+		}
+	}
+
+	data := []T{}
+	for requests := 0; requests < source.MaxRequests; requests++ {
+		items, lastKey, err := source.Query(ctx, startKey, pageSize-len(data))
+		if err != nil {
+			return nil, PaginationResponse{}, fmt.Errorf("failed to query DynamoDB: %w", err)
+		}
+		data = append(data, items...)
+		startKey = lastKey
+		if len(startKey) == 0 || len(data) >= pageSize {
+			break
+		}
+	}
+
+	meta := PaginationResponse{PerPage: pageSize, TotalStatus: TotalStatusUnknown}
+	if len(startKey) > 0 {
+		next, err := encodeScopedToken(startKey, source.Scope)
+		if err != nil {
+			return nil, PaginationResponse{}, err
+		}
+		meta.NextCursor = next
+	}
+	return data, meta, nil
+}
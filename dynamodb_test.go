@@ -0,0 +1,104 @@
+package pagination
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDynamoDB serves items 1..rows keyed by a numeric sort key, evaluating limit items
+// per query and keeping only those accepted by filter like a FilterExpression
+func fakeDynamoDB(rows int, filter func(int) bool, queries *int) DynamoDBSource[int] {
+	return DynamoDBSource[int]{
+		Query: func(ctx context.Context, startKey DynamoDBKey, limit int) ([]int, DynamoDBKey, error) {
+			*queries++
+			start := 0
+			if startKey != nil {
+				start, _ = strconv.Atoi(startKey["sk"].N)
+			}
+			var items []int
+			last := start
+			for id := start + 1; id <= rows && id <= start+limit; id++ {
+				last = id
+				if filter == nil || filter(id) {
+					items = append(items, id)
+				}
+			}
+			if last == start || last == rows && limit > rows-start {
+				return items, nil, nil
+			}
+			return items, DynamoDBKey{"pk": {S: "tenant#1"}, "sk": {N: strconv.Itoa(last)}}, nil
+		},
+	}
+}
+
+func TestPaginateDynamoDB(t *testing.T) {
+	queries := 0
+	source := fakeDynamoDB(5, nil, &queries)
+
+	data, meta, err := PaginateDynamoDB(context.Background(), source, "", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, data)
+	assert.Equal(t, TotalStatusUnknown, meta.TotalStatus)
+	assert.NotEmpty(t, meta.NextCursor)
+
+	encoded, err := json.Marshal(meta)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"per_page":2,"max_page":null,"total":null,"last_page":null,"total_status":"unknown","next_cursor":"`+meta.NextCursor+`"}`, string(encoded))
+
+	data, meta, err = PaginateDynamoDB(context.Background(), source, meta.NextCursor, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 4}, data)
+
+	data, meta, err = PaginateDynamoDB(context.Background(), source, meta.NextCursor, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{5}, data)
+	assert.Empty(t, meta.NextCursor)
+	assert.Equal(t, 3, queries)
+}
+
+func TestPaginateDynamoDB_TopsUpFilteredPages(t *testing.T) {
+	queries := 0
+	even := func(id int) bool { return id%2 == 0 }
+
+	data, meta, err := PaginateDynamoDB(context.Background(), fakeDynamoDB(20, even, &queries), "", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 4, 6}, data)
+	assert.Greater(t, queries, 1)
+
+	source := fakeDynamoDB(20, func(int) bool { return false }, &queries)
+	source.MaxRequests = 2
+	queries = 0
+	data, meta, err = PaginateDynamoDB(context.Background(), source, "", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{}, data)
+	assert.Equal(t, 2, queries)
+	assert.NotEmpty(t, meta.NextCursor, "the page ends early but the listing goes on")
+}
+
+func TestPaginateDynamoDB_Errors(t *testing.T) {
+	queries := 0
+	source := fakeDynamoDB(5, nil, &queries)
+	source.Scope = CursorScope("tenant#1")
+	_, meta, err := PaginateDynamoDB(context.Background(), source, "", 2)
+	assert.NoError(t, err)
+	source.Scope = CursorScope("tenant#2")
+	_, _, err = PaginateDynamoDB(context.Background(), source, meta.NextCursor, 2)
+	assert.ErrorIs(t, err, ErrTokenScope)
+
+	failing := DynamoDBSource[int]{Query: func(context.Context, DynamoDBKey, int) ([]int, DynamoDBKey, error) {
+		return nil, nil, errors.New("throughput exceeded")
+	}}
+	_, _, err = PaginateDynamoDB(context.Background(), failing, "", 2)
+	assert.ErrorContains(t, err, "failed to query DynamoDB: throughput exceeded")
+}
+
+func TestClient_FollowsMetaNextCursor(t *testing.T) {
+	next, err := NewClient(nil).nextURL("http://api/events?limit=2", "", "", &PaginationResponse{TotalStatus: TotalStatusUnknown, NextCursor: "abc"})
+	assert.NoError(t, err)
+	assert.Equal(t, "http://api/events?cursor=abc&limit=2", next)
+}
@@ -0,0 +1,186 @@
+// Package echoadapter adapts pagination to Echo. The functions carry the names of the
+// Gin helpers the root package used to provide and take an echo.Context instead, handing
+// its request and response to the transport-neutral API.
+package echoadapter
+
+import (
+	"net/http"
+	"time"
+
+	pagination "github.com/Caknoooo/go-pagination"
+	"github.com/labstack/echo/v4"
+	"gorm.io/gorm"
+)
+
+// BindPagination binds the pagination parameters of the request
+func BindPagination(c echo.Context) pagination.PaginationRequest {
+	request, _ := pagination.BindPaginationRequest(c.Request())
+	return request
+}
+
+// BindPaginationWithWarnings binds pagination parameters like BindPagination and reports
+// every parameter that was replaced by a default or clamped
+func BindPaginationWithWarnings(c echo.Context) (pagination.PaginationRequest, []pagination.PaginationWarning) {
+	return pagination.BindPaginationRequest(c.Request())
+}
+
+// ParsePagination binds pagination parameters like BindPagination; in strict mode
+// invalid values are reported instead of replaced by defaults
+func ParsePagination(c echo.Context) (pagination.PaginationRequest, error) {
+	return pagination.ParsePaginationRequest(c.Request())
+}
+
+// BindFilter binds custom filter fields, pagination and includes from the query string
+func BindFilter(c echo.Context, filter interface{}) error {
+	return pagination.BindFilterRequest(c.Request(), filter)
+}
+
+// BindAndValidateFilter binds pagination and query parameters, then validates the filter
+func BindAndValidateFilter(c echo.Context, filter pagination.IncludableQueryBuilder) error {
+	if err := BindFilter(c, filter); err != nil {
+		return err
+	}
+	filter.Validate()
+	return nil
+}
+
+// PaginateWithCustomFilter provides pagination using custom filter that implements Filterable interface
+func PaginateWithCustomFilter[T any](db *gorm.DB, c echo.Context, filter pagination.Filterable) ([]T, pagination.PaginationResponse, error) {
+	return pagination.PaginateRequest[T](db, c.Request(), filter)
+}
+
+// PaginatedAPIResponseWithCustomFilter creates a complete API response using custom filter
+func PaginatedAPIResponseWithCustomFilter[T any](db *gorm.DB, c echo.Context, filter pagination.Filterable, message string) pagination.PaginatedResponse {
+	return pagination.PaginatedAPIResponseRequest[T](db, c.Request(), filter, message)
+}
+
+// PaginateModel provides a simple way to paginate any GORM model
+func PaginateModel[T any](db *gorm.DB, c echo.Context, tableName string, searchFields []string) ([]T, pagination.PaginationResponse, error) {
+	return pagination.PaginateTableRequest[T](db, c.Request(), tableName, searchFields, []string{})
+}
+
+// PaginateWithIncludes provides pagination with preloaded relationships
+func PaginateWithIncludes[T any](db *gorm.DB, c echo.Context, tableName string, searchFields []string, includes []string) ([]T, pagination.PaginationResponse, error) {
+	return pagination.PaginateTableRequest[T](db, c.Request(), tableName, searchFields, includes)
+}
+
+// PaginateWithFilter provides pagination with custom filters
+func PaginateWithFilter[T any](
+	db *gorm.DB,
+	c echo.Context,
+	tableName string,
+	searchFields []string,
+	filterFunc func(*gorm.DB) *gorm.DB,
+) ([]T, pagination.PaginationResponse, error) {
+	return pagination.PaginateTableRequest[T](db, c.Request(), tableName, searchFields, []string{}, filterFunc)
+}
+
+// QuickPaginate provides the simplest way to paginate with minimal configuration
+func QuickPaginate[T any](db *gorm.DB, c echo.Context, tableName string) ([]T, pagination.PaginationResponse, error) {
+	return pagination.PaginateTableRequest[T](db, c.Request(), tableName, nil, []string{})
+}
+
+// PaginatedAPIResponse creates a complete API response with pagination
+func PaginatedAPIResponse[T any](db *gorm.DB, c echo.Context, tableName string, searchFields []string, message string) pagination.PaginatedResponse {
+	return PaginatedAPIResponseWithIncludes[T](db, c, tableName, searchFields, []string{}, message)
+}
+
+// PaginatedAPIResponseWithIncludes creates a complete API response with pagination and includes
+func PaginatedAPIResponseWithIncludes[T any](
+	db *gorm.DB,
+	c echo.Context,
+	tableName string,
+	searchFields []string,
+	includes []string,
+	message string,
+) pagination.PaginatedResponse {
+	data, paginationResponse, err := PaginateWithIncludes[T](db, c, tableName, searchFields, includes)
+	if err != nil {
+		return pagination.NewPaginatedResponse(500, "Internal Server Error: "+err.Error(), nil, pagination.PaginationResponse{})
+	}
+	return pagination.NewPaginatedResponse(200, message, data, paginationResponse)
+}
+
+// Links returns the links of the pages next to the page meta describes
+func Links(c echo.Context, meta pagination.PaginationResponse) pagination.PageLinks {
+	return pagination.NewPageLinks(c.Request(), meta)
+}
+
+// NotModified sets the Last-Modified header and answers 304 when the request's
+// If-Modified-Since is not older than lastModified; it reports whether the response was written
+func NotModified(c echo.Context, lastModified time.Time) bool {
+	return pagination.CheckNotModified(c.Response(), c.Request(), lastModified)
+}
+
+// StreamPaginatedQuery writes the standard envelope of a paginated query to the
+// response row by row, see pagination.StreamPaginatedResponse
+func StreamPaginatedQuery[T any](
+	c echo.Context,
+	db *gorm.DB,
+	builder pagination.QueryBuilder,
+	request pagination.PaginationRequest,
+	options pagination.PaginatedQueryOptions,
+	stream pagination.StreamOptions,
+) error {
+	return pagination.StreamPaginatedResponse[T](c.Response(), c.Request(), db, builder, request, options, stream)
+}
+
+// Middleware runs a net/http middleware in an Echo chain like echo.WrapMiddleware; the
+// route path is available to the middleware as the request pattern
+func Middleware(middleware func(http.Handler) http.Handler) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) (err error) {
+			r := c.Request()
+			if r.Pattern == "" && c.Path() != "" {
+				r = r.WithContext(r.Context())
+				r.Pattern = c.Path()
+			}
+			middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				c.SetRequest(r)
+				c.SetResponse(echo.NewResponse(w, c.Echo()))
+				err = next(c)
+			})).ServeHTTP(c.Response(), r)
+			return err
+		}
+	}
+}
+
+// AdaptivePageSize reduces the page size bound by the handlers of each route under load
+func AdaptivePageSize(sizer *pagination.AdaptivePageSize) echo.MiddlewareFunc {
+	return Middleware(sizer.Handler)
+}
+
+// MaxPageSizeMiddleware caps the page size bound by the handlers of a route at max
+func MaxPageSizeMiddleware(max int) echo.MiddlewareFunc {
+	return Middleware(pagination.MaxPageSizeHandler(max))
+}
+
+// PolicyMiddleware resolves the PagePolicy of each request of a route, see pagination.PagePolicy
+func PolicyMiddleware(resolve pagination.PolicyResolver) echo.MiddlewareFunc {
+	return Middleware(pagination.PolicyHandler(resolve))
+}
+
+// DeadlineMiddleware applies the client-supplied deadline to the request context
+func DeadlineMiddleware(options pagination.DeadlineOptions) echo.MiddlewareFunc {
+	return Middleware(pagination.DeadlineHandler(options))
+}
+
+// CanonicalMiddleware normalizes listing queries so equivalent URLs share one cache entry
+func CanonicalMiddleware(options pagination.CanonicalOptions) echo.MiddlewareFunc {
+	return Middleware(pagination.CanonicalHandler(options))
+}
+
+// AdminHandler serves pagination.Introspect as JSON; mount it behind authentication
+func AdminHandler(options pagination.AdminOptions) echo.HandlerFunc {
+	return echo.WrapHandler(pagination.AdminHTTPHandler(options))
+}
+
+// CapabilitiesHandler serves the capabilities of filter as JSON
+func CapabilitiesHandler(filter pagination.QueryBuilder) echo.HandlerFunc {
+	return echo.WrapHandler(pagination.CapabilitiesHTTPHandler(filter))
+}
+
+// DescribeHandler answers OPTIONS requests with the description of the resource served by filter
+func DescribeHandler(filter pagination.QueryBuilder) echo.HandlerFunc {
+	return echo.WrapHandler(pagination.DescribeHTTPHandler(filter))
+}
@@ -0,0 +1,107 @@
+package echoadapter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pagination "github.com/Caknoooo/go-pagination"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type testUser struct {
+	ID   uint   `json:"id" gorm:"primaryKey"`
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+type testUserFilter struct {
+	pagination.BaseFilter
+	MinAge int `form:"min_age"`
+}
+
+func (f *testUserFilter) ApplyFilters(query *gorm.DB) *gorm.DB {
+	if f.MinAge > 0 {
+		query = query.Where("age >= ?", f.MinAge)
+	}
+	return query
+}
+func (f *testUserFilter) GetTableName() string      { return "test_users" }
+func (f *testUserFilter) GetSearchFields() []string { return []string{"name"} }
+func (f *testUserFilter) GetDefaultSort() string    { return "id asc" }
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&testUser{}))
+	for _, user := range []testUser{{Name: "John", Age: 25}, {Name: "Jane", Age: 30}, {Name: "Bob", Age: 35}} {
+		db.Create(&user)
+	}
+	return db
+}
+
+func serve(e *echo.Echo, target string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	e.ServeHTTP(recorder, httptest.NewRequest("GET", target, nil))
+	return recorder
+}
+
+func TestPaginatedAPIResponseWithCustomFilter(t *testing.T) {
+	db := setupTestDB(t)
+	e := echo.New()
+	e.GET("/users", func(c echo.Context) error {
+		response := PaginatedAPIResponseWithCustomFilter[testUser](db, c, &testUserFilter{}, "ok")
+		return c.JSON(response.Code, response.WithLinks(c.Request()))
+	})
+
+	recorder := serve(e, "/users?min_age=30&per_page=1")
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response struct {
+		Data       []testUser                    `json:"data"`
+		Pagination pagination.PaginationResponse `json:"pagination"`
+		Links      pagination.PageLinks          `json:"links"`
+	}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "Jane", response.Data[0].Name)
+	assert.Equal(t, int64(2), response.Pagination.Total)
+	assert.Equal(t, "/users?min_age=30&page=2&per_page=1", response.Links.Next)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, serve(e, "/users?min_age=old").Code)
+}
+
+func TestBindPagination(t *testing.T) {
+	e := echo.New()
+	var bound pagination.PaginationRequest
+	var warnings []pagination.PaginationWarning
+	e.GET("/users", func(c echo.Context) error {
+		bound, warnings = BindPaginationWithWarnings(c)
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	serve(e, "/users?page=3&per_page=500&sort=-age")
+	assert.Equal(t, 3, bound.Page)
+	assert.Equal(t, "desc", bound.Order)
+	assert.Len(t, warnings, 1)
+}
+
+func TestMiddleware(t *testing.T) {
+	e := echo.New()
+	sizer := &pagination.AdaptivePageSize{Threshold: time.Millisecond, Samples: 1}
+	sizer.Record("/users/:team", time.Second)
+	e.Use(CanonicalMiddleware(pagination.CanonicalOptions{Redirect: true}), AdaptivePageSize(sizer))
+
+	var bound pagination.PaginationRequest
+	e.GET("/users/:team", func(c echo.Context) error {
+		bound = BindPagination(c)
+		return c.NoContent(http.StatusNoContent)
+	})
+
+	assert.Equal(t, http.StatusMovedPermanently, serve(e, "/users/red?page=1").Code)
+	assert.Equal(t, http.StatusNoContent, serve(e, "/users/red?page=1&per_page=100").Code)
+	assert.Equal(t, 50, bound.PerPage, "endpoints are told apart by their route path")
+}
@@ -0,0 +1,104 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// EventRecord is one record of an event log such as a Kafka partition
+type EventRecord[T any] struct {
+	Offset    int64     `json:"offset"`
+	Timestamp time.Time `json:"timestamp"`
+	Key       string    `json:"key,omitempty"`
+	Value     T         `json:"value"`
+}
+
+// EventLogSource reads one partition of a topic
+type EventLogSource[T any] struct {
+	Topic     string
+	Partition int32
+	// Watermarks returns the first offset still retained and the offset the next
+	// record will be written at
+	Watermarks func(ctx context.Context) (low, high int64, err error)
+	// Read returns up to max records from offset on in offset order
+	Read func(ctx context.Context, offset int64, max int) ([]EventRecord[T], error)
+	// Newest pages from the latest record backwards, as debugging UIs browse events
+	Newest bool
+	// Scope binds cursors to anything else the listing depends on, see CursorScope;
+	// the topic and partition are always part of it
+	Scope string
+}
+
+// eventLogCursor holds the offset the next page starts at, or ends before when paging
+// backwards
+type eventLogCursor struct {
+	Offset int64 `json:"o"`
+}
+
+// PaginateEventLog returns the page of a partition following cursor. Cursors hold offsets,
+// so pages stay put while new records are appended; records removed by retention are
+// skipped. Compaction and transaction markers leave gaps in the offsets, so backward pages
+// can be short. Event logs have no totals.
+func PaginateEventLog[T any](ctx context.Context, source EventLogSource[T], cursor string, pageSize int) ([]EventRecord[T], PaginationResponse, error) {
+	if pageSize <= 0 {
+		pageSize = CurrentConfig().DefaultPageSize
+	}
+	scope := CursorScope(source.Topic, strconv.Itoa(int(source.Partition)), source.Scope)
+
+	low, high, err := source.Watermarks(ctx)
+	if err != nil {
+		return nil, PaginationResponse{}, fmt.Errorf("failed to read watermarks of %s/%d: %w", source.Topic, source.Partition, err)
+	}
+
+	position := eventLogCursor{Offset: low}
+	if source.Newest {
+		position.Offset = high
+	}
+	if cursor != "" {
+		if err := decodeScopedToken(cursor, scope, &position); err != nil {
+			return nil, PaginationResponse{}, err
+		}
+	}
+
+	start, end := position.Offset, high
+	if source.Newest {
+		end = min(position.Offset, high)
+		start = max(end-int64(pageSize), low)
+	}
+	start = max(start, low)
+
+	records := []EventRecord[T]{}
+	if start < end {
+		read, err := source.Read(ctx, start, int(min(int64(pageSize), end-start)))
+		if err != nil {
+			return nil, PaginationResponse{}, fmt.Errorf("failed to read %s/%d at offset %d: %w", source.Topic, source.Partition, start, err)
+		}
+		for _, record := range read {
+			if record.Offset < end {
+				records = append(records, record)
+			}
+		}
+	}
+
+	var next eventLogCursor
+	hasMore := false
+	if source.Newest {
+		for i, j := 0, len(records)-1; i < j; i, j = i+1, j-1 {
+			records[i], records[j] = records[j], records[i]
+		}
+		next.Offset, hasMore = start, start > low
+	} else if len(records) > 0 {
+		next.Offset = records[len(records)-1].Offset + 1
+		hasMore = next.Offset < high
+	}
+
+	meta := PaginationResponse{PerPage: pageSize, TotalStatus: TotalStatusUnknown}
+	if hasMore {
+		if meta.NextCursor, err = encodeScopedToken(next, scope); err != nil {
+			return nil, PaginationResponse{}, err
+		}
+	}
+	return records, meta, nil
+}
@@ -0,0 +1,93 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakePartition holds records at the given offsets; gaps stand for compacted records
+func fakePartition(offsets []int64, low, high int64) EventLogSource[string] {
+	return EventLogSource[string]{
+		Topic:     "orders",
+		Partition: 3,
+		Watermarks: func(ctx context.Context) (int64, int64, error) {
+			return low, high, nil
+		},
+		Read: func(ctx context.Context, offset int64, max int) ([]EventRecord[string], error) {
+			var records []EventRecord[string]
+			for _, o := range offsets {
+				if o >= offset && len(records) < max {
+					records = append(records, EventRecord[string]{Offset: o, Value: "event"})
+				}
+			}
+			return records, nil
+		},
+	}
+}
+
+func eventOffsets(records []EventRecord[string]) []int64 {
+	offsets := make([]int64, len(records))
+	for i, record := range records {
+		offsets[i] = record.Offset
+	}
+	return offsets
+}
+
+func collectEventPages(t *testing.T, source EventLogSource[string], pageSize int) [][]int64 {
+	var pages [][]int64
+	cursor := ""
+	for len(pages) < 10 {
+		records, meta, err := PaginateEventLog(context.Background(), source, cursor, pageSize)
+		assert.NoError(t, err)
+		assert.Equal(t, TotalStatusUnknown, meta.TotalStatus)
+		pages = append(pages, eventOffsets(records))
+		if meta.NextCursor == "" {
+			break
+		}
+		cursor = meta.NextCursor
+	}
+	return pages
+}
+
+func TestPaginateEventLog_Forward(t *testing.T) {
+	source := fakePartition([]int64{10, 11, 13, 14, 15}, 10, 16)
+	assert.Equal(t, [][]int64{{10, 11}, {13, 14}, {15}}, collectEventPages(t, source, 2))
+}
+
+func TestPaginateEventLog_Newest(t *testing.T) {
+	source := fakePartition([]int64{10, 11, 13, 14, 15}, 10, 16)
+	source.Newest = true
+	assert.Equal(t, [][]int64{{15, 14}, {13}, {11, 10}}, collectEventPages(t, source, 2),
+		"the compacted offset 12 leaves a short page")
+}
+
+func TestPaginateEventLog_StableWhileAppending(t *testing.T) {
+	source := fakePartition([]int64{0, 1, 2, 3}, 0, 4)
+	source.Newest = true
+	_, meta, err := PaginateEventLog(context.Background(), source, "", 2)
+	assert.NoError(t, err)
+
+	appended := fakePartition([]int64{0, 1, 2, 3, 4, 5}, 0, 6)
+	appended.Newest = true
+	records, _, err := PaginateEventLog(context.Background(), appended, meta.NextCursor, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 0}, eventOffsets(records))
+}
+
+func TestPaginateEventLog_Errors(t *testing.T) {
+	source := fakePartition([]int64{0, 1, 2}, 0, 3)
+	_, meta, err := PaginateEventLog(context.Background(), source, "", 1)
+	assert.NoError(t, err)
+
+	other := fakePartition([]int64{0, 1, 2}, 0, 3)
+	other.Partition = 4
+	_, _, err = PaginateEventLog(context.Background(), other, meta.NextCursor, 1)
+	assert.ErrorIs(t, err, ErrTokenScope)
+
+	source.Watermarks = func(context.Context) (int64, int64, error) { return 0, 0, errors.New("broker down") }
+	_, _, err = PaginateEventLog(context.Background(), source, "", 1)
+	assert.ErrorContains(t, err, "failed to read watermarks of orders/3: broker down")
+}
@@ -38,7 +38,7 @@ func (f *EventFilter) ApplyFilters(query *gorm.DB) *gorm.DB {
 		query = query.Where("name LIKE ?", "%"+f.Name+"%")
 	}
 	if f.Year > 0 {
-		query = query.Where("YEAR(start_date) = ?", f.Year)
+		query = query.Scopes(pagination.InYear("start_date", f.Year))
 	}
 	if f.SportID > 0 {
 		query = query.Where("sport_id = ?", f.SportID)
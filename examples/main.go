@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/Caknoooo/go-pagination"
+	"github.com/Caknoooo/go-pagination/ginadapter"
 	"github.com/gin-gonic/gin"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -29,16 +30,18 @@ func main() {
 
 	r.GET("/provinces", func(c *gin.Context) {
 		filter := &ProvinceFilter{}
-		response := pagination.PaginatedAPIResponseWithCustomFilter[Province](
-			db, c, filter, "Provinces retrieved successfully",
+		response := ginadapter.PaginateResponse[Province](
+			db, c, "Provinces retrieved successfully", pagination.WithCustomFilter(filter),
 		)
 		c.JSON(response.Code, response)
 	})
 
 	r.GET("/provinces/with-athletes", func(c *gin.Context) {
 		filter := &ProvinceFilter{}
-		filter.BindPagination(c)
-		c.ShouldBindQuery(filter)
+		if err := ginadapter.BindFilter(c, filter); err != nil {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
 
 		provinces, total, err := pagination.PaginatedQueryWithIncludable[Province](db, filter)
 
@@ -55,16 +58,18 @@ func main() {
 
 	r.GET("/sports", func(c *gin.Context) {
 		filter := &SportFilter{}
-		response := pagination.PaginatedAPIResponseWithCustomFilter[Sport](
-			db, c, filter, "Sports retrieved successfully",
+		response := ginadapter.PaginateResponse[Sport](
+			db, c, "Sports retrieved successfully", pagination.WithCustomFilter(filter),
 		)
 		c.JSON(response.Code, response)
 	})
 
 	r.GET("/sports/with-relations", func(c *gin.Context) {
 		filter := &SportFilter{}
-		filter.BindPagination(c)
-		c.ShouldBindQuery(filter)
+		if err := ginadapter.BindFilter(c, filter); err != nil {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
 
 		sports, total, err := pagination.PaginatedQueryWithIncludable[Sport](db, filter)
 
@@ -81,16 +86,18 @@ func main() {
 
 	r.GET("/events", func(c *gin.Context) {
 		filter := &EventFilter{}
-		response := pagination.PaginatedAPIResponseWithCustomFilter[Event](
-			db, c, filter, "Events retrieved successfully",
+		response := ginadapter.PaginateResponse[Event](
+			db, c, "Events retrieved successfully", pagination.WithCustomFilter(filter),
 		)
 		c.JSON(response.Code, response)
 	})
 
 	r.GET("/events/with-sport", func(c *gin.Context) {
 		filter := &EventFilter{}
-		filter.BindPagination(c)
-		c.ShouldBindQuery(filter)
+		if err := ginadapter.BindFilter(c, filter); err != nil {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
 
 		events, total, err := pagination.PaginatedQueryWithIncludable[Event](db, filter)
 
@@ -107,16 +114,18 @@ func main() {
 
 	r.GET("/athletes", func(c *gin.Context) {
 		filter := &AthleteFilter{}
-		response := pagination.PaginatedAPIResponseWithCustomFilter[Athlete](
-			db, c, filter, "Athletes retrieved successfully",
+		response := ginadapter.PaginateResponse[Athlete](
+			db, c, "Athletes retrieved successfully", pagination.WithCustomFilter(filter),
 		)
 		c.JSON(response.Code, response)
 	})
 
 	r.GET("/athletes/with-includes", func(c *gin.Context) {
 		filter := &AthleteFilter{}
-		filter.BindPagination(c)
-		c.ShouldBindQuery(filter)
+		if err := ginadapter.BindFilter(c, filter); err != nil {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
 
 		athletes, total, err := pagination.PaginatedQueryWithIncludable[Athlete](db, filter)
 
@@ -133,8 +142,10 @@ func main() {
 
 	r.GET("/athletes/detailed", func(c *gin.Context) {
 		filter := &AthleteFilter{}
-		filter.BindPagination(c)
-		c.ShouldBindQuery(filter)
+		if err := ginadapter.BindFilter(c, filter); err != nil {
+			c.JSON(400, gin.H{"error": err.Error()})
+			return
+		}
 
 		filter.Includes = []string{"Province", "Sport", "Event"}
 
@@ -164,8 +175,8 @@ func main() {
 			ProvinceID: provinceID,
 		}
 
-		response := pagination.PaginatedAPIResponseWithCustomFilter[Athlete](
-			db, c, filter, "Athletes from province retrieved successfully",
+		response := ginadapter.PaginateResponse[Athlete](
+			db, c, "Athletes from province retrieved successfully", pagination.WithCustomFilter(filter),
 		)
 		c.JSON(response.Code, response)
 	})
@@ -181,8 +192,8 @@ func main() {
 			SportID: sportID,
 		}
 
-		response := pagination.PaginatedAPIResponseWithCustomFilter[Athlete](
-			db, c, filter, "Athletes from sport retrieved successfully",
+		response := ginadapter.PaginateResponse[Athlete](
+			db, c, "Athletes from sport retrieved successfully", pagination.WithCustomFilter(filter),
 		)
 		c.JSON(response.Code, response)
 	})
@@ -198,8 +209,8 @@ func main() {
 			EventID: eventID,
 		}
 
-		response := pagination.PaginatedAPIResponseWithCustomFilter[Athlete](
-			db, c, filter, "Athletes from event retrieved successfully",
+		response := ginadapter.PaginateResponse[Athlete](
+			db, c, "Athletes from event retrieved successfully", pagination.WithCustomFilter(filter),
 		)
 		c.JSON(response.Code, response)
 	})
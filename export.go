@@ -0,0 +1,254 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"gorm.io/gorm"
+)
+
+// KeyRange is an inclusive range of integer primary key values
+type KeyRange struct {
+	Start int64 `json:"start"`
+	End   int64 `json:"end"`
+}
+
+// PartitionedExportOptions configures a parallel, partitioned full-table iteration
+type PartitionedExportOptions struct {
+	TableName   string
+	KeyColumn   string
+	Partitions  int
+	Concurrency int
+	BatchSize   int
+	FilterFunc  func(*gorm.DB) *gorm.DB
+
+	// Ranges overrides the min/max based split, e.g. with the output of PartitionsWithOptions
+	Ranges []KeyRange
+
+	// Checkpoints maps a partition index to the last key already processed,
+	// allowing an interrupted export to resume where each worker stopped
+	Checkpoints map[int]int64
+
+	// OnCheckpoint is called after every processed batch with the last key of that batch
+	OnCheckpoint func(partition int, lastKey int64)
+}
+
+func (o *PartitionedExportOptions) validate() {
+	if o.KeyColumn == "" {
+		o.KeyColumn = "id"
+	}
+	if len(o.Ranges) > 0 {
+		o.Partitions = len(o.Ranges)
+	}
+	if o.Partitions <= 0 {
+		o.Partitions = 4
+	}
+	if o.Concurrency <= 0 || o.Concurrency > o.Partitions {
+		o.Concurrency = o.Partitions
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 1000
+	}
+}
+
+// ExportPartitioned splits the table into key ranges and iterates them with a bounded worker pool
+// The handler is called concurrently from different workers and must be safe for concurrent use.
+// The first error returned by a worker or the handler cancels the remaining work.
+func ExportPartitioned[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	options PartitionedExportOptions,
+	handler func(partition int, batch []T) error,
+) error {
+	options.validate()
+
+	ranges := options.Ranges
+	if len(ranges) == 0 {
+		var err error
+		ranges, err = partitionKeyRanges(db.WithContext(ctx), options.TableName, options.KeyColumn, options.Partitions, options.FilterFunc)
+		if err != nil {
+			return err
+		}
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+
+	fail := func(err error) {
+		once.Do(func() {
+			firstErr = err
+			cancel()
+		})
+	}
+
+	jobs := make(chan int)
+	for w := 0; w < options.Concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for partition := range jobs {
+				if err := exportKeyRange(ctx, db, options, partition, ranges[partition], handler); err != nil {
+					fail(err)
+				}
+			}
+		}()
+	}
+
+	for partition := range ranges {
+		select {
+		case jobs <- partition:
+		case <-ctx.Done():
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// exportKeyRange iterates one key range in keyset batches
+func exportKeyRange[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	options PartitionedExportOptions,
+	partition int,
+	keyRange KeyRange,
+	handler func(partition int, batch []T) error,
+) error {
+	lastKey, resumed := options.Checkpoints[partition]
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		query := db.WithContext(ctx).Table(options.TableName)
+		if options.FilterFunc != nil {
+			query = options.FilterFunc(query)
+		}
+
+		query = query.Where(options.KeyColumn+" >= ? AND "+options.KeyColumn+" <= ?", keyRange.Start, keyRange.End)
+		if resumed {
+			query = query.Where(options.KeyColumn+" > ?", lastKey)
+		}
+
+		var batch []T
+		if err := query.Order(options.KeyColumn + " asc").Limit(options.BatchSize).Find(&batch).Error; err != nil {
+			return fmt.Errorf("failed to fetch partition %d: %w", partition, err)
+		}
+
+		if len(batch) == 0 {
+			return nil
+		}
+
+		if err := handler(partition, batch); err != nil {
+			return err
+		}
+
+		key, err := columnValue(db, &batch[len(batch)-1], options.KeyColumn)
+		if err != nil {
+			return err
+		}
+		lastKey, err = toInt64(key)
+		if err != nil {
+			return fmt.Errorf("column %s: %w", options.KeyColumn, err)
+		}
+		resumed = true
+
+		if options.OnCheckpoint != nil {
+			options.OnCheckpoint(partition, lastKey)
+		}
+
+		if len(batch) < options.BatchSize {
+			return nil
+		}
+	}
+}
+
+// partitionKeyRanges splits [min, max] of the key column into n contiguous ranges
+func partitionKeyRanges(db *gorm.DB, tableName, keyColumn string, n int, filterFunc func(*gorm.DB) *gorm.DB) ([]KeyRange, error) {
+	var bounds struct {
+		MinKey *int64
+		MaxKey *int64
+	}
+
+	query := db.Table(tableName)
+	if filterFunc != nil {
+		query = filterFunc(query)
+	}
+
+	if err := query.Select("MIN(" + keyColumn + ") AS min_key, MAX(" + keyColumn + ") AS max_key").Scan(&bounds).Error; err != nil {
+		return nil, fmt.Errorf("failed to read key bounds: %w", err)
+	}
+
+	if bounds.MinKey == nil || bounds.MaxKey == nil {
+		return []KeyRange{}, nil
+	}
+
+	return splitKeyRange(*bounds.MinKey, *bounds.MaxKey, n), nil
+}
+
+// splitKeyRange divides an inclusive key range into at most n contiguous, non-overlapping ranges
+func splitKeyRange(min, max int64, n int) []KeyRange {
+	if n <= 0 {
+		n = 1
+	}
+	span := max - min + 1
+	if span < int64(n) {
+		n = int(span)
+	}
+
+	ranges := make([]KeyRange, 0, n)
+	step := span / int64(n)
+	remainder := span % int64(n)
+	start := min
+
+	for i := 0; i < n; i++ {
+		size := step
+		if int64(i) < remainder {
+			size++
+		}
+		ranges = append(ranges, KeyRange{Start: start, End: start + size - 1})
+		start += size
+	}
+
+	return ranges
+}
+
+// toInt64 converts integer-like key values into int64
+func toInt64(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint:
+		return int64(v), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	default:
+		return 0, fmt.Errorf("unsupported key type %T, integer keys are required", value)
+	}
+}
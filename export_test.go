@@ -0,0 +1,83 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// setupExportDB pins the in-memory database to one connection so concurrent workers share it
+func setupExportDB(recordCount int) *gorm.DB {
+	db := setupBenchmarkDB(recordCount)
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+	return db
+}
+
+func TestSplitKeyRange(t *testing.T) {
+	ranges := splitKeyRange(1, 10, 3)
+
+	assert.Equal(t, []KeyRange{{1, 4}, {5, 7}, {8, 10}}, ranges)
+	assert.Len(t, splitKeyRange(1, 2, 5), 2)
+}
+
+func TestExportPartitioned(t *testing.T) {
+	db := setupExportDB(250)
+
+	var mu sync.Mutex
+	seen := make(map[uint]bool)
+	checkpoints := make(map[int]int64)
+
+	err := ExportPartitioned[TestUser](context.Background(), db, PartitionedExportOptions{
+		TableName:   "test_users",
+		Partitions:  4,
+		Concurrency: 2,
+		BatchSize:   30,
+		OnCheckpoint: func(partition int, lastKey int64) {
+			mu.Lock()
+			checkpoints[partition] = lastKey
+			mu.Unlock()
+		},
+	}, func(partition int, batch []TestUser) error {
+		mu.Lock()
+		defer mu.Unlock()
+		for _, user := range batch {
+			assert.False(t, seen[user.ID], "row exported twice")
+			seen[user.ID] = true
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, seen, 250)
+	assert.Len(t, checkpoints, 4)
+	assert.Equal(t, int64(250), checkpoints[3])
+}
+
+func TestExportPartitioned_ResumeAndError(t *testing.T) {
+	db := setupExportDB(100)
+
+	count := 0
+	err := ExportPartitioned[TestUser](context.Background(), db, PartitionedExportOptions{
+		TableName:   "test_users",
+		Partitions:  1,
+		Checkpoints: map[int]int64{0: 90},
+	}, func(partition int, batch []TestUser) error {
+		count += len(batch)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 10, count)
+
+	boom := errors.New("boom")
+	err = ExportPartitioned[TestUser](context.Background(), db, PartitionedExportOptions{
+		TableName: "test_users",
+	}, func(partition int, batch []TestUser) error {
+		return boom
+	})
+	assert.ErrorIs(t, err, boom)
+}
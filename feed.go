@@ -0,0 +1,106 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// FeedItem is one entry of a heterogeneous page, Type telling clients how to read Data
+type FeedItem struct {
+	Type string      `json:"type"`
+	Data interface{} `json:"data"`
+	// At orders the feed, newest first; items of the same time keep source order
+	At time.Time `json:"-"`
+}
+
+// NewFeedSource returns the source of one entity type of a mixed feed. fetch pages the
+// items newest first like a MergeSource, at returns the time ordering an item.
+func NewFeedSource[T any](
+	kind string,
+	fetch func(ctx context.Context, cursor string, limit int) ([]T, string, error),
+	at func(T) time.Time,
+) MergeSource[FeedItem] {
+	return MergeSource[FeedItem]{
+		Name: kind,
+		Fetch: func(ctx context.Context, cursor string, limit int) ([]FeedItem, string, error) {
+			items, next, err := fetch(ctx, cursor, limit)
+			if err != nil {
+				return nil, "", err
+			}
+			feed := make([]FeedItem, len(items))
+			for i, item := range items {
+				feed[i] = FeedItem{Type: kind, Data: item, At: at(item)}
+			}
+			return feed, next, nil
+		},
+	}
+}
+
+// TableFeedSource returns the source of a model paged newest first by timeColumn and
+// the primary key. filter narrows the rows and may be nil.
+func TableFeedSource[T any](kind string, db *gorm.DB, timeColumn string, filter func(*gorm.DB) *gorm.DB) MergeSource[FeedItem] {
+	return MergeSource[FeedItem]{
+		Name: kind,
+		Fetch: func(ctx context.Context, cursor string, limit int) ([]FeedItem, string, error) {
+			idColumn, err := primaryKeyColumn[T](db)
+			if err != nil {
+				return nil, "", err
+			}
+			query := db.WithContext(ctx).Model(new(T))
+			if filter != nil {
+				query = filter(query)
+			}
+			paginator := CursorPaginator{
+				Columns: []KeysetColumn{{Name: timeColumn, Desc: true}, {Name: idColumn, Desc: true}},
+				Scope:   CursorScope(kind, timeColumn, totalCacheKey(query)),
+			}
+			query, _, err = paginator.Page(query, cursor, limit)
+			if err != nil {
+				return nil, "", err
+			}
+
+			var rows []T
+			if err := query.Find(&rows).Error; err != nil {
+				return nil, "", fmt.Errorf("failed to fetch %s records: %w", kind, err)
+			}
+			more := len(rows) > limit
+			if more {
+				rows = rows[:limit]
+			}
+
+			feed := make([]FeedItem, len(rows))
+			for i := range rows {
+				value, err := columnValue(db, &rows[i], timeColumn)
+				if err != nil {
+					return nil, "", err
+				}
+				at, ok := value.(time.Time)
+				if !ok {
+					return nil, "", fmt.Errorf("column %s of %s is not a time", timeColumn, kind)
+				}
+				feed[i] = FeedItem{Type: kind, Data: rows[i], At: at}
+			}
+
+			next := ""
+			if more {
+				if next, err = paginator.Encode(db, &rows[len(rows)-1], false); err != nil {
+					return nil, "", err
+				}
+			}
+			return feed, next, nil
+		},
+	}
+}
+
+// FeedPaginate returns one page of a feed mixing the items of several sources, newest
+// first. Every item carries the type of its source; NextCursor resumes all of them.
+func FeedPaginate(ctx context.Context, sources []MergeSource[FeedItem], cursor string, limit int) (MergeResult[FeedItem], error) {
+	return MergePaginate(ctx, sources, feedLess, cursor, limit)
+}
+
+func feedLess(a, b FeedItem) bool {
+	return a.At.After(b.At)
+}
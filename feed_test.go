@@ -0,0 +1,91 @@
+package pagination
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func setupFeedDB() *gorm.DB {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	db.AutoMigrate(&TestChange{}, &TestSignup{})
+
+	at := func(hour int) time.Time { return time.Date(2024, 1, 1, hour, 0, 0, 0, time.UTC) }
+	db.Create(&[]TestChange{
+		{Name: "a", UpdatedAt: at(1)},
+		{Name: "b", UpdatedAt: at(4)},
+		{Name: "c", UpdatedAt: at(4)},
+		{Name: "d", UpdatedAt: at(7)},
+	})
+	db.Create(&[]TestSignup{
+		{Plan: "free", CreatedAt: at(2)},
+		{Plan: "pro", CreatedAt: at(5)},
+		{Plan: "free", CreatedAt: at(6)},
+	})
+	return db
+}
+
+func TestFeedPaginate(t *testing.T) {
+	db := setupFeedDB()
+	sources := []MergeSource[FeedItem]{
+		TableFeedSource[TestChange]("change", db, "updated_at", nil),
+		TableFeedSource[TestSignup]("signup", db, "created_at", nil),
+	}
+
+	var kinds []string
+	var hours []int
+	cursor := ""
+	for {
+		page, err := FeedPaginate(context.Background(), sources, cursor, 3)
+		assert.NoError(t, err)
+		for _, item := range page.Data {
+			kinds = append(kinds, item.Type)
+			hours = append(hours, item.At.Hour())
+		}
+		if !page.HasMore {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	assert.Equal(t, []string{"change", "signup", "signup", "change", "change", "signup", "change"}, kinds)
+	assert.Equal(t, []int{7, 6, 5, 4, 4, 2, 1}, hours)
+}
+
+func TestFeedPaginate_Filter(t *testing.T) {
+	db := setupFeedDB()
+	pro := func(query *gorm.DB) *gorm.DB { return query.Where("plan = ?", "pro") }
+	sources := []MergeSource[FeedItem]{TableFeedSource[TestSignup]("signup", db, "created_at", pro)}
+
+	page, err := FeedPaginate(context.Background(), sources, "", 10)
+	assert.NoError(t, err)
+	assert.Len(t, page.Data, 1)
+	assert.Equal(t, "pro", page.Data[0].Data.(TestSignup).Plan)
+}
+
+func TestNewFeedSource(t *testing.T) {
+	type note struct {
+		Text string    `json:"text"`
+		At   time.Time `json:"-"`
+	}
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notes := NewFeedSource("note",
+		func(ctx context.Context, cursor string, limit int) ([]note, string, error) {
+			return []note{{Text: "later", At: base.Add(time.Hour)}, {Text: "earlier", At: base}}, "", nil
+		},
+		func(n note) time.Time { return n.At },
+	)
+
+	page, err := FeedPaginate(context.Background(), []MergeSource[FeedItem]{notes}, "", 5)
+	assert.NoError(t, err)
+	assert.False(t, page.HasMore)
+
+	encoded, err := json.Marshal(page.Data[0])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"type":"note","data":{"text":"later"}}`, string(encoded))
+}
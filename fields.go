@@ -0,0 +1,145 @@
+package pagination
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Field is a column of a model holding values of type V, so filters and sorts on it
+// are checked by the compiler instead of failing as SQL. Declare the fields of a model
+// once and use them everywhere:
+//
+//	var AthleteFields = struct {
+//		Age  pagination.Field[int]
+//		Name pagination.StringField
+//	}{pagination.NewField[int]("age"), pagination.NewStringField("name")}
+//
+//	builder.WithFilters(pagination.Where(AthleteFields.Age.Gte(18)))
+type Field[V any] struct {
+	column string
+}
+
+// NewField declares a column, optionally qualified with its table as in "athletes.age"
+func NewField[V any](column string) Field[V] {
+	return Field[V]{column: column}
+}
+
+// Column returns the column name of the field
+func (f Field[V]) Column() string {
+	return f.column
+}
+
+// compare renders "column operator ?" with value
+func (f Field[V]) compare(operator string, value interface{}) Scope {
+	return f.condition(f.column+" "+operator+" ?", value)
+}
+
+// condition renders sql on the column, failing for invalid column names
+func (f Field[V]) condition(sql string, vars ...interface{}) Scope {
+	if !isValidSortField(f.column) {
+		return Scope{err: fmt.Errorf("invalid field name: %s", f.column)}
+	}
+	return Scope{sql: sql, vars: vars, simple: true}
+}
+
+// Eq matches rows whose field equals value
+func (f Field[V]) Eq(value V) Scope { return f.compare("=", value) }
+
+// Ne matches rows whose field differs from value
+func (f Field[V]) Ne(value V) Scope { return f.compare("<>", value) }
+
+// Gt matches rows whose field is greater than value
+func (f Field[V]) Gt(value V) Scope { return f.compare(">", value) }
+
+// Gte matches rows whose field is greater than or equal to value
+func (f Field[V]) Gte(value V) Scope { return f.compare(">=", value) }
+
+// Lt matches rows whose field is less than value
+func (f Field[V]) Lt(value V) Scope { return f.compare("<", value) }
+
+// Lte matches rows whose field is less than or equal to value
+func (f Field[V]) Lte(value V) Scope { return f.compare("<=", value) }
+
+// Between matches rows whose field lies in [low, high]
+func (f Field[V]) Between(low, high V) Scope {
+	return f.condition(f.column+" BETWEEN ? AND ?", low, high)
+}
+
+// In matches rows whose field is one of values; no values match no rows
+func (f Field[V]) In(values ...V) Scope {
+	if len(values) == 0 {
+		return f.condition("1 = 0")
+	}
+	return f.compare("IN", values)
+}
+
+// IsNull matches rows whose field is NULL
+func (f Field[V]) IsNull() Scope { return f.condition(f.column + " IS NULL") }
+
+// IsNotNull matches rows whose field is not NULL
+func (f Field[V]) IsNotNull() Scope { return f.condition(f.column + " IS NOT NULL") }
+
+// Asc sorts by the field in ascending order
+func (f Field[V]) Asc() Ordering { return Ordering{column: f.column, direction: "asc"} }
+
+// Desc sorts by the field in descending order
+func (f Field[V]) Desc() Ordering { return Ordering{column: f.column, direction: "desc"} }
+
+// StringField is a text column, adding pattern matches to Field
+type StringField struct {
+	Field[string]
+}
+
+// NewStringField declares a text column
+func NewStringField(column string) StringField {
+	return StringField{Field: NewField[string](column)}
+}
+
+// Contains matches rows whose field contains text, wildcards in text match literally
+func (f StringField) Contains(text string) Scope {
+	return f.like("%" + escapeLikePattern(text) + "%")
+}
+
+// HasPrefix matches rows whose field starts with text
+func (f StringField) HasPrefix(text string) Scope {
+	return f.like(escapeLikePattern(text) + "%")
+}
+
+// HasSuffix matches rows whose field ends with text
+func (f StringField) HasSuffix(text string) Scope {
+	return f.like("%" + escapeLikePattern(text))
+}
+
+func (f StringField) like(pattern string) Scope {
+	return f.condition(f.column+" LIKE ? ESCAPE '!'", pattern)
+}
+
+// escapeLikePattern escapes the LIKE wildcards of text with "!", which unlike a backslash
+// needs no escaping inside string literals of any dialect
+func escapeLikePattern(text string) string {
+	return strings.NewReplacer("!", "!!", "%", "!%", "_", "!_").Replace(text)
+}
+
+// Ordering sorts by a typed field, see Field.Asc and Field.Desc
+type Ordering struct {
+	column    string
+	direction string
+}
+
+// String renders the ordering as in "age desc"
+func (o Ordering) String() string {
+	return o.column + " " + o.direction
+}
+
+// OrderBy renders orderings as an ORDER BY list for WithDefaultSort or WithSortPreset,
+// e.g. OrderBy(AthleteFields.Age.Desc(), AthleteFields.Name.Asc()); orderings on
+// invalid field names are left out
+func OrderBy(orderings ...Ordering) string {
+	rendered := make([]string, 0, len(orderings))
+	for _, ordering := range orderings {
+		if isValidSortField(ordering.column) {
+			rendered = append(rendered, ordering.String())
+		}
+	}
+	return strings.Join(rendered, ", ")
+}
@@ -0,0 +1,55 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testUserFields = struct {
+	ID   Field[uint]
+	Name StringField
+	Age  Field[int]
+}{NewField[uint]("id"), NewStringField("name"), NewField[int]("age")}
+
+func TestTypedFields_FilterAndSort(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users").
+		WithFilters(Where(testUserFields.Age.Gte(28), testUserFields.Name.Contains("o"))).
+		WithDefaultSort(OrderBy(testUserFields.Age.Desc(), testUserFields.Name.Asc()))
+
+	users, total, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 10}, nil, PaginatedQueryOptions{Dialect: SQLite})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.Equal(t, []string{"Bob Johnson", "Charlie Wilson", "Alice Brown"}, userNames(users))
+}
+
+func TestTypedFields_Conditions(t *testing.T) {
+	db := setupTestDB()
+	names := func(scopes ...Scope) []string {
+		var users []TestUser
+		assert.NoError(t, db.Table("test_users").Scopes(Where(scopes...)).Order("id").Find(&users).Error)
+		return userNames(users)
+	}
+
+	assert.Equal(t, []string{"Jane Smith", "Alice Brown"}, names(testUserFields.ID.In(2, 4)))
+	assert.Empty(t, names(testUserFields.ID.In()))
+	assert.Equal(t, []string{"Jane Smith", "Alice Brown", "Charlie Wilson"}, names(testUserFields.Age.Between(28, 32)))
+	assert.Equal(t, []string{"John Doe"}, names(testUserFields.Age.Lt(28), testUserFields.Name.HasPrefix("J"), testUserFields.Name.Ne("Jane Smith")))
+	assert.Equal(t, []string{"Bob Johnson"}, names(testUserFields.Name.HasSuffix("son"), testUserFields.Age.Gt(32)))
+	assert.Empty(t, names(testUserFields.Name.IsNull()))
+	assert.Len(t, names(testUserFields.Name.IsNotNull()), 5)
+
+	db.Create(&TestUser{Name: "100%_sure", Age: 50})
+	assert.Equal(t, []string{"100%_sure"}, names(testUserFields.Name.Contains("%_")), "wildcards match literally")
+}
+
+func TestTypedFields_InvalidColumn(t *testing.T) {
+	db := setupTestDB()
+	invalid := NewField[int]("age; DROP TABLE test_users")
+
+	var users []TestUser
+	err := db.Table("test_users").Scopes(Where(invalid.Eq(1))).Find(&users).Error
+	assert.ErrorContains(t, err, "invalid field name")
+	assert.Equal(t, "age desc", OrderBy(invalid.Asc(), testUserFields.Age.Desc()))
+}
@@ -0,0 +1,156 @@
+package pagination
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// FilterFieldsProvider is implemented by filters accepting operator filters in the query
+// string, e.g. ?age=gte:18. It maps each query parameter to the column it filters, and
+// parameters it does not name are never turned into conditions.
+type FilterFieldsProvider interface {
+	GetFilterFields() map[string]string
+}
+
+// FilterExpression compares a column with the values of one query parameter
+type FilterExpression struct {
+	Param    string
+	Column   string
+	Operator string
+	Values   []string
+}
+
+// FilterSet is the list of operator filters of a query string, see ParseFilterSet
+type FilterSet []FilterExpression
+
+// filterSetArity is the number of values of each operator; -1 accepts any but none
+var filterSetArity = map[string]int{
+	"eq":      1,
+	"ne":      1,
+	"gt":      1,
+	"gte":     1,
+	"lt":      1,
+	"lte":     1,
+	"like":    1,
+	"prefix":  1,
+	"null":    1,
+	"in":      -1,
+	"not_in":  -1,
+	"between": 2,
+}
+
+// ParseFilterSet parses the parameters of query named by fields, which maps them to their
+// columns, as operator filters written operator:value. The operators are eq, ne, gt, gte,
+// lt, lte, like, prefix, null (true or false), in and not_in (comma-separated values) and
+// between (two comma-separated bounds):
+//
+//	?age=gte:18&name=like:budi&created_at=between:2024-01-01,2024-12-31
+//
+// Values without a known operator are compared with eq, so ?status=active still works,
+// and the array syntax ?status[]=a&status[]=b compares with in.
+// Malformed values are reported as a *CoercionError.
+func ParseFilterSet(query url.Values, fields map[string]string) (FilterSet, error) {
+	var set FilterSet
+	var params []ParamError
+	for _, param := range sortedKeys(fields) {
+		for _, raw := range query[param] {
+			if raw == "" {
+				continue
+			}
+			expression, err := parseFilterExpression(param, fields[param], raw)
+			if err != nil {
+				params = append(params, *err)
+				continue
+			}
+			set = append(set, expression)
+		}
+		// The array syntax param[]=a&param[]=b selects any of the values
+		if values := splitMultiValue(query[param+"[]"]); len(values) > 0 {
+			set = append(set, FilterExpression{Param: param, Column: fields[param], Operator: "in", Values: values})
+		}
+	}
+	if len(params) > 0 {
+		return nil, &CoercionError{Params: params}
+	}
+	return set, nil
+}
+
+// parseFilterExpression parses one operator:value parameter
+func parseFilterExpression(param, column, raw string) (FilterExpression, *ParamError) {
+	expression := FilterExpression{Param: param, Column: column, Operator: "eq", Values: []string{raw}}
+	operator, value, found := strings.Cut(raw, ":")
+	arity, known := filterSetArity[strings.ToLower(operator)]
+	if !found || !known {
+		return expression, nil
+	}
+	expression.Operator = strings.ToLower(operator)
+
+	invalid := func(message string) *ParamError {
+		return &ParamError{Param: param, Value: raw, Type: expression.Operator, Message: fmt.Sprintf("%s %s", param, message)}
+	}
+	expression.Values = []string{value}
+	if arity != 1 {
+		expression.Values = strings.Split(value, ",")
+		for i := range expression.Values {
+			expression.Values[i] = strings.TrimSpace(expression.Values[i])
+		}
+	}
+	if value == "" {
+		return expression, invalid("needs a value after " + expression.Operator + ":")
+	}
+	if arity > 1 && len(expression.Values) != arity {
+		return expression, invalid(fmt.Sprintf("needs %d comma-separated values for %s", arity, expression.Operator))
+	}
+	if expression.Operator == "null" {
+		if _, ok := parseFilterBool(value); !ok {
+			return expression, invalid("must be true or false for null")
+		}
+	}
+	return expression, nil
+}
+
+// Apply adds a bound WHERE clause for every expression of the set
+func (s FilterSet) Apply(query *gorm.DB) *gorm.DB {
+	for _, expression := range s {
+		column, values := expression.Column, expression.Values
+		switch expression.Operator {
+		case "between":
+			query = query.Where(column+" BETWEEN ? AND ?", values[0], values[1])
+		case "null":
+			if null, _ := parseFilterBool(values[0]); null {
+				query = query.Where(column + " IS NULL")
+			} else {
+				query = query.Where(column + " IS NOT NULL")
+			}
+		case "in", "not_in":
+			query = query.Where(column+" "+tagFilterConditions[expression.Operator], values)
+		case "like":
+			query = query.Where(column+" LIKE ?", "%"+values[0]+"%")
+		case "prefix":
+			query = query.Where(column+" LIKE ?", values[0]+"%")
+		default:
+			query = query.Where(column+" "+tagFilterConditions[expression.Operator], values[0])
+		}
+	}
+	return query
+}
+
+// GetFilterSet returns the operator filters bound for a filter implementing
+// FilterFieldsProvider. BaseFilter.ApplyFilters applies them; filters defining their own
+// ApplyFilters call Apply on it.
+func (f *BaseFilter) GetFilterSet() FilterSet {
+	return f.filterSet
+}
+
+// bindFilterSet parses the operator filters of the parameters declared by fields
+func (f *BaseFilter) bindFilterSet(query url.Values, fields map[string]string) error {
+	set, err := ParseFilterSet(query, fields)
+	if err != nil {
+		return err
+	}
+	f.filterSet = set
+	return nil
+}
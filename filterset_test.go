@@ -0,0 +1,84 @@
+package pagination
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// operatorUserFilter declares operator filters instead of MinAge/MaxAge style fields
+type operatorUserFilter struct {
+	BaseFilter
+}
+
+func (f *operatorUserFilter) GetTableName() string      { return "test_users" }
+func (f *operatorUserFilter) GetDefaultSort() string    { return "id asc" }
+func (f *operatorUserFilter) GetSearchFields() []string { return []string{"name"} }
+func (f *operatorUserFilter) GetFilterFields() map[string]string {
+	return map[string]string{"age": "age", "name": "name", "email": "email"}
+}
+
+func TestParseFilterSet(t *testing.T) {
+	query, _ := url.ParseQuery("age=gte:18&age=lt:65&name=like:budi&created_at=between:2024-01-01,2024-12-31&status=active&email=a:b")
+	set, err := ParseFilterSet(query, map[string]string{"age": "age", "name": "full_name", "created_at": "created_at", "email": "email"})
+	assert.NoError(t, err)
+	assert.Equal(t, FilterSet{
+		{Param: "age", Column: "age", Operator: "gte", Values: []string{"18"}},
+		{Param: "age", Column: "age", Operator: "lt", Values: []string{"65"}},
+		{Param: "created_at", Column: "created_at", Operator: "between", Values: []string{"2024-01-01", "2024-12-31"}},
+		{Param: "email", Column: "email", Operator: "eq", Values: []string{"a:b"}},
+		{Param: "name", Column: "full_name", Operator: "like", Values: []string{"budi"}},
+	}, set, "undeclared parameters are ignored and unknown operators compare the whole value")
+
+	query, _ = url.ParseQuery("age=between:18&name=null:maybe&email=in:")
+	_, err = ParseFilterSet(query, map[string]string{"age": "age", "name": "name", "email": "email"})
+	var coercionErr *CoercionError
+	assert.ErrorAs(t, err, &coercionErr)
+	assert.Len(t, coercionErr.Params, 3)
+}
+
+func TestPaginateRequest_FilterSet(t *testing.T) {
+	db := setupTestDB()
+
+	tests := []struct {
+		query string
+		names []string
+	}{
+		{"age=gte:30", []string{"Jane Smith", "Bob Johnson", "Charlie Wilson"}},
+		{"age=between:28,32&name=ne:Jane+Smith", []string{"Alice Brown", "Charlie Wilson"}},
+		{"name=in:John+Doe,Bob+Johnson", []string{"John Doe", "Bob Johnson"}},
+		{"name=prefix:J&age=lt:30", []string{"John Doe"}},
+		{"email=null:false&name=Alice+Brown", []string{"Alice Brown"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			users, _, err := PaginateRequest[TestUser](db, httptest.NewRequest("GET", "/users?"+tt.query, nil), &operatorUserFilter{})
+			assert.NoError(t, err)
+
+			var names []string
+			for _, user := range users {
+				names = append(names, user.Name)
+			}
+			assert.Equal(t, tt.names, names)
+		})
+	}
+
+	response := PaginatedAPIResponseRequest[TestUser](db, httptest.NewRequest("GET", "/users?age=between:1", nil), &operatorUserFilter{}, "ok")
+	assert.Equal(t, 422, response.Code)
+}
+
+func TestPaginate_WithFilterFields(t *testing.T) {
+	db := setupTestDB()
+
+	users, meta, err := Paginate[TestUser](db, newTestRequest("/users?age=gt:30&secret=1"),
+		WithTable("test_users"), WithFilterFields(map[string]string{"age": "age"}))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), meta.Total)
+	assert.Len(t, users, 2)
+
+	response := PaginateResponse[TestUser](db, newTestRequest("/users?age=null:x"), "ok",
+		WithTable("test_users"), WithFilterFields(map[string]string{"age": "age"}))
+	assert.Equal(t, 422, response.Code)
+}
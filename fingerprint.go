@@ -0,0 +1,214 @@
+package pagination
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxQueryShapes bounds the number of distinct shapes tracked; new shapes beyond it are ignored
+	maxQueryShapes = 1000
+	// queryShapeSamples is the number of recent latencies kept per shape for percentiles
+	queryShapeSamples = 256
+)
+
+// QueryShape is the normalized form of a paginated request: which filters were used,
+// how it was sorted and how deep it paged, without any values
+type QueryShape struct {
+	Table     string   `json:"table"`
+	Filters   []string `json:"filters,omitempty"`
+	Sort      string   `json:"sort,omitempty"`
+	PageDepth string   `json:"page_depth"`
+}
+
+// Fingerprint returns a stable identifier of the shape
+func (s QueryShape) Fingerprint() string {
+	return s.Table + "|" + strings.Join(s.Filters, ",") + "|" + s.Sort + "|" + s.PageDepth
+}
+
+// QueryShapeStats aggregates the executions of one query shape
+type QueryShapeStats struct {
+	QueryShape
+	Fingerprint string        `json:"fingerprint"`
+	Count       int64         `json:"count"`
+	P50         time.Duration `json:"p50"`
+	P95         time.Duration `json:"p95"`
+	P99         time.Duration `json:"p99"`
+	Max         time.Duration `json:"max"`
+}
+
+type queryShapeEntry struct {
+	shape   QueryShape
+	count   int64
+	max     time.Duration
+	samples []time.Duration
+	next    int
+}
+
+// queryShapeTracker collects statistics per query shape
+type queryShapeTracker struct {
+	mu     sync.Mutex
+	shapes map[string]*queryShapeEntry
+}
+
+var queryShapes = queryShapeTracker{shapes: make(map[string]*queryShapeEntry)}
+
+func (t *queryShapeTracker) record(shape QueryShape, duration time.Duration) {
+	fingerprint := shape.Fingerprint()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	entry, ok := t.shapes[fingerprint]
+	if !ok {
+		if len(t.shapes) >= maxQueryShapes {
+			return
+		}
+		entry = &queryShapeEntry{shape: shape}
+		t.shapes[fingerprint] = entry
+	}
+
+	entry.count++
+	if duration > entry.max {
+		entry.max = duration
+	}
+	if len(entry.samples) < queryShapeSamples {
+		entry.samples = append(entry.samples, duration)
+	} else {
+		entry.samples[entry.next] = duration
+		entry.next = (entry.next + 1) % queryShapeSamples
+	}
+}
+
+func (t *queryShapeTracker) top(n int) []QueryShapeStats {
+	t.mu.Lock()
+	stats := make([]QueryShapeStats, 0, len(t.shapes))
+	for fingerprint, entry := range t.shapes {
+		samples := append([]time.Duration(nil), entry.samples...)
+		sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+		stats = append(stats, QueryShapeStats{
+			QueryShape:  entry.shape,
+			Fingerprint: fingerprint,
+			Count:       entry.count,
+			P50:         percentile(samples, 0.50),
+			P95:         percentile(samples, 0.95),
+			P99:         percentile(samples, 0.99),
+			Max:         entry.max,
+		})
+	}
+	t.mu.Unlock()
+
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		return stats[i].Fingerprint < stats[j].Fingerprint
+	})
+	if n > 0 && len(stats) > n {
+		stats = stats[:n]
+	}
+	return stats
+}
+
+// percentile returns the nearest-rank percentile of sorted samples
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := int(p*float64(len(sorted))+0.5) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
+
+// TopQueryShapes returns the n most frequent query shapes with their latency percentiles;
+// n <= 0 returns all of them
+func TopQueryShapes(n int) []QueryShapeStats {
+	return queryShapes.top(n)
+}
+
+// ResetQueryShapes discards all collected query shape statistics
+func ResetQueryShapes() {
+	queryShapes.mu.Lock()
+	defer queryShapes.mu.Unlock()
+	queryShapes.shapes = make(map[string]*queryShapeEntry)
+}
+
+// queryShapeOf normalizes a paginated request; filters are the form fields of the
+// builder that are set, plus "search" when a search term was given
+func queryShapeOf(builder QueryBuilder, pagination PaginationRequest) QueryShape {
+	shape := QueryShape{
+		Table:     builder.GetTableName(),
+		Filters:   usedFilterFields(reflect.ValueOf(builder), nil),
+		PageDepth: pageDepth(pagination),
+	}
+
+	if pagination.Search != "" {
+		shape.Filters = append(shape.Filters, "search")
+	}
+	sort.Strings(shape.Filters)
+
+	if pagination.Sort != "" {
+		shape.Sort = sortFieldsClause(pagination.SortFields())
+	}
+
+	return shape
+}
+
+// usedFilterFields collects the form names of non-zero fields, descending into embedded structs
+func usedFilterFields(value reflect.Value, fields []string) []string {
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return fields
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return fields
+	}
+
+	valueType := value.Type()
+	for i := 0; i < valueType.NumField(); i++ {
+		field := valueType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("form"), ",")
+		if name == "" || name == "-" {
+			if field.Anonymous {
+				fields = usedFilterFields(value.Field(i), fields)
+			}
+			continue
+		}
+
+		if !value.Field(i).IsZero() {
+			fields = append(fields, name)
+		}
+	}
+	return fields
+}
+
+// pageDepth buckets the requested page so deep offset pagination stands out
+func pageDepth(pagination PaginationRequest) string {
+	switch {
+	case pagination.IsDisabled:
+		return "all"
+	case pagination.Page <= 1:
+		return "1"
+	case pagination.Page <= 10:
+		return "2-10"
+	case pagination.Page <= 100:
+		return "11-100"
+	default:
+		return "100+"
+	}
+}
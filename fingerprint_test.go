@@ -0,0 +1,78 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type testUserFilter struct {
+	BaseFilter
+	Name   string `form:"name"`
+	MinAge int    `form:"min_age"`
+}
+
+func (f *testUserFilter) ApplyFilters(query *gorm.DB) *gorm.DB {
+	if f.Name != "" {
+		query = query.Where("name = ?", f.Name)
+	}
+	if f.MinAge > 0 {
+		query = query.Where("age >= ?", f.MinAge)
+	}
+	return query
+}
+
+func (f *testUserFilter) GetTableName() string      { return "test_users" }
+func (f *testUserFilter) GetDefaultSort() string    { return "id asc" }
+func (f *testUserFilter) GetSearchFields() []string { return []string{"name"} }
+
+func TestQueryShapeOf(t *testing.T) {
+	filter := &testUserFilter{MinAge: 30}
+	shape := queryShapeOf(filter, PaginationRequest{Page: 12, Search: "j", Sort: "age", Order: "desc"})
+
+	assert.Equal(t, "test_users", shape.Table)
+	assert.Equal(t, []string{"min_age", "search"}, shape.Filters)
+	assert.Equal(t, "age desc", shape.Sort)
+	assert.Equal(t, "11-100", shape.PageDepth)
+
+	// Values do not change the shape
+	other := queryShapeOf(&testUserFilter{MinAge: 18}, PaginationRequest{Page: 50, Search: "x", Sort: "age", Order: "desc"})
+	assert.Equal(t, shape.Fingerprint(), other.Fingerprint())
+}
+
+func TestTopQueryShapes(t *testing.T) {
+	ResetQueryShapes()
+	t.Cleanup(ResetQueryShapes)
+
+	db := setupTestDB()
+	for i := 0; i < 3; i++ {
+		_, _, err := PaginatedQuery[TestUser](db, &testUserFilter{MinAge: 30}, PaginationRequest{Page: 1, PerPage: 2}, nil)
+		assert.NoError(t, err)
+	}
+	_, _, err := PaginatedQuery[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 2, PerPage: 2}, nil)
+	assert.NoError(t, err)
+
+	top := TopQueryShapes(0)
+	if assert.Len(t, top, 2) {
+		assert.Equal(t, int64(3), top[0].Count)
+		assert.Equal(t, []string{"min_age"}, top[0].Filters)
+		assert.Greater(t, top[0].P50, time.Duration(0))
+		assert.GreaterOrEqual(t, top[0].Max, top[0].P99)
+		assert.Equal(t, "2-10", top[1].PageDepth)
+	}
+	assert.Len(t, TopQueryShapes(1), 1)
+}
+
+func TestPercentile(t *testing.T) {
+	samples := make([]time.Duration, 100)
+	for i := range samples {
+		samples[i] = time.Duration(i+1) * time.Millisecond
+	}
+
+	assert.Equal(t, 50*time.Millisecond, percentile(samples, 0.50))
+	assert.Equal(t, 95*time.Millisecond, percentile(samples, 0.95))
+	assert.Equal(t, 99*time.Millisecond, percentile(samples, 0.99))
+	assert.Equal(t, time.Duration(0), percentile(nil, 0.5))
+}
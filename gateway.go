@@ -0,0 +1,92 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Gateway fans a request out to several paginated backends and merges the results
+// into one sorted, deduplicated page with a unified cursor.
+type Gateway[T any] struct {
+	Sources []MergeSource[T]
+	Less    func(a, b T) bool
+	Key     func(T) string
+	// AllowPartial, SourceTimeout and Scope as in MergeOptions
+	AllowPartial  bool
+	SourceTimeout time.Duration
+	Scope         string
+}
+
+// NewGateway creates a Gateway; key identifies duplicates across sources and may be nil
+func NewGateway[T any](less func(a, b T) bool, key func(T) string, sources ...MergeSource[T]) *Gateway[T] {
+	return &Gateway[T]{
+		Sources: sources,
+		Less:    less,
+		Key:     key,
+	}
+}
+
+// AddSource registers another backend source
+func (g *Gateway[T]) AddSource(source MergeSource[T]) *Gateway[T] {
+	g.Sources = append(g.Sources, source)
+	return g
+}
+
+// Paginate returns the merged page following cursor
+func (g *Gateway[T]) Paginate(ctx context.Context, cursor string, limit int) (MergeResult[T], error) {
+	return MergePaginateWithOptions(ctx, g.Sources, g.Less, cursor, limit, MergeOptions[T]{
+		Key:           g.Key,
+		AllowPartial:  g.AllowPartial,
+		SourceTimeout: g.SourceTimeout,
+		Scope:         g.Scope,
+	})
+}
+
+// RemoteSource exposes an upstream endpoint that returns this package's envelope as a MergeSource
+// The source cursor is only the query of the upstream page: every request is rebuilt on
+// startURL, and a next page on another origin or path fails the fetch, so a forged cursor
+// cannot redirect the client or its headers. per_page is set from the requested limit
+// on the first request only, later pages keep the upstream query unchanged.
+func RemoteSource[T any](client *Client, name, startURL string) MergeSource[T] {
+	return MergeSource[T]{
+		Name: name,
+		Fetch: func(ctx context.Context, cursor string, limit int) ([]T, string, error) {
+			start, err := url.Parse(startURL)
+			if err != nil {
+				return nil, "", err
+			}
+
+			query := start.Query()
+			if cursor == "" {
+				if query.Get("per_page") == "" {
+					query.Set("per_page", strconv.Itoa(limit))
+				}
+			} else if query, err = url.ParseQuery(cursor); err != nil {
+				return nil, "", fmt.Errorf("%w: %v", ErrInvalidToken, err)
+			}
+			pageURL := *start
+			pageURL.RawQuery = query.Encode()
+
+			page, err := FetchPage[T](ctx, client, pageURL.String())
+			if err != nil {
+				return nil, "", err
+			}
+			if page.NextURL == "" {
+				return page.Data, "", nil
+			}
+
+			// FetchPage already keeps the origin, the path must stay that of startURL
+			next, err := url.Parse(page.NextURL)
+			if err != nil {
+				return nil, "", err
+			}
+			if next.EscapedPath() != start.EscapedPath() {
+				return nil, "", fmt.Errorf("next page %s leaves %s", next.Redacted(), start.Redacted())
+			}
+			return page.Data, next.RawQuery, nil
+		},
+	}
+}
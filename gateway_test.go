@@ -0,0 +1,96 @@
+package pagination
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func newUserBackend(db *gorm.DB) *httptest.Server {
+	router := http.NewServeMux()
+	router.HandleFunc("GET /users", func(w http.ResponseWriter, r *http.Request) {
+		data, meta, err := Paginate[TestUser](db, r, WithTable("test_users"), WithFilter(func(query *gorm.DB) *gorm.DB {
+			return query.Order("age asc")
+		}))
+		if err != nil {
+			writeJSON(w, 500, NewPaginatedResponse(500, err.Error(), nil, PaginationResponse{}))
+			return
+		}
+		writeJSON(w, 200, NewPaginatedResponse(200, "ok", data, meta))
+	})
+	return httptest.NewServer(router)
+}
+
+func TestGatewayPaginate(t *testing.T) {
+	first := setupTestDB()
+	second := setupTestDB()
+	second.Create(&TestUser{Name: "Dina", Email: "dina@example.com", Age: 27})
+
+	backendA := newUserBackend(first)
+	defer backendA.Close()
+	backendB := newUserBackend(second)
+	defer backendB.Close()
+
+	client := NewClient(nil)
+	gateway := NewGateway(
+		func(a, b TestUser) bool { return a.Age < b.Age },
+		func(u TestUser) string { return u.Email },
+		RemoteSource[TestUser](client, "a", backendA.URL+"/users"),
+		RemoteSource[TestUser](client, "b", backendB.URL+"/users"),
+	)
+
+	var ages []int
+	cursor := ""
+	for {
+		page, err := gateway.Paginate(context.Background(), cursor, 2)
+		assert.NoError(t, err)
+		for _, user := range page.Data {
+			ages = append(ages, user.Age)
+		}
+		if !page.HasMore {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	assert.Equal(t, []int{25, 27, 28, 30, 32, 35}, ages)
+}
+
+func TestGatewayPaginate_ForgedCursor(t *testing.T) {
+	leaked := make(chan string, 1)
+	attacker := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		leaked <- r.Header.Get("Authorization")
+	}))
+	defer attacker.Close()
+
+	backend := newUserBackend(setupTestDB())
+	defer backend.Close()
+
+	client := NewClient(nil)
+	client.Header.Set("Authorization", "Bearer secret")
+	gateway := NewGateway(
+		func(a, b TestUser) bool { return a.Age < b.Age },
+		nil,
+		RemoteSource[TestUser](client, "a", backend.URL+"/users"),
+	)
+
+	forged := mergeCursor{Positions: map[string]mergePosition{"a": {Cursor: attacker.URL + "/steal"}}}
+
+	// Unscoped tokens are rejected outright
+	unscoped, err := encodeToken(forged)
+	assert.NoError(t, err)
+	_, err = gateway.Paginate(context.Background(), unscoped, 2)
+	assert.ErrorIs(t, err, ErrTokenScope)
+
+	// A correctly scoped cursor still only carries a query for the configured backend
+	scoped, err := encodeScopedToken(forged, CursorScope("merge", []string{"a"}, ""))
+	assert.NoError(t, err)
+	_, err = gateway.Paginate(context.Background(), scoped, 2)
+	assert.NoError(t, err)
+
+	assert.Empty(t, leaked)
+}
@@ -0,0 +1,220 @@
+// Package ginadapter adapts the transport-neutral API of pagination to Gin. Each
+// function hands the *http.Request and response writer of the Gin context to its
+// net/http counterpart, so Gin and plain net/http handlers bind and answer alike.
+package ginadapter
+
+import (
+	"net/http"
+	"time"
+
+	pagination "github.com/Caknoooo/go-pagination"
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// BindPagination binds the pagination parameters of the request and reports every
+// parameter that was replaced by a default or clamped
+func BindPagination(c *gin.Context) (pagination.PaginationRequest, []pagination.PaginationWarning) {
+	return pagination.BindPaginationRequest(c.Request)
+}
+
+// ParsePagination binds pagination parameters; in strict mode invalid values are
+// reported instead of replaced by defaults
+func ParsePagination(c *gin.Context) (pagination.PaginationRequest, error) {
+	return pagination.ParsePaginationRequest(c.Request)
+}
+
+// BindFilter binds custom filter fields, pagination and includes from the query string
+func BindFilter(c *gin.Context, filter interface{}) error {
+	return pagination.BindFilterRequest(c.Request, filter)
+}
+
+// Paginate paginates db with the pagination parameters of the request, configured by
+// options, see pagination.Paginate
+func Paginate[T any](db *gorm.DB, c *gin.Context, options ...pagination.PaginateOption) ([]T, pagination.PaginationResponse, error) {
+	return pagination.Paginate[T](db, c.Request, options...)
+}
+
+// PaginateResponse creates a complete API response from Paginate
+func PaginateResponse[T any](db *gorm.DB, c *gin.Context, message string, options ...pagination.PaginateOption) pagination.PaginatedResponse {
+	return pagination.PaginateResponse[T](db, c.Request, message, options...)
+}
+
+// PaginateOrSample answers ?sample=n with a random sample of the filtered set and
+// paginates otherwise
+func PaginateOrSample[T any](db *gorm.DB, c *gin.Context, filter pagination.Filterable) ([]T, pagination.PaginationResponse, error) {
+	return pagination.PaginateOrSample[T](db, c.Request, filter)
+}
+
+// PaginateTable paginates a table with the defaults registered for it
+func PaginateTable[T any](
+	db *gorm.DB,
+	c *gin.Context,
+	tableName string,
+	searchFields []string,
+	includes []string,
+	filters ...func(*gorm.DB) *gorm.DB,
+) ([]T, pagination.PaginationResponse, error) {
+	return pagination.PaginateTableRequest[T](db, c.Request, tableName, searchFields, includes, filters...)
+}
+
+// Respond answers with the envelope of the page filter selects, status 400 when the
+// query string does not bind to it
+func Respond[T any](db *gorm.DB, c *gin.Context, filter pagination.Filterable, message string) {
+	response := pagination.PaginatedAPIResponseRequest[T](db, c.Request, filter, message)
+	c.JSON(response.Code, response)
+}
+
+// BindAndValidateFilter binds pagination and query parameters, then validates the filter
+func BindAndValidateFilter(c *gin.Context, filter pagination.IncludableQueryBuilder) error {
+	return pagination.BindAndValidateFilter(c.Request, filter)
+}
+
+// PaginatedAPIResponseWithQueryLayer creates a complete API response using query layer pattern
+func PaginatedAPIResponseWithQueryLayer[T any](
+	c *gin.Context,
+	filter pagination.IncludableQueryBuilder,
+	message string,
+	queryFunc func(pagination.IncludableQueryBuilder) ([]T, int64, error),
+) pagination.PaginatedResponse {
+	return pagination.PaginatedAPIResponseWithQueryLayer(c.Request, filter, message, queryFunc)
+}
+
+// ParseIncludeFields reads sparse fieldsets of includes: fields[province]=id,name
+func ParseIncludeFields(c *gin.Context) map[string][]string {
+	return pagination.ParseIncludeFieldsValues(c.Request.URL.Query())
+}
+
+// BindSample reads ?sample=n; ok is false when no sample was requested
+func BindSample(c *gin.Context) (size int, ok bool, err error) {
+	return pagination.BindSampleValues(c.Request.URL.Query())
+}
+
+// BindPolymorphicFilter reads the polymorphic filter name of the query string, see
+// pagination.BindPolymorphicFilterValues
+func BindPolymorphicFilter(c *gin.Context, name string, types map[string]string) (filter pagination.PolymorphicFilter, ok bool, err error) {
+	return pagination.BindPolymorphicFilterValues(c.Request.URL.Query(), name, types)
+}
+
+// NotModified sets the Last-Modified header and aborts with 304 when the request's
+// If-Modified-Since is not older than lastModified
+func NotModified(c *gin.Context, lastModified time.Time) bool {
+	if !pagination.CheckNotModified(c.Writer, c.Request, lastModified) {
+		return false
+	}
+	c.Writer.WriteHeaderNow()
+	c.Abort()
+	return true
+}
+
+// PaginatedAPIResponseIfModified creates a paginated API response unless nothing in scope
+// changed since the ETag in If-None-Match; notModified reports that the chain was aborted
+// with 304
+func PaginatedAPIResponseIfModified[T any](
+	db *gorm.DB,
+	c *gin.Context,
+	builder pagination.QueryBuilder,
+	column string,
+	message string,
+) (response pagination.PaginatedResponse, notModified bool) {
+	response, notModified = pagination.PaginatedAPIResponseIfModified[T](db, c.Writer, c.Request, builder, column, message)
+	if notModified {
+		c.Writer.WriteHeaderNow()
+		c.Abort()
+	}
+	return response, notModified
+}
+
+// Stream writes the page to the response row by row, see pagination.StreamPaginatedResponse
+func Stream[T any](
+	c *gin.Context,
+	db *gorm.DB,
+	builder pagination.QueryBuilder,
+	request pagination.PaginationRequest,
+	options pagination.PaginatedQueryOptions,
+	stream pagination.StreamOptions,
+) error {
+	return pagination.StreamPaginatedResponse[T](c.Writer, c.Request, db, builder, request, options, stream)
+}
+
+// QueryTags returns the route, controller and request ID of the request as query tags
+func QueryTags(c *gin.Context) map[string]string {
+	tags := pagination.RequestQueryTags(c.Request, c.FullPath())
+	tags["controller"] = c.HandlerName()
+	return tags
+}
+
+// Middleware runs a net/http middleware in a Gin chain: the handlers after it see the
+// request it passes on, and the chain is aborted when it answers without calling next.
+// The route template is available to the middleware as the request pattern.
+func Middleware(middleware func(http.Handler) http.Handler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		called := false
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			called = true
+			c.Request = r
+			c.Next()
+		})
+
+		r := c.Request
+		if r.Pattern == "" && c.FullPath() != "" {
+			r = r.WithContext(r.Context())
+			r.Pattern = c.FullPath()
+		}
+		middleware(next).ServeHTTP(c.Writer, r)
+		if !called {
+			c.Abort()
+		}
+	}
+}
+
+// RequestID propagates the X-Request-ID header of each request, generating an ID when
+// it is missing, see pagination.RequestIDHandler
+func RequestID() gin.HandlerFunc {
+	return Middleware(pagination.RequestIDHandler)
+}
+
+// AdaptivePageSize reduces the page size bound by the handlers of each route under load
+func AdaptivePageSize(sizer *pagination.AdaptivePageSize) gin.HandlerFunc {
+	return Middleware(sizer.Handler)
+}
+
+// MaxPageSize caps the page size bound by the handlers of a route at max
+func MaxPageSize(max int) gin.HandlerFunc {
+	return Middleware(pagination.MaxPageSizeHandler(max))
+}
+
+// Policy resolves the PagePolicy of each request of a route, see pagination.PagePolicy
+func Policy(resolve pagination.PolicyResolver) gin.HandlerFunc {
+	return Middleware(pagination.PolicyHandler(resolve))
+}
+
+// Deadline applies the client-supplied deadline to the request context
+func Deadline(options pagination.DeadlineOptions) gin.HandlerFunc {
+	return Middleware(pagination.DeadlineHandler(options))
+}
+
+// Canonical normalizes listing queries so equivalent URLs share one cache entry
+func Canonical(options pagination.CanonicalOptions) gin.HandlerFunc {
+	return Middleware(pagination.CanonicalHandler(options))
+}
+
+// Admin serves pagination.Introspect as JSON; mount it behind authentication
+func Admin(options pagination.AdminOptions) gin.HandlerFunc {
+	return gin.WrapH(pagination.AdminHTTPHandler(options))
+}
+
+// Capabilities serves the capabilities of filter as JSON
+func Capabilities(filter pagination.QueryBuilder) gin.HandlerFunc {
+	return gin.WrapH(pagination.CapabilitiesHTTPHandler(filter))
+}
+
+// Describe answers OPTIONS requests with the description of the resource served by filter
+func Describe(filter pagination.QueryBuilder) gin.HandlerFunc {
+	return gin.WrapH(pagination.DescribeHTTPHandler(filter))
+}
+
+// RegisterDescribe registers Describe of filter for OPTIONS requests on path
+func RegisterDescribe(routes gin.IRoutes, path string, filter pagination.QueryBuilder) {
+	routes.OPTIONS(path, Describe(filter))
+}
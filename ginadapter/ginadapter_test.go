@@ -0,0 +1,180 @@
+package ginadapter
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	pagination "github.com/Caknoooo/go-pagination"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type testUser struct {
+	ID   uint   `json:"id" gorm:"primaryKey"`
+	Name string `json:"name"`
+	Age  int    `json:"age"`
+}
+
+type testUserFilter struct {
+	pagination.BaseFilter
+	MinAge int `form:"min_age"`
+}
+
+func (f *testUserFilter) ApplyFilters(query *gorm.DB) *gorm.DB {
+	if f.MinAge > 0 {
+		query = query.Where("age >= ?", f.MinAge)
+	}
+	return query
+}
+func (f *testUserFilter) GetTableName() string      { return "test_users" }
+func (f *testUserFilter) GetSearchFields() []string { return []string{"name"} }
+func (f *testUserFilter) GetDefaultSort() string    { return "id asc" }
+
+func setupTestDB(t *testing.T) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&testUser{}))
+	for _, user := range []testUser{{Name: "John", Age: 25}, {Name: "Jane", Age: 30}, {Name: "Bob", Age: 35}} {
+		db.Create(&user)
+	}
+	return db
+}
+
+func serve(router *gin.Engine, target string, header http.Header) *httptest.ResponseRecorder {
+	request := httptest.NewRequest("GET", target, nil)
+	for name, values := range header {
+		request.Header[name] = values
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestRespond(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	router := gin.New()
+	router.GET("/users", func(c *gin.Context) {
+		Respond[testUser](db, c, &testUserFilter{}, "ok")
+	})
+
+	recorder := serve(router, "/users?min_age=30&per_page=1", nil)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	var response struct {
+		Data       []testUser                    `json:"data"`
+		Pagination pagination.PaginationResponse `json:"pagination"`
+	}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "Jane", response.Data[0].Name)
+	assert.Equal(t, int64(2), response.Pagination.Total)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, serve(router, "/users?min_age=old", nil).Code)
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Canonical(pagination.CanonicalOptions{Redirect: true}), Deadline(pagination.DeadlineOptions{}))
+
+	var deadline time.Time
+	var bound pagination.PaginationRequest
+	router.GET("/users", func(c *gin.Context) {
+		deadline, _ = c.Request.Context().Deadline()
+		bound, _ = BindPagination(c)
+		c.Status(http.StatusNoContent)
+	})
+
+	recorder := serve(router, "/users?page=2", nil)
+	assert.Equal(t, http.StatusMovedPermanently, recorder.Code, "a middleware answering aborts the chain")
+	assert.True(t, deadline.IsZero())
+
+	recorder = serve(router, "/users?page=2&per_page=10", http.Header{"X-Request-Timeout": {"5s"}})
+	assert.Equal(t, http.StatusNoContent, recorder.Code)
+	assert.False(t, deadline.IsZero(), "handlers see the request passed on")
+	assert.Equal(t, 2, bound.Page)
+}
+
+func TestAdaptivePageSize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	sizer := &pagination.AdaptivePageSize{Threshold: time.Millisecond, Samples: 1}
+	sizer.Record("/users/:team", time.Second)
+
+	router := gin.New()
+	router.Use(AdaptivePageSize(sizer))
+	var bound pagination.PaginationRequest
+	router.GET("/users/:team", func(c *gin.Context) {
+		bound, _ = BindPagination(c)
+	})
+
+	serve(router, "/users/red?per_page=100", nil)
+	assert.Equal(t, 50, bound.PerPage, "endpoints are told apart by their route template")
+}
+
+func TestMaxPageSize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var bound pagination.PaginationRequest
+	router.GET("/users", MaxPageSize(25), func(c *gin.Context) {
+		bound, _ = BindPagination(c)
+	})
+
+	serve(router, "/users?per_page=100000", nil)
+	assert.Equal(t, 25, bound.PerPage)
+}
+
+func TestPaginateResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	db := setupTestDB(t)
+	var response pagination.PaginatedResponse
+	router := gin.New()
+	router.GET("/users", func(c *gin.Context) {
+		response = PaginateResponse[testUser](db, c, "ok", pagination.WithTable("test_users"), pagination.WithSearchFields("name"))
+	})
+
+	serve(router, "/users?search=J&per_page=1", nil)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, int64(2), response.Pagination.Total)
+	assert.Equal(t, "/users", response.Pagination.Path)
+}
+
+func TestCanonical(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET("/users", Canonical(pagination.CanonicalOptions{}), func(c *gin.Context) {
+		c.String(http.StatusOK, c.Request.URL.RawQuery+"|"+c.Query("per_page"))
+	})
+
+	recorder := serve(router, "/users?sort=%2Bname", nil)
+	assert.Equal(t, "order=asc&page=1&per_page=10&sort=name|10", recorder.Body.String(), "Gin reads the rewritten query")
+}
+
+func TestRequestID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestID())
+	var seen string
+	router.GET("/users", func(c *gin.Context) {
+		seen = pagination.RequestID(c.Request)
+		c.Status(http.StatusNoContent)
+	})
+
+	recorder := serve(router, "/users", http.Header{"X-Request-Id": {"req-42"}})
+	assert.Equal(t, "req-42", seen)
+	assert.Equal(t, "req-42", recorder.Header().Get(pagination.RequestIDHeader))
+}
+
+func TestRegisterDescribe(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	RegisterDescribe(router, "/users", &testUserFilter{})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodOptions, "/users", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, "GET, OPTIONS", recorder.Header().Get("Allow"))
+}
@@ -1,51 +1,141 @@
 package pagination
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
 	"strings"
 
-	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
-// PaginateWithCustomFilter provides pagination using custom filter that implements Filterable interface
-func PaginateWithCustomFilter[T any](
-	db *gorm.DB,
-	ctx *gin.Context,
-	filter Filterable,
-) ([]T, PaginationResponse, error) {
-	// Bind pagination from context
-	if baseFilter, ok := filter.(interface{ BindPagination(*gin.Context) }); ok {
-		baseFilter.BindPagination(ctx)
+// PaginateOption configures Paginate
+type PaginateOption func(*paginateOptions)
+
+// paginateOptions are the settings collected from PaginateOption values
+type paginateOptions struct {
+	table        string
+	searchFields []string
+	includes     []string
+	filters      []func(*gorm.DB) *gorm.DB
+	filterFields map[string]string
+	filter       Filterable
+}
+
+// WithTable paginates a table, applying the defaults registered for it
+func WithTable(tableName string) PaginateOption {
+	return func(o *paginateOptions) { o.table = tableName }
+}
+
+// WithSearchFields sets the columns the search parameter matches, replacing the
+// registered ones and those of earlier WithSearchFields options
+func WithSearchFields(fields ...string) PaginateOption {
+	return func(o *paginateOptions) { o.searchFields = slices.Clone(fields) }
+}
+
+// WithIncludes preloads relationships
+func WithIncludes(includes ...string) PaginateOption {
+	return func(o *paginateOptions) { o.includes = append(o.includes, includes...) }
+}
+
+// WithFilter adds a condition to the query; several filters are applied in order
+func WithFilter(filter func(*gorm.DB) *gorm.DB) PaginateOption {
+	return func(o *paginateOptions) { o.filters = append(o.filters, filter) }
+}
+
+// WithFilterFields accepts operator filters such as ?age=gte:18 on the query parameters
+// of fields, which maps them to their columns, see ParseFilterSet
+func WithFilterFields(fields map[string]string) PaginateOption {
+	return func(o *paginateOptions) {
+		if o.filterFields == nil {
+			o.filterFields = make(map[string]string)
+		}
+		for param, column := range fields {
+			o.filterFields[param] = column
+		}
 	}
+}
+
+// WithCustomFilter binds the query string to filter, which then supplies the table,
+// search fields, includes and conditions. It cannot be combined with the other options.
+func WithCustomFilter(filter Filterable) PaginateOption {
+	return func(o *paginateOptions) { o.filter = filter }
+}
 
-	// Bind custom filter parameters
-	if err := ctx.ShouldBindQuery(filter); err != nil {
-		return nil, PaginationResponse{}, err
+// Paginate paginates db with the pagination parameters of r, configured by options.
+// Either WithTable or WithCustomFilter is required:
+//
+//	users, meta, err := pagination.Paginate[User](db, r,
+//		pagination.WithTable("users"),
+//		pagination.WithSearchFields("name", "email"),
+//		pagination.WithIncludes("Orders"),
+//	)
+//
+// ginadapter.Paginate takes the Gin context instead.
+func Paginate[T any](db *gorm.DB, r *http.Request, options ...PaginateOption) ([]T, PaginationResponse, error) {
+	var o paginateOptions
+	for _, option := range options {
+		option(&o)
+	}
+
+	if o.filter != nil {
+		if o.table != "" || o.searchFields != nil || o.includes != nil || o.filters != nil || o.filterFields != nil {
+			return nil, PaginationResponse{}, errors.New("WithCustomFilter cannot be combined with other paginate options")
+		}
+		return PaginateRequest[T](db, r, o.filter)
 	}
 
-	data, total, err := PaginatedQuery[T](db, filter, filter.GetPagination(), filter.GetIncludes())
-	if err != nil {
-		return nil, PaginationResponse{}, err
+	if o.table == "" {
+		return nil, PaginationResponse{}, errors.New("Paginate requires WithTable or WithCustomFilter")
 	}
+	if o.filterFields != nil {
+		set, err := ParseFilterSet(requestQuery(r), o.filterFields)
+		if err != nil {
+			return nil, PaginationResponse{}, &FilterBindingError{Err: err}
+		}
+		o.filters = append(o.filters, set.Apply)
+	}
+	return PaginateTableRequest[T](db, r, o.table, o.searchFields, o.includes, o.filters...)
+}
 
-	paginationResponse := CalculatePagination(filter.GetPagination(), total)
-	return data, paginationResponse, nil
+// PaginateResponse creates a complete API response from Paginate, answering 400 or 422
+// when the query string does not bind to a custom filter
+func PaginateResponse[T any](db *gorm.DB, r *http.Request, message string, options ...PaginateOption) PaginatedResponse {
+	data, paginationResponse, err := Paginate[T](db, r, options...)
+	return filterResponse(data, paginationResponse, err, message).withRequestID(r)
 }
 
-// PaginatedAPIResponseWithCustomFilter creates a complete API response using custom filter
-func PaginatedAPIResponseWithCustomFilter[T any](
+// helperQuery runs the paginated query of the request helpers, reusing the total of the
+// total_token parameter when Config.TotalTokenTTL enables it, stopping the count at
+// Config.CountLimit and counting in Config.CountMode. It returns the mode used.
+func helperQuery[T any](
 	db *gorm.DB,
-	ctx *gin.Context,
-	filter Filterable,
-	message string,
-) PaginatedResponse {
-	data, paginationResponse, err := PaginateWithCustomFilter[T](db, ctx, filter)
+	query url.Values,
+	builder QueryBuilder,
+	pagination PaginationRequest,
+	includes []string,
+) ([]T, int64, string, CountMode, error) {
+	config := CurrentConfig()
+	reuse := bindTotalReuse(query.Get("total_token"))
+	report := &CountReport{}
+	data, total, err := PaginatedQueryWithOptions[T](db, builder, pagination, includes, PaginatedQueryOptions{
+		Dialect:     MySQL, // Default to MySQL for backward compatibility
+		TotalReuse:  reuse,
+		CountLimit:  config.CountLimit,
+		CountMode:   config.CountMode,
+		CountReport: report,
+	})
+	return data, total, reuse.IssuedToken(), report.Mode(), err
+}
 
-	if err != nil {
-		return NewPaginatedResponse(500, "Internal Server Error: "+err.Error(), nil, PaginationResponse{})
+// helperPagination builds the metadata of a helper query counted in mode
+func helperPagination(ctx context.Context, pagination PaginationRequest, total int64, mode CountMode) PaginationResponse {
+	if mode != CountExact {
+		return CalculateCountModePagination(pagination, total, mode)
 	}
-
-	return NewPaginatedResponse(200, message, data, paginationResponse)
+	return CalculateCappedPagination(pagination, total, requestCountLimit(ctx, pagination))
 }
 
 // CreateSearchableFilter creates a default search implementation for custom filters
@@ -78,126 +168,6 @@ func CreateSearchableFilter(searchFields []string, dialect DatabaseDialect) func
 	}
 }
 
-// PaginateModel provides a simple way to paginate any GORM model
-func PaginateModel[T any](
-	db *gorm.DB,
-	ctx *gin.Context,
-	tableName string,
-	searchFields []string,
-) ([]T, PaginationResponse, error) {
-	pagination := BindPagination(ctx)
-
-	builder := NewSimpleQueryBuilder(tableName).
-		WithSearchFields(searchFields...)
-
-	data, total, err := PaginatedQuery[T](db, builder, pagination, []string{})
-	if err != nil {
-		return nil, PaginationResponse{}, err
-	}
-
-	paginationResponse := CalculatePagination(pagination, total)
-	return data, paginationResponse, nil
-}
-
-// PaginateWithIncludes provides pagination with preloaded relationships
-func PaginateWithIncludes[T any](
-	db *gorm.DB,
-	ctx *gin.Context,
-	tableName string,
-	searchFields []string,
-	includes []string,
-) ([]T, PaginationResponse, error) {
-	pagination := BindPagination(ctx)
-
-	builder := NewSimpleQueryBuilder(tableName).
-		WithSearchFields(searchFields...)
-
-	data, total, err := PaginatedQuery[T](db, builder, pagination, includes)
-	if err != nil {
-		return nil, PaginationResponse{}, err
-	}
-
-	paginationResponse := CalculatePagination(pagination, total)
-	return data, paginationResponse, nil
-}
-
-// PaginateWithFilter provides pagination with custom filters
-func PaginateWithFilter[T any](
-	db *gorm.DB,
-	ctx *gin.Context,
-	tableName string,
-	searchFields []string,
-	filterFunc func(*gorm.DB) *gorm.DB,
-) ([]T, PaginationResponse, error) {
-	pagination := BindPagination(ctx)
-
-	builder := NewSimpleQueryBuilder(tableName).
-		WithSearchFields(searchFields...).
-		WithFilters(filterFunc)
-
-	data, total, err := PaginatedQuery[T](db, builder, pagination, []string{})
-	if err != nil {
-		return nil, PaginationResponse{}, err
-	}
-
-	paginationResponse := CalculatePagination(pagination, total)
-	return data, paginationResponse, nil
-}
-
-// QuickPaginate provides the simplest way to paginate with minimal configuration
-func QuickPaginate[T any](
-	db *gorm.DB,
-	ctx *gin.Context,
-	tableName string,
-) ([]T, PaginationResponse, error) {
-	pagination := BindPagination(ctx)
-
-	builder := NewSimpleQueryBuilder(tableName)
-
-	data, total, err := PaginatedQuery[T](db, builder, pagination, []string{})
-	if err != nil {
-		return nil, PaginationResponse{}, err
-	}
-
-	paginationResponse := CalculatePagination(pagination, total)
-	return data, paginationResponse, nil
-}
-
-// PaginatedAPIResponse creates a complete API response with pagination
-func PaginatedAPIResponse[T any](
-	db *gorm.DB,
-	ctx *gin.Context,
-	tableName string,
-	searchFields []string,
-	message string,
-) PaginatedResponse {
-	data, paginationResponse, err := PaginateModel[T](db, ctx, tableName, searchFields)
-
-	if err != nil {
-		return NewPaginatedResponse(500, "Internal Server Error: "+err.Error(), nil, PaginationResponse{})
-	}
-
-	return NewPaginatedResponse(200, message, data, paginationResponse)
-}
-
-// PaginatedAPIResponseWithIncludes creates a complete API response with pagination and includes
-func PaginatedAPIResponseWithIncludes[T any](
-	db *gorm.DB,
-	ctx *gin.Context,
-	tableName string,
-	searchFields []string,
-	includes []string,
-	message string,
-) PaginatedResponse {
-	data, paginationResponse, err := PaginateWithIncludes[T](db, ctx, tableName, searchFields, includes)
-
-	if err != nil {
-		return NewPaginatedResponse(500, "Internal Server Error: "+err.Error(), nil, PaginationResponse{})
-	}
-
-	return NewPaginatedResponse(200, message, data, paginationResponse)
-}
-
 // PaginatedQueryWithQueryLayer provides pagination using query layer pattern
 // This function separates the database logic from the handler
 func PaginatedQueryWithQueryLayer[T any](
@@ -213,42 +183,34 @@ func PaginatedQueryWithQueryLayer[T any](
 	return queryFunc(filter)
 }
 
-// PaginatedAPIResponseWithQueryLayer creates a complete API response using query layer pattern
+// PaginatedAPIResponseWithQueryLayer creates a complete API response using query layer
+// pattern, binding filter from the query string of r
 func PaginatedAPIResponseWithQueryLayer[T any](
-	ctx *gin.Context,
+	r *http.Request,
 	filter IncludableQueryBuilder,
 	message string,
 	queryFunc func(IncludableQueryBuilder) ([]T, int64, error),
 ) PaginatedResponse {
-	// Bind pagination from context
-	if baseFilter, ok := filter.(interface{ BindPagination(*gin.Context) }); ok {
-		baseFilter.BindPagination(ctx)
-	}
-
-	// Bind custom filter parameters
-	if err := ctx.ShouldBindQuery(filter); err != nil {
-		return NewPaginatedResponse(400, "Invalid query parameters: "+err.Error(), nil, PaginationResponse{})
+	if err := BindFilterRequest(r, filter); err != nil {
+		return NewPaginatedResponse(400, "Invalid query parameters: "+err.Error(), nil, PaginationResponse{}).withRequestID(r)
 	}
 
 	// Execute query through query layer
 	data, total, err := PaginatedQueryWithQueryLayer(filter, queryFunc)
 	if err != nil {
-		return NewPaginatedResponse(500, "Internal Server Error: "+err.Error(), nil, PaginationResponse{})
+		return NewPaginatedResponse(500, "Internal Server Error: "+err.Error(), nil, PaginationResponse{}).withRequestID(r)
 	}
 
 	paginationResponse := CalculatePagination(filter.GetPagination(), total)
+	if warner, ok := filter.(interface{ GetPaginationWarnings() []PaginationWarning }); ok {
+		paginationResponse.Warnings = warner.GetPaginationWarnings()
+	}
 	return NewPaginatedResponse(200, message, data, paginationResponse)
 }
 
-// BindAndValidateFilter binds pagination and query parameters, then validates the filter
-func BindAndValidateFilter(ctx *gin.Context, filter IncludableQueryBuilder) error {
-	// Bind pagination from context
-	if baseFilter, ok := filter.(interface{ BindPagination(*gin.Context) }); ok {
-		baseFilter.BindPagination(ctx)
-	}
-
-	// Bind custom filter parameters
-	if err := ctx.ShouldBindQuery(filter); err != nil {
+// BindAndValidateFilter binds pagination and query parameters of r, then validates the filter
+func BindAndValidateFilter(r *http.Request, filter IncludableQueryBuilder) error {
+	if err := BindFilterRequest(r, filter); err != nil {
 		return err
 	}
 
@@ -259,3 +221,16 @@ func BindAndValidateFilter(ctx *gin.Context, filter IncludableQueryBuilder) erro
 
 	return nil
 }
+
+// FilterBindingError is returned when query parameters cannot be bound to a filter
+type FilterBindingError struct {
+	Err error
+}
+
+func (e *FilterBindingError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *FilterBindingError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,64 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestBindFilter(t *testing.T) {
+	filter := &testUserFilter{}
+	err := BindFilterRequest(newTestRequest("/users?page=2&per_page=500&min_age=30&includes=Orders,%20Tags"), filter)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 30, filter.MinAge)
+	assert.Equal(t, 2, filter.Pagination.Page)
+	assert.Equal(t, 100, filter.Pagination.PerPage, "per_page above the maximum must not slip through the struct binding")
+	assert.Equal(t, []string{"Orders", "Tags"}, filter.Includes)
+}
+
+func TestPaginatedAPIResponseWithCustomFilter_BindingError(t *testing.T) {
+	db := setupTestDB()
+
+	response := PaginateResponse[TestUser](db, newTestRequest("/users?min_age=old"), "ok", WithCustomFilter(&testUserFilter{}))
+	assert.Equal(t, 422, response.Code)
+
+	response = PaginateResponse[TestUser](db, newTestRequest("/users?min_age=30"), "ok", WithCustomFilter(&testUserFilter{}))
+	assert.Equal(t, 200, response.Code)
+	assert.Equal(t, int64(3), response.Pagination.Total)
+}
+
+func TestPaginate_Options(t *testing.T) {
+	db := setupTestDB()
+
+	users, meta, err := Paginate[TestUser](db, newTestRequest("/users?search=jo&sort=age"),
+		WithTable("test_users"),
+		WithSearchFields("name"),
+		WithFilter(func(query *gorm.DB) *gorm.DB { return query.Where("age > ?", 26) }),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), meta.Total)
+	assert.Equal(t, "Bob Johnson", users[0].Name)
+
+	users, meta, err = Paginate[TestUser](db, newTestRequest("/users?min_age=30"), WithCustomFilter(&testUserFilter{}))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), meta.Total)
+	assert.Len(t, users, 3)
+
+	_, meta, err = Paginate[TestUser](db, newTestRequest("/users?search=example"),
+		WithTable("test_users"),
+		WithSearchFields("email"),
+		WithSearchFields("name"),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), meta.Total, "the last WithSearchFields replaces the earlier ones")
+
+	_, _, err = Paginate[TestUser](db, newTestRequest("/users"))
+	assert.Error(t, err)
+	_, _, err = Paginate[TestUser](db, newTestRequest("/users"), WithCustomFilter(&testUserFilter{}), WithTable("test_users"))
+	assert.Error(t, err)
+
+	response := PaginateResponse[TestUser](db, newTestRequest("/users?min_age=old"), "ok", WithCustomFilter(&testUserFilter{}))
+	assert.Equal(t, 422, response.Code)
+}
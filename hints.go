@@ -0,0 +1,205 @@
+package pagination
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IndexHintType is the kind of index hint attached to the table reference
+type IndexHintType string
+
+const (
+	UseIndex    IndexHintType = "USE INDEX"
+	ForceIndex  IndexHintType = "FORCE INDEX"
+	IgnoreIndex IndexHintType = "IGNORE INDEX"
+)
+
+// IndexHint asks the planner to use, force, or ignore specific indexes
+type IndexHint struct {
+	Type    IndexHintType
+	Indexes []string
+}
+
+// QueryHints holds optimizer hints attached to the paginated data and count queries
+type QueryHints struct {
+	// IndexHints apply to the data query, and to the count query unless CountIndexHints is set
+	IndexHints      []IndexHint
+	CountIndexHints []IndexHint
+
+	// OptimizerHints are rendered as /*+ ... */ after SELECT (MySQL 8, pg_hint_plan)
+	OptimizerHints []string
+
+	// Settings are applied with set_config(..., true) inside a transaction on PostgreSQL,
+	// e.g. {"enable_seqscan": "off"}, and as a SETTINGS clause of both queries on ClickHouse;
+	// other dialects ignore them
+	Settings map[string]string
+
+	// CountSettings are added to the SETTINGS clause of the count query on ClickHouse,
+	// see ApproximateClickHouseCount
+	CountSettings map[string]string
+}
+
+// QueryHintsProvider interface for query builders that attach optimizer hints
+type QueryHintsProvider interface {
+	GetQueryHints() QueryHints
+}
+
+func (h QueryHints) isEmpty() bool {
+	return len(h.IndexHints) == 0 && len(h.CountIndexHints) == 0 &&
+		len(h.OptimizerHints) == 0 && len(h.Settings) == 0 && len(h.CountSettings) == 0
+}
+
+// merge combines builder level hints with per-call hints, per-call entries win
+func (h QueryHints) merge(other QueryHints) QueryHints {
+	merged := QueryHints{
+		IndexHints:      append(append([]IndexHint{}, h.IndexHints...), other.IndexHints...),
+		CountIndexHints: append(append([]IndexHint{}, h.CountIndexHints...), other.CountIndexHints...),
+		OptimizerHints:  append(append([]string{}, h.OptimizerHints...), other.OptimizerHints...),
+	}
+
+	merged.Settings = mergeSettings(h.Settings, other.Settings)
+	merged.CountSettings = mergeSettings(h.CountSettings, other.CountSettings)
+
+	return merged
+}
+
+// mergeSettings combines two setting maps, entries of other win; nil when both are empty
+func mergeSettings(settings, other map[string]string) map[string]string {
+	if len(settings) == 0 && len(other) == 0 {
+		return nil
+	}
+	merged := make(map[string]string, len(settings)+len(other))
+	for key, value := range settings {
+		merged[key] = value
+	}
+	for key, value := range other {
+		merged[key] = value
+	}
+	return merged
+}
+
+// resolveQueryHints returns the hints declared by the builder merged with the call options
+func resolveQueryHints(db *gorm.DB, builder interface{}, options PaginatedQueryOptions) QueryHints {
+	hints := QueryHints{}
+	if provider, ok := builder.(QueryHintsProvider); ok {
+		hints = provider.GetQueryHints()
+	}
+	return hints.merge(options.Hints).merge(statementTimeoutHints(DetectDialect(db), options.StatementTimeout))
+}
+
+// statementTimeoutHints translates a statement timeout into the dialect's server-side limit
+// MySQL uses the MAX_EXECUTION_TIME optimizer hint, PostgreSQL a transaction scoped
+// statement_timeout and ClickHouse the max_execution_time setting. Other dialects have no per-statement equivalent and rely on the context.
+func statementTimeoutHints(dialect DatabaseDialect, timeout time.Duration) QueryHints {
+	if timeout <= 0 {
+		return QueryHints{}
+	}
+
+	milliseconds := timeout.Milliseconds()
+	if milliseconds < 1 {
+		milliseconds = 1
+	}
+
+	switch dialect {
+	case MySQL:
+		return QueryHints{OptimizerHints: []string{fmt.Sprintf("MAX_EXECUTION_TIME(%d)", milliseconds)}}
+	case PostgreSQL:
+		return QueryHints{Settings: map[string]string{"statement_timeout": fmt.Sprintf("%dms", milliseconds)}}
+	case ClickHouse:
+		return QueryHints{Settings: map[string]string{"max_execution_time": clickHouseSeconds(timeout)}}
+	default:
+		return QueryHints{}
+	}
+}
+
+// hintedTable starts a query on the table with the index hints rendered for the dialect
+func hintedTable(db *gorm.DB, tableName string, indexHints []IndexHint, dialect DatabaseDialect) *gorm.DB {
+	hint := renderIndexHints(indexHints, dialect)
+	if hint == "" {
+		return db.Table(tableName)
+	}
+
+	query := db.Table("? "+hint, clause.Table{Name: tableName})
+	query.Statement.Table = tableName
+	return query
+}
+
+// renderIndexHints renders the table-level hint syntax of the dialect
+func renderIndexHints(indexHints []IndexHint, dialect DatabaseDialect) string {
+	var parts []string
+
+	for _, hint := range indexHints {
+		indexes := make([]string, 0, len(hint.Indexes))
+		for _, index := range hint.Indexes {
+			if isValidSortField(index) {
+				indexes = append(indexes, index)
+			}
+		}
+		if len(indexes) == 0 {
+			continue
+		}
+
+		switch dialect {
+		case MySQL:
+			hintType := hint.Type
+			if hintType != UseIndex && hintType != ForceIndex && hintType != IgnoreIndex {
+				hintType = UseIndex
+			}
+			parts = append(parts, string(hintType)+" ("+strings.Join(indexes, ", ")+")")
+		case SQLServer:
+			if hint.Type != IgnoreIndex {
+				parts = append(parts, "WITH (INDEX("+strings.Join(indexes, ", ")+"))")
+			}
+		case SQLite:
+			if hint.Type != IgnoreIndex {
+				parts = append(parts, "INDEXED BY "+indexes[0])
+			}
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// optimizerHints renders /*+ ... */ right after the SELECT keyword
+type optimizerHints []string
+
+func (h optimizerHints) ModifyStatement(stmt *gorm.Statement) {
+	selectClause := stmt.Clauses["SELECT"]
+	selectClause.AfterNameExpression = h
+	stmt.Clauses["SELECT"] = selectClause
+}
+
+func (h optimizerHints) Build(builder clause.Builder) {
+	builder.WriteString("/*+ " + strings.Join(h, " ") + " */")
+}
+
+// applyOptimizerHints attaches the optimizer hints to the query, dropping unsafe entries
+func applyOptimizerHints(query *gorm.DB, hints []string) *gorm.DB {
+	var safe optimizerHints
+	for _, hint := range hints {
+		if hint != "" && !strings.Contains(hint, "*/") {
+			safe = append(safe, hint)
+		}
+	}
+	if len(safe) == 0 {
+		return query
+	}
+	return query.Clauses(safe)
+}
+
+// applySessionSettings applies transaction scoped settings on PostgreSQL
+func applySessionSettings(tx *gorm.DB, settings map[string]string) error {
+	for key, value := range settings {
+		if !isValidSortField(key) {
+			return fmt.Errorf("invalid setting name: %s", key)
+		}
+		if err := tx.Exec("SELECT set_config(?, ?, true)", key, value).Error; err != nil {
+			return fmt.Errorf("failed to apply setting %s: %w", key, err)
+		}
+	}
+	return nil
+}
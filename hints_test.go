@@ -0,0 +1,71 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// captureSQL records the SQL of every query executed on db
+func captureSQL(db *gorm.DB) *[]string {
+	statements := &[]string{}
+	db.Callback().Query().After("gorm:query").Register("test:capture_sql", func(tx *gorm.DB) {
+		*statements = append(*statements, tx.Statement.SQL.String())
+	})
+	db.Callback().Row().After("gorm:row").Register("test:capture_sql", func(tx *gorm.DB) {
+		*statements = append(*statements, tx.Statement.SQL.String())
+	})
+	return statements
+}
+
+func TestRenderIndexHints(t *testing.T) {
+	hints := []IndexHint{{Type: ForceIndex, Indexes: []string{"idx_age", "bad;index"}}}
+
+	assert.Equal(t, "FORCE INDEX (idx_age)", renderIndexHints(hints, MySQL))
+	assert.Equal(t, "WITH (INDEX(idx_age))", renderIndexHints(hints, SQLServer))
+	assert.Equal(t, "INDEXED BY idx_age", renderIndexHints(hints, SQLite))
+	assert.Equal(t, "", renderIndexHints(hints, PostgreSQL))
+}
+
+func TestPaginatedQuery_QueryHints(t *testing.T) {
+	db := setupTestDB()
+	db.Exec("CREATE INDEX idx_test_users_age ON test_users(age)")
+	statements := captureSQL(db)
+
+	builder := NewSimpleQueryBuilder("test_users").
+		WithDefaultSort("age asc").
+		WithQueryHints(QueryHints{
+			IndexHints:     []IndexHint{{Type: UseIndex, Indexes: []string{"idx_test_users_age"}}},
+			OptimizerHints: []string{"NO_ICP(test_users)", "bad */ hint"},
+		})
+
+	users, total, err := PaginatedQuery[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.Len(t, users, 2)
+	assert.Len(t, *statements, 2)
+	for _, statement := range *statements {
+		assert.Contains(t, statement, "SELECT /*+ NO_ICP(test_users) */")
+		assert.Contains(t, statement, "INDEXED BY idx_test_users_age")
+	}
+
+	// A hint on a missing index proves the hint reaches the database
+	builder.WithQueryHints(QueryHints{})
+	builder.WithIndexHint(ForceIndex, "idx_missing")
+	_, _, err = PaginatedQuery[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil)
+	assert.Error(t, err)
+}
+
+func TestStatementTimeoutHints(t *testing.T) {
+	mysqlHints := statementTimeoutHints(MySQL, 1500*time.Millisecond)
+	assert.Equal(t, []string{"MAX_EXECUTION_TIME(1500)"}, mysqlHints.OptimizerHints)
+
+	postgresHints := statementTimeoutHints(PostgreSQL, 2*time.Second)
+	assert.Equal(t, map[string]string{"statement_timeout": "2000ms"}, postgresHints.Settings)
+
+	assert.True(t, statementTimeoutHints(SQLite, time.Second).isEmpty())
+	assert.True(t, statementTimeoutHints(MySQL, 0).isEmpty())
+}
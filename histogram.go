@@ -0,0 +1,99 @@
+package pagination
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// HistogramField declares a numeric column bucketed into bins of Width, aligned to multiples of Width
+type HistogramField struct {
+	Column string
+	Width  float64
+}
+
+// HistogramBucket counts the rows with From <= value < To; empty buckets are omitted
+type HistogramBucket struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int64   `json:"count"`
+}
+
+// HistogramProvider is implemented by builders declaring histogram fields
+type HistogramProvider interface {
+	GetHistogramFields() []HistogramField
+}
+
+// Histogram counts the filtered set of builder per bucket of field; NULL values are skipped
+func Histogram(db *gorm.DB, builder QueryBuilder, pagination PaginationRequest, field HistogramField) ([]HistogramBucket, error) {
+	if !isValidSortField(field.Column) {
+		return nil, fmt.Errorf("invalid histogram column %q", field.Column)
+	}
+	if field.Width <= 0 {
+		return nil, fmt.Errorf("histogram width of %s must be positive", field.Column)
+	}
+
+	// The width is inlined so the grouped expression matches the selected one on every dialect;
+	// a decimal literal avoids integer division
+	width := strconv.FormatFloat(field.Width, 'f', -1, 64)
+	if !strings.Contains(width, ".") {
+		width += ".0"
+	}
+	bucket := floorExpression(DetectDialect(db), "("+field.Column+" / "+width+")")
+
+	rows, err := filteredSet(db, builder, pagination).
+		Select(bucket + " AS bucket, COUNT(*) AS bucket_count").
+		Where(field.Column + " IS NOT NULL").
+		Group(bucket).
+		Order("bucket").
+		Rows()
+	if err != nil {
+		return nil, fmt.Errorf("failed to compute histogram of %s: %w", field.Column, err)
+	}
+	defer rows.Close()
+
+	var buckets []HistogramBucket
+	for rows.Next() {
+		var index float64
+		var count int64
+		if err := rows.Scan(&index, &count); err != nil {
+			return nil, fmt.Errorf("failed to compute histogram of %s: %w", field.Column, err)
+		}
+		buckets = append(buckets, HistogramBucket{From: index * field.Width, To: (index + 1) * field.Width, Count: count})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to compute histogram of %s: %w", field.Column, err)
+	}
+
+	return buckets, nil
+}
+
+// Histograms computes the histograms declared by a HistogramProvider builder, keyed by column;
+// it returns nil for other builders
+func Histograms(db *gorm.DB, builder QueryBuilder, pagination PaginationRequest) (map[string][]HistogramBucket, error) {
+	provider, ok := builder.(HistogramProvider)
+	if !ok || len(provider.GetHistogramFields()) == 0 {
+		return nil, nil
+	}
+
+	histograms := make(map[string][]HistogramBucket)
+	for _, field := range provider.GetHistogramFields() {
+		buckets, err := Histogram(db, builder, pagination, field)
+		if err != nil {
+			return nil, err
+		}
+		histograms[field.Column] = buckets
+	}
+	return histograms, nil
+}
+
+// floorExpression rounds a numeric expression down; SQLite builds often lack FLOOR
+func floorExpression(dialect DatabaseDialect, expression string) string {
+	if dialect != SQLite {
+		return "FLOOR(" + expression + ")"
+	}
+	truncated := "CAST(" + expression + " AS INTEGER)"
+	return "(CASE WHEN " + expression + " < " + truncated + " THEN " + truncated + " - 1 ELSE " + truncated + " END)"
+}
@@ -0,0 +1,82 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type histogramUserFilter struct {
+	testUserFilter
+}
+
+func (f *histogramUserFilter) GetHistogramFields() []HistogramField {
+	return []HistogramField{{Column: "age", Width: 5}}
+}
+
+func TestHistogram(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users").WithSearchFields("name")
+
+	buckets, err := Histogram(db, builder, PaginationRequest{}, HistogramField{Column: "age", Width: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, []HistogramBucket{
+		{From: 25, To: 30, Count: 2},
+		{From: 30, To: 35, Count: 2},
+		{From: 35, To: 40, Count: 1},
+	}, buckets)
+
+	buckets, err = Histogram(db, builder, PaginationRequest{Search: "o"}, HistogramField{Column: "age", Width: 10})
+	assert.NoError(t, err)
+	// John Doe (25), Bob Johnson (35), Alice Brown (28), Charlie Wilson (32)
+	assert.Equal(t, []HistogramBucket{{From: 20, To: 30, Count: 2}, {From: 30, To: 40, Count: 2}}, buckets)
+
+	_, err = Histogram(db, builder, PaginationRequest{}, HistogramField{Column: "age", Width: 0})
+	assert.Error(t, err)
+	_, err = Histogram(db, builder, PaginationRequest{}, HistogramField{Column: "age)--", Width: 5})
+	assert.Error(t, err)
+}
+
+func TestHistograms_Builder(t *testing.T) {
+	db := setupTestDB()
+
+	histograms, err := Histograms(db, NewSimpleQueryBuilder("test_users"), PaginationRequest{})
+	assert.NoError(t, err)
+	assert.Nil(t, histograms)
+
+	builder := NewSimpleQueryBuilder("test_users").
+		WithFilters(func(query *gorm.DB) *gorm.DB { return query.Where("age >= ?", 30) }).
+		WithHistogram("age", 2.5)
+	histograms, err = Histograms(db, builder, PaginationRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, []HistogramBucket{
+		{From: 30, To: 32.5, Count: 2},
+		{From: 35, To: 37.5, Count: 1},
+	}, histograms["age"])
+}
+
+func TestPaginateWithCustomFilter_Histograms(t *testing.T) {
+	db := setupTestDB()
+	request := newTestRequest("/users?min_age=28")
+
+	_, meta, err := PaginateRequest[TestUser](db, request, &histogramUserFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []HistogramBucket{
+		{From: 25, To: 30, Count: 1},
+		{From: 30, To: 35, Count: 2},
+		{From: 35, To: 40, Count: 1},
+	}, meta.Histograms["age"])
+}
+
+func TestFloorExpression_SQLiteNegative(t *testing.T) {
+	db := setupTestDB()
+
+	var values []float64
+	for _, number := range []string{"-2.5", "-3.0", "2.5"} {
+		var value float64
+		assert.NoError(t, db.Raw("SELECT "+floorExpression(SQLite, "("+number+")")).Row().Scan(&value))
+		values = append(values, value)
+	}
+	assert.Equal(t, []float64{-3, -3, 2}, values)
+}
@@ -0,0 +1,124 @@
+package pagination
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// IncludeFieldsProvider is implemented by builders restricting the columns loaded per include
+// Keys are lowercase include names, e.g. "province" or "province.country".
+type IncludeFieldsProvider interface {
+	GetIncludeFields() map[string][]string
+}
+
+// ParseIncludeFieldsValues reads sparse fieldsets of includes: fields[province]=id,name
+func ParseIncludeFieldsValues(query url.Values) map[string][]string {
+	fields := make(map[string][]string)
+	for key, values := range query {
+		include, ok := strings.CutPrefix(key, "fields[")
+		if !ok || len(include) < 2 || !strings.HasSuffix(include, "]") || len(values) == 0 {
+			continue
+		}
+		include = strings.TrimSuffix(include, "]")
+
+		var columns []string
+		for _, column := range strings.Split(values[0], ",") {
+			if column = strings.TrimSpace(column); column != "" {
+				columns = append(columns, column)
+			}
+		}
+		if len(columns) > 0 {
+			fields[strings.ToLower(include)] = columns
+		}
+	}
+	return fields
+}
+
+// includeFields returns the requested columns of include, if any
+func includeFields(builder interface{}, include string) []string {
+	provider, ok := builder.(IncludeFieldsProvider)
+	if !ok {
+		return nil
+	}
+	return provider.GetIncludeFields()[strings.ToLower(include)]
+}
+
+// includeColumns resolves the columns to select for an include of model, keeping the
+// keys GORM needs to attach related rows; unknown fields are ignored
+func includeColumns(db *gorm.DB, model interface{}, include string, fields []string) ([]string, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return nil, fmt.Errorf("failed to parse model schema: %w", err)
+	}
+
+	// Walk nested includes down to the relation being preloaded
+	current := stmt.Schema
+	var relationship *schema.Relationship
+	for _, name := range strings.Split(include, ".") {
+		var ok bool
+		relationship, ok = current.Relationships.Relations[name]
+		if !ok {
+			return nil, nil
+		}
+		current = relationship.FieldSchema
+	}
+
+	var columns []string
+	seen := make(map[string]bool)
+	add := func(column string) {
+		if column != "" && !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+
+	for _, field := range fields {
+		if schemaField := current.LookUpField(field); schemaField != nil && schemaField.DBName != "" {
+			add(schemaField.DBName)
+		}
+	}
+	if len(columns) == 0 {
+		return nil, nil
+	}
+
+	for _, field := range current.PrimaryFields {
+		add(field.DBName)
+	}
+	for _, reference := range relationship.References {
+		if reference.ForeignKey != nil && reference.ForeignKey.Schema == current {
+			add(reference.ForeignKey.DBName)
+		}
+		if reference.PrimaryKey != nil && reference.PrimaryKey.Schema == current {
+			add(reference.PrimaryKey.DBName)
+		}
+	}
+	if relationship.Polymorphic != nil && relationship.Polymorphic.PolymorphicType != nil {
+		add(relationship.Polymorphic.PolymorphicType.DBName)
+	}
+
+	return columns, nil
+}
+
+// preloadInclude adds the preload of include, selecting only the requested fields
+func preloadInclude[T any](query *gorm.DB, builder interface{}, include string) (*gorm.DB, error) {
+	fields := includeFields(builder, include)
+	if len(fields) == 0 {
+		return query.Preload(include), nil
+	}
+
+	columns, err := includeColumns(query, new(T), include, fields)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return query.Preload(include), nil
+	}
+
+	return query.Preload(include, func(tx *gorm.DB) *gorm.DB {
+		return tx.Select(columns)
+	}), nil
+}
@@ -0,0 +1,74 @@
+package pagination
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type TestTeam struct {
+	ID      uint
+	Name    string
+	Motto   string
+	Members []TestMember `gorm:"foreignKey:TeamID"`
+}
+
+type TestMember struct {
+	ID     uint
+	TeamID uint
+	Name   string
+	Bio    string
+	Team   *TestTeam
+}
+
+type includeFieldsBuilder struct {
+	*SimpleQueryBuilder
+	fields map[string][]string
+}
+
+func (b includeFieldsBuilder) GetIncludeFields() map[string][]string {
+	return b.fields
+}
+
+func setupTeamsDB() *gorm.DB {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	db.AutoMigrate(&TestTeam{}, &TestMember{})
+	db.Create(&TestTeam{Name: "Red", Motto: "Fast", Members: []TestMember{{Name: "Ann", Bio: "long bio"}, {Name: "Ben", Bio: "long bio"}}})
+	return db
+}
+
+func TestParseIncludeFields(t *testing.T) {
+	fields := ParseIncludeFieldsValues(newTestRequest("/members?fields[Team]=id,%20name&fields[team.owner]=email&fields[empty]=").URL.Query())
+	assert.Equal(t, map[string][]string{"team": {"id", "name"}, "team.owner": {"email"}}, fields)
+}
+
+func TestPreloadInclude_BelongsToFields(t *testing.T) {
+	db := setupTeamsDB()
+	statements := captureSQL(db)
+
+	builder := includeFieldsBuilder{NewSimpleQueryBuilder("test_members"), map[string][]string{"team": {"name", "unknown"}}}
+	data, _, err := PaginatedQuery[TestMember](db, builder, PaginationRequest{Page: 1, PerPage: 10}, []string{"Team"})
+	assert.NoError(t, err)
+
+	if assert.Len(t, data, 2) && assert.NotNil(t, data[0].Team) {
+		assert.Equal(t, "Red", data[0].Team.Name)
+		assert.Equal(t, "", data[0].Team.Motto)
+	}
+	assert.Contains(t, strings.Join(*statements, "\n"), "SELECT `name`,`id` FROM `test_teams`")
+}
+
+func TestPreloadInclude_HasManyFields(t *testing.T) {
+	db := setupTeamsDB()
+
+	builder := includeFieldsBuilder{NewSimpleQueryBuilder("test_teams"), map[string][]string{"members": {"name"}}}
+	data, _, err := PaginatedQuery[TestTeam](db, builder, PaginationRequest{Page: 1, PerPage: 10}, []string{"Members"})
+	assert.NoError(t, err)
+
+	if assert.Len(t, data, 1) && assert.Len(t, data[0].Members, 2) {
+		assert.Equal(t, "Ann", data[0].Members[0].Name)
+		assert.Equal(t, "", data[0].Members[0].Bio)
+	}
+}
@@ -0,0 +1,121 @@
+package pagination
+
+import (
+	"reflect"
+	"strings"
+)
+
+// IncludeLimitsProvider is implemented by builders capping the related rows an include
+// attaches to each row. Keys are lowercase include names, e.g. "members" or
+// "team.members"; the limit applies to the last relation of the path.
+type IncludeLimitsProvider interface {
+	GetIncludeLimits() map[string]int
+}
+
+// IncludeStats describes how an include was loaded for a page
+type IncludeStats struct {
+	// Rows is the number of related rows attached to the page
+	Rows int `json:"rows"`
+	// Truncated is set when the include limit dropped related rows of at least one row
+	Truncated bool `json:"truncated,omitempty"`
+	// Limit is the include limit applied per row, zero without one
+	Limit int `json:"limit,omitempty"`
+}
+
+// IncludeReport collects the IncludeStats of the includes of a page for
+// pagination.includes, so clients can tell incomplete child lists apart
+type IncludeReport struct {
+	stats map[string]IncludeStats
+}
+
+// Stats returns the statistics of every include after the query ran, keyed by the
+// include as requested
+func (r *IncludeReport) Stats() map[string]IncludeStats {
+	if r == nil {
+		return nil
+	}
+	return r.stats
+}
+
+// includeLimits returns the include limits of the builder
+func includeLimits(builder interface{}) map[string]int {
+	if provider, ok := builder.(IncludeLimitsProvider); ok {
+		return provider.GetIncludeLimits()
+	}
+	return nil
+}
+
+// limitIncludes trims the related rows of includes beyond their limit and, with a
+// report, records the rows each include attached. Limits are applied after loading,
+// so they bound the response rather than the rows read.
+func limitIncludes[T any](builder interface{}, includes []string, result []T, report *IncludeReport) {
+	limits := includeLimits(builder)
+	if len(limits) == 0 && report == nil {
+		return
+	}
+
+	for _, include := range includes {
+		stats := IncludeStats{Limit: max(limits[strings.ToLower(include)], 0)}
+		path := strings.Split(include, ".")
+		found := false
+		for i := range result {
+			found = walkInclude(reflect.ValueOf(&result[i]).Elem(), path, &stats) || found
+		}
+		if report != nil && (found || len(result) == 0) {
+			if report.stats == nil {
+				report.stats = make(map[string]IncludeStats)
+			}
+			report.stats[include] = stats
+		}
+	}
+}
+
+// walkInclude follows path from value, counting and trimming the related rows of its
+// last relation; it reports whether the relation exists on the model
+func walkInclude(value reflect.Value, path []string, stats *IncludeStats) bool {
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return true
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return false
+	}
+
+	field := value.FieldByNameFunc(func(name string) bool { return strings.EqualFold(name, path[0]) })
+	if !field.IsValid() {
+		return false
+	}
+
+	if len(path) > 1 {
+		if field.Kind() != reflect.Slice {
+			return walkInclude(field, path[1:], stats)
+		}
+		found := false
+		for i := 0; i < field.Len(); i++ {
+			found = walkInclude(field.Index(i), path[1:], stats) || found
+		}
+		return found || field.Len() == 0
+	}
+
+	switch field.Kind() {
+	case reflect.Slice:
+		rows := field.Len()
+		if stats.Limit > 0 && rows > stats.Limit {
+			field.Set(field.Slice(0, stats.Limit))
+			rows = stats.Limit
+			stats.Truncated = true
+		}
+		stats.Rows += rows
+	case reflect.Ptr, reflect.Interface:
+		if !field.IsNil() {
+			stats.Rows++
+		}
+	default:
+		if !field.IsZero() {
+			stats.Rows++
+		}
+	}
+	return true
+}
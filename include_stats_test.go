@@ -0,0 +1,42 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIncludeReport(t *testing.T) {
+	db := setupTeamsDB()
+	db.Create(&TestTeam{Name: "Blue", Members: []TestMember{{Name: "Cid"}}})
+
+	report := &IncludeReport{}
+	builder := NewSimpleQueryBuilder("test_teams").WithIncludeLimit("Members", 1)
+	data, _, err := PaginatedQueryWithOptions[TestTeam](db, builder, PaginationRequest{Page: 1, PerPage: 10}, []string{"Members"}, PaginatedQueryOptions{
+		Dialect:       SQLite,
+		IncludeReport: report,
+	})
+	assert.NoError(t, err)
+
+	if assert.Len(t, data, 2) {
+		assert.Len(t, data[0].Members, 1)
+		assert.Equal(t, "Ann", data[0].Members[0].Name)
+	}
+	assert.Equal(t, map[string]IncludeStats{"Members": {Rows: 2, Truncated: true, Limit: 1}}, report.Stats())
+}
+
+func TestIncludeReport_NestedAndBelongsTo(t *testing.T) {
+	db := setupTeamsDB()
+
+	report := &IncludeReport{}
+	builder := NewSimpleQueryBuilder("test_members")
+	_, _, err := PaginatedQueryWithOptions[TestMember](db, builder, PaginationRequest{Page: 1, PerPage: 10}, []string{"Team", "Team.Members"}, PaginatedQueryOptions{
+		Dialect:       SQLite,
+		IncludeReport: report,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]IncludeStats{
+		"Team":         {Rows: 2},
+		"Team.Members": {Rows: 4},
+	}, report.Stats())
+}
@@ -0,0 +1,85 @@
+package pagination
+
+import (
+	"net/url"
+	"strings"
+)
+
+// ParseIncludes reads the includes of a query string from include or includes, as
+// comma-separated lists or repeated parameters, e.g. ?include=province,sport.category.
+// Dots name nested relations; duplicates are dropped.
+func ParseIncludes(query url.Values) []string {
+	var includes []string
+	seen := make(map[string]bool)
+	for _, param := range []string{"includes", "include"} {
+		for _, include := range ParseMultiValue(query, param) {
+			if !seen[include] {
+				seen[include] = true
+				includes = append(includes, include)
+			}
+		}
+	}
+	return includes
+}
+
+// resolveInclude returns the allowed include requested by include. Names match exactly
+// or segment by segment ignoring case and underscores, so "sport.category" and
+// "Sport.Category" both resolve to the allowed "Sport.Category".
+func resolveInclude(include string, allowed map[string]bool) (string, bool) {
+	if !isValidInclude(include) {
+		return "", false
+	}
+	if allowed[include] {
+		return include, true
+	}
+	normalized := normalizeInclude(include)
+	for _, name := range sortedKeys(allowed) {
+		if allowed[name] && normalizeInclude(name) == normalized {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// normalizeInclude lowercases an include and drops its underscores
+func normalizeInclude(include string) string {
+	return strings.ReplaceAll(strings.ToLower(include), "_", "")
+}
+
+// allowIncludes resolves includes against allowed, dropping the others
+func allowIncludes(includes []string, allowed map[string]bool) (kept, rejected []string) {
+	for _, include := range includes {
+		if name, ok := resolveInclude(include, allowed); ok {
+			kept = append(kept, name)
+		} else {
+			rejected = append(rejected, include)
+		}
+	}
+	return kept, rejected
+}
+
+// restrictIncludes resolves the bound includes of a filter implementing
+// AllowedIncludesProvider, warning about each include it does not allow
+func (f *BaseFilter) restrictIncludes(allowed map[string]bool) {
+	kept, rejected := allowIncludes(f.Includes, allowed)
+	if len(rejected) == 0 {
+		f.Includes = kept
+		return
+	}
+
+	var names []string
+	for _, name := range sortedKeys(allowed) {
+		if allowed[name] {
+			names = append(names, name)
+		}
+	}
+	for _, include := range rejected {
+		f.warnings = append(f.warnings, PaginationWarning{
+			Param:   "includes",
+			Value:   include,
+			Applied: "ignored",
+			Message: "include must be one of " + strings.Join(names, ", "),
+		})
+	}
+	f.Includes = kept
+}
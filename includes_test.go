@@ -0,0 +1,67 @@
+package pagination
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type memberIncludeFilter struct {
+	BaseFilter
+}
+
+func (f *memberIncludeFilter) ApplyFilters(query *gorm.DB) *gorm.DB { return query }
+func (f *memberIncludeFilter) GetTableName() string                 { return "test_members" }
+func (f *memberIncludeFilter) GetDefaultSort() string               { return "id asc" }
+func (f *memberIncludeFilter) GetSearchFields() []string            { return []string{"name"} }
+func (f *memberIncludeFilter) GetAllowedIncludes() map[string]bool {
+	return map[string]bool{"Team": true, "Team.Members": true, "Secrets": false}
+}
+
+func TestParseIncludes(t *testing.T) {
+	query, _ := url.ParseQuery("include=province,sport.category&includes=Team&include[]=province&include=")
+	assert.Equal(t, []string{"Team", "province", "sport.category"}, ParseIncludes(query))
+}
+
+func TestResolveInclude(t *testing.T) {
+	allowed := map[string]bool{"Sport.Category": true, "PlayerStats": true, "Hidden": false}
+
+	for include, want := range map[string]string{
+		"Sport.Category": "Sport.Category",
+		"sport.category": "Sport.Category",
+		"player_stats":   "PlayerStats",
+	} {
+		name, ok := resolveInclude(include, allowed)
+		assert.True(t, ok, include)
+		assert.Equal(t, want, name)
+	}
+	for _, include := range []string{"sport", "hidden", "Sport.Category;--"} {
+		_, ok := resolveInclude(include, allowed)
+		assert.False(t, ok, include)
+	}
+}
+
+func TestPaginateRequest_NestedIncludes(t *testing.T) {
+	db := setupTeamsDB()
+
+	filter := &memberIncludeFilter{}
+	members, meta, err := PaginateRequest[TestMember](db, httptest.NewRequest("GET", "/members?include=team.members,secrets,profile", nil), filter)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Team.Members"}, filter.GetIncludes())
+	if assert.Len(t, members, 2) && assert.NotNil(t, members[0].Team) {
+		assert.Equal(t, "Red", members[0].Team.Name)
+		assert.Len(t, members[0].Team.Members, 2, "nested relations are preloaded")
+	}
+
+	var ignored []string
+	for _, warning := range meta.Warnings {
+		if warning.Param == "includes" {
+			ignored = append(ignored, warning.Value)
+			assert.Equal(t, "include must be one of Team, Team.Members", warning.Message)
+		}
+	}
+	assert.Equal(t, []string{"secrets", "profile"}, ignored)
+}
@@ -0,0 +1,67 @@
+package pagination
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// TableInvalidator drops the cached data of a table; TotalCache implements it
+type TableInvalidator interface {
+	InvalidateTable(table string)
+}
+
+// CacheInvalidator is a GORM plugin invalidating caches for the table of every successful
+// create, update and delete. Register it with db.Use(pagination.CacheInvalidator{Caches: ...}).
+// Writes through db.Exec carry no table and are not seen; writes inside a transaction
+// invalidate before the commit.
+type CacheInvalidator struct {
+	Caches []TableInvalidator
+}
+
+// Name implements gorm.Plugin
+func (CacheInvalidator) Name() string {
+	return "pagination:cache_invalidator"
+}
+
+// Initialize implements gorm.Plugin
+func (c CacheInvalidator) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Create().After("gorm:create").Register("pagination:invalidate_cache", c.invalidate); err != nil {
+		return err
+	}
+	if err := db.Callback().Update().After("gorm:update").Register("pagination:invalidate_cache", c.invalidate); err != nil {
+		return err
+	}
+	return db.Callback().Delete().After("gorm:delete").Register("pagination:invalidate_cache", c.invalidate)
+}
+
+func (c CacheInvalidator) invalidate(db *gorm.DB) {
+	if db.Error != nil {
+		return
+	}
+
+	table := db.Statement.Table
+	if table == "" && db.Statement.Schema != nil {
+		table = db.Statement.Schema.Table
+	}
+	if table == "" {
+		return
+	}
+
+	for _, cache := range c.Caches {
+		cache.InvalidateTable(table)
+	}
+}
+
+// baseTableName reduces "public.users u" or `users` to users so writes and queries agree
+func baseTableName(table string) string {
+	fields := strings.Fields(table)
+	if len(fields) == 0 {
+		return ""
+	}
+	name := fields[0]
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		name = name[idx+1:]
+	}
+	return strings.Trim(name, "`\"[]")
+}
@@ -0,0 +1,79 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheInvalidator(t *testing.T) {
+	db := setupTestDB()
+	db.AutoMigrate(&TestChange{})
+
+	cache := NewTotalCache(time.Minute)
+	assert.NoError(t, db.Use(CacheInvalidator{Caches: []TableInvalidator{cache}}))
+
+	builder := NewSimpleQueryBuilder("test_users u")
+	options := PaginatedQueryOptions{Dialect: SQLite, TotalCache: cache}
+	request := PaginationRequest{Page: 1, PerPage: 2}
+
+	_, total, err := PaginatedQueryWithOptions[TestUser](db, builder, request, nil, options)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+
+	// Writes to other tables keep the cached total
+	db.Create(&TestChange{Name: "x"})
+	assert.Equal(t, 1, cache.Stats().Entries)
+
+	db.Create(&TestUser{Name: "Dave", Email: "dave@example.com", Age: 40})
+	assert.Equal(t, 0, cache.Stats().Entries)
+
+	_, total, err = PaginatedQueryWithOptions[TestUser](db, builder, request, nil, options)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), total)
+
+	db.Model(&TestUser{}).Where("name = ?", "Dave").Update("age", 41)
+	assert.Equal(t, 0, cache.Stats().Entries)
+
+	PaginatedQueryWithOptions[TestUser](db, builder, request, nil, options)
+	db.Where("name = ?", "Dave").Delete(&TestUser{})
+	_, total, err = PaginatedQueryWithOptions[TestUser](db, builder, request, nil, options)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+}
+
+func TestTotalCache_InvalidateTable(t *testing.T) {
+	cache := NewTotalCache(0)
+	cache.store("users", "users", 1)
+	cache.store("teams", "teams", 2)
+	cache.Set("custom", 3)
+
+	cache.InvalidateTable("`users`")
+
+	_, ok := cache.Get("users")
+	assert.False(t, ok)
+	_, ok = cache.Get("custom")
+	assert.False(t, ok)
+	total, ok := cache.Get("teams")
+	assert.True(t, ok)
+	assert.Equal(t, int64(2), total)
+}
+
+func TestTotalCache_StaleCountNotStored(t *testing.T) {
+	cache := NewTotalCache(0)
+
+	generation := cache.currentGeneration()
+	cache.InvalidateTable("users")
+	cache.storeCounted("users", "users", 5, generation)
+
+	_, ok := cache.Get("users")
+	assert.False(t, ok, "a count started before the write must not be cached")
+}
+
+func TestBaseTableName(t *testing.T) {
+	assert.Equal(t, "users", baseTableName("public.users u"))
+	assert.Equal(t, "users", baseTableName(`"users"`))
+	assert.Equal(t, "users", baseTableName("users"))
+	assert.Equal(t, "", baseTableName(""))
+}
@@ -0,0 +1,192 @@
+package pagination
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// JSONAPIMediaType is the content type of JSON:API documents
+const JSONAPIMediaType = "application/vnd.api+json"
+
+// JSONAPIDocument is a JSON:API top-level document holding a page of resources, or the
+// errors of a failed request
+type JSONAPIDocument struct {
+	Data   []JSONAPIResource `json:"data,omitempty"`
+	Errors []JSONAPIError    `json:"errors,omitempty"`
+	Links  *JSONAPILinks     `json:"links,omitempty"`
+	Meta   *JSONAPIMeta      `json:"meta,omitempty"`
+}
+
+// JSONAPIResource is a resource object; the attributes are the JSON fields of the model
+// other than its primary key
+type JSONAPIResource struct {
+	Type       string                 `json:"type"`
+	ID         string                 `json:"id"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
+
+// JSONAPILinks are the self and pagination links of a document
+type JSONAPILinks struct {
+	Self  string `json:"self"`
+	First string `json:"first,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last,omitempty"`
+}
+
+// JSONAPIMeta carries the total, page count and last page; they are omitted while unknown
+type JSONAPIMeta struct {
+	Total    *int64 `json:"total,omitempty"`
+	Pages    *int64 `json:"pages,omitempty"`
+	LastPage *int64 `json:"last_page,omitempty"`
+	Path     string `json:"path,omitempty"`
+}
+
+// JSONAPIError is an error object; Source names the query parameter at fault
+type JSONAPIError struct {
+	Status string              `json:"status"`
+	Title  string              `json:"title"`
+	Detail string              `json:"detail,omitempty"`
+	Source *JSONAPIErrorSource `json:"source,omitempty"`
+}
+
+// JSONAPIErrorSource points at the query parameter causing an error
+type JSONAPIErrorSource struct {
+	Parameter string `json:"parameter"`
+}
+
+// MarshalJSON keeps data in documents without errors, as an empty page is "data": []
+func (d JSONAPIDocument) MarshalJSON() ([]byte, error) {
+	type plain JSONAPIDocument
+	if len(d.Errors) > 0 {
+		return json.Marshal(plain(d))
+	}
+	data := d.Data
+	if data == nil {
+		data = []JSONAPIResource{}
+	}
+	return json.Marshal(struct {
+		Data []JSONAPIResource `json:"data"`
+		plain
+	}{Data: data, plain: plain(d)})
+}
+
+// GenerateJSONAPIResponse renders a page as a JSON:API document: each row becomes a
+// resource of resourceType (the table of T when empty) identified by its primary key,
+// the links follow NewPageLinks, and meta holds total and pages. Set
+// Config.ParamStyle to ParamStyleJSONAPI to read and link page[number] and page[size].
+func GenerateJSONAPIResponse[T any](db *gorm.DB, r *http.Request, data []T, meta PaginationResponse, resourceType string) (JSONAPIDocument, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return JSONAPIDocument{}, fmt.Errorf("failed to parse model schema: %w", err)
+	}
+	if resourceType == "" {
+		resourceType = stmt.Schema.Table
+	}
+	idColumn, err := primaryKeyColumn[T](db)
+	if err != nil {
+		return JSONAPIDocument{}, err
+	}
+	idKey := "id"
+	if field := stmt.Schema.LookUpField(idColumn); field != nil {
+		idKey = jsonFieldName(field.StructField)
+	}
+
+	resources := make([]JSONAPIResource, len(data))
+	for i := range data {
+		id, err := columnValue(db, &data[i], idColumn)
+		if err != nil {
+			return JSONAPIDocument{}, err
+		}
+		attributes, err := jsonAttributes(data[i])
+		if err != nil {
+			return JSONAPIDocument{}, err
+		}
+		delete(attributes, idKey)
+		resources[i] = JSONAPIResource{Type: resourceType, ID: fmt.Sprint(id), Attributes: attributes}
+	}
+
+	document := JSONAPIDocument{Data: resources, Meta: &JSONAPIMeta{}}
+	if meta.TotalStatus == "" && !meta.IsDisabled {
+		total, pages, lastPage := meta.Total, meta.MaxPage, meta.LastPage
+		document.Meta.Total, document.Meta.Pages, document.Meta.LastPage = &total, &pages, &lastPage
+	} else if meta.IsDisabled {
+		total := meta.Total
+		document.Meta.Total = &total
+	}
+	if r != nil && r.URL != nil {
+		document.Meta.Path = r.URL.Path
+		links := NewPageLinks(r, meta)
+		document.Links = &JSONAPILinks{
+			Self:  r.URL.RequestURI(),
+			First: links.First,
+			Prev:  links.Prev,
+			Next:  links.Next,
+			Last:  links.Last,
+		}
+	}
+	return document, nil
+}
+
+// JSONAPIErrorDocument renders err as the errors of a JSON:API document: one error per
+// bad parameter of a *CoercionError, otherwise a single error with status
+func JSONAPIErrorDocument(status int, err error) JSONAPIDocument {
+	var coercionErr *CoercionError
+	if errors.As(err, &coercionErr) {
+		document := JSONAPIDocument{}
+		for _, param := range coercionErr.Params {
+			document.Errors = append(document.Errors, JSONAPIError{
+				Status: strconv.Itoa(http.StatusUnprocessableEntity),
+				Title:  "Invalid query parameter",
+				Detail: param.Message,
+				Source: &JSONAPIErrorSource{Parameter: param.Param},
+			})
+		}
+		return document
+	}
+	return JSONAPIDocument{Errors: []JSONAPIError{{
+		Status: strconv.Itoa(status),
+		Title:  http.StatusText(status),
+		Detail: err.Error(),
+	}}}
+}
+
+// WriteJSONAPI writes document to w with the JSON:API media type
+func WriteJSONAPI(w http.ResponseWriter, status int, document JSONAPIDocument) {
+	body, err := json.Marshal(document)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", JSONAPIMediaType)
+	w.WriteHeader(status)
+	_, _ = w.Write(body)
+}
+
+// jsonAttributes returns the JSON object item encodes to
+func jsonAttributes(item interface{}) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(item)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode resource attributes: %w", err)
+	}
+	var attributes map[string]interface{}
+	if err := json.Unmarshal(encoded, &attributes); err != nil {
+		return nil, fmt.Errorf("resource must encode to a JSON object: %w", err)
+	}
+	return attributes, nil
+}
+
+// jsonFieldName returns the key field encodes to in JSON
+func jsonFieldName(field reflect.StructField) string {
+	if name, _, _ := strings.Cut(field.Tag.Get("json"), ","); name != "" && name != "-" {
+		return name
+	}
+	return field.Name
+}
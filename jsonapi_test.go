@@ -0,0 +1,55 @@
+package pagination
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateJSONAPIResponse(t *testing.T) {
+	db := setupTestDB()
+	useTestConfig(t, map[string]string{EnvParamStyle: "jsonapi"})
+
+	request := httptest.NewRequest("GET", "/users?page%5Bnumber%5D=2&page%5Bsize%5D=2", nil)
+	pagination, warnings := BindPaginationRequest(request)
+	assert.Empty(t, warnings)
+	assert.Equal(t, 2, pagination.Page)
+	assert.Equal(t, 2, pagination.PerPage)
+
+	users, total, err := PaginatedQueryWithOptions[TestUser](db, NewSimpleQueryBuilder("test_users"), pagination, nil, PaginatedQueryOptions{Dialect: SQLite})
+	assert.NoError(t, err)
+	meta := CalculatePagination(pagination, total)
+
+	document, err := GenerateJSONAPIResponse(db, request, users, meta, "")
+	assert.NoError(t, err)
+	assert.Len(t, document.Data, 2)
+	assert.Equal(t, "test_users", document.Data[0].Type)
+	assert.Equal(t, "3", document.Data[0].ID)
+	assert.Equal(t, map[string]interface{}{"name": "Bob Johnson", "email": "bob@example.com", "age": float64(35)}, document.Data[0].Attributes)
+	assert.Equal(t, int64(5), *document.Meta.Total)
+	assert.Equal(t, int64(3), *document.Meta.Pages)
+	assert.Equal(t, int64(3), *document.Meta.LastPage)
+	assert.Equal(t, "/users", document.Meta.Path)
+
+	next, err := url.Parse(document.Links.Next)
+	assert.NoError(t, err)
+	assert.Equal(t, "/users?page%5Bnumber%5D=2&page%5Bsize%5D=2", document.Links.Self)
+	assert.Equal(t, "3", next.Query().Get("page[number]"))
+	assert.Equal(t, "2", next.Query().Get("page[size]"))
+	assert.NotContains(t, next.Query(), "page")
+}
+
+func TestJSONAPIDocument_MarshalJSON(t *testing.T) {
+	empty, err := json.Marshal(JSONAPIDocument{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"data": []}`, string(empty))
+
+	failed, err := json.Marshal(JSONAPIErrorDocument(400, &CoercionError{Params: []ParamError{
+		{Param: "min_age", Value: "old", Type: "integer", Message: "min_age must be an integer"},
+	}}))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"errors": [{"status": "422", "title": "Invalid query parameter", "detail": "min_age must be an integer", "source": {"parameter": "min_age"}}]}`, string(failed))
+}
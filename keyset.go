@@ -0,0 +1,111 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// KeysetColumn describes one column of a composite keyset ordering
+type KeysetColumn struct {
+	Name string
+	Desc bool
+}
+
+// keysetOrderClause returns the ORDER BY fragment for the keyset columns
+func keysetOrderClause(columns []KeysetColumn) string {
+	parts := make([]string, len(columns))
+	for i, column := range columns {
+		direction := "asc"
+		if column.Desc {
+			direction = "desc"
+		}
+		parts[i] = column.Name + " " + direction
+	}
+	return strings.Join(parts, ", ")
+}
+
+// buildKeysetCondition builds a WHERE clause selecting rows strictly after the given key values
+// For columns (a, b) it produces: (a > ?) OR (a = ? AND b > ?)
+func buildKeysetCondition(columns []KeysetColumn, values []interface{}) (string, []interface{}) {
+	if len(columns) == 0 || len(columns) != len(values) {
+		return "", nil
+	}
+
+	var groups []string
+	var args []interface{}
+
+	for i, column := range columns {
+		var conditions []string
+
+		for j := 0; j < i; j++ {
+			conditions = append(conditions, columns[j].Name+" = ?")
+			args = append(args, values[j])
+		}
+
+		operator := ">"
+		if column.Desc {
+			operator = "<"
+		}
+		conditions = append(conditions, column.Name+" "+operator+" ?")
+		args = append(args, values[i])
+
+		groups = append(groups, "("+strings.Join(conditions, " AND ")+")")
+	}
+
+	return "(" + strings.Join(groups, " OR ") + ")", args
+}
+
+// applyKeyset applies the keyset condition and ordering to the query
+func applyKeyset(query *gorm.DB, columns []KeysetColumn, values []interface{}) *gorm.DB {
+	if len(values) > 0 {
+		condition, args := buildKeysetCondition(columns, values)
+		if condition != "" {
+			query = query.Where(condition, args...)
+		}
+	}
+	return query.Order(keysetOrderClause(columns))
+}
+
+// keysetValues extracts the values of the keyset columns from a model instance
+func keysetValues(db *gorm.DB, item interface{}, columns []KeysetColumn) ([]interface{}, error) {
+	values := make([]interface{}, len(columns))
+	for i, column := range columns {
+		value, err := columnValue(db, item, column.Name)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// columnValue reads the value of a database column from a model instance using the GORM schema
+func columnValue(db *gorm.DB, item interface{}, column string) (interface{}, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(item); err != nil {
+		return nil, fmt.Errorf("failed to parse model schema: %w", err)
+	}
+
+	// Strip any table qualifier, e.g. "athletes.id"
+	name := column
+	if idx := strings.LastIndex(name, "."); idx >= 0 {
+		name = name[idx+1:]
+	}
+
+	field := stmt.Schema.LookUpField(name)
+	if field == nil {
+		return nil, fmt.Errorf("column %s not found on model %s", column, stmt.Schema.Name)
+	}
+
+	value := reflect.ValueOf(item)
+	for value.Kind() == reflect.Ptr {
+		value = value.Elem()
+	}
+
+	fieldValue, _ := field.ValueOf(context.Background(), value)
+	return fieldValue, nil
+}
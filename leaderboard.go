@@ -0,0 +1,117 @@
+package pagination
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// LeaderboardOptions configures ranked pagination
+type LeaderboardOptions struct {
+	ScoreColumn string
+	// Ascending ranks the lowest score first, e.g. for race times
+	Ascending bool
+	// TieBreaker orders rows sharing a rank, the primary key by default
+	TieBreaker string
+	// Competitor, when set, replaces the requested page by the page containing this primary key
+	Competitor interface{}
+}
+
+// RankedItem is one row of a leaderboard; equal scores share a rank (dense ranking)
+// while Position is unique
+type RankedItem[T any] struct {
+	Rank     int64 `json:"rank"`
+	Position int64 `json:"position"`
+	Item     T     `json:"item"`
+}
+
+// rankedRow is the scan target of the ranked query
+type rankedRow[T any] struct {
+	Item                T `gorm:"embedded"`
+	LeaderboardRank     int64
+	LeaderboardPosition int64
+}
+
+func (o *LeaderboardOptions) validate() error {
+	if !isValidSortField(o.ScoreColumn) {
+		return fmt.Errorf("invalid score column %q", o.ScoreColumn)
+	}
+	if o.TieBreaker != "" && !isValidSortField(o.TieBreaker) {
+		return fmt.Errorf("invalid tie breaker column %q", o.TieBreaker)
+	}
+	return nil
+}
+
+// PaginateLeaderboard paginates the filtered set of builder by score with each row's rank;
+// requires window functions (SQLite 3.25+, MySQL 8+, PostgreSQL, SQL Server)
+func PaginateLeaderboard[T any](db *gorm.DB, builder QueryBuilder, pagination PaginationRequest, options LeaderboardOptions) ([]RankedItem[T], PaginationResponse, error) {
+	if err := options.validate(); err != nil {
+		return nil, PaginationResponse{}, err
+	}
+	pagination.Validate()
+
+	primaryKey := distinctPrimaryKey[T](db, builder.GetTableName())
+	if options.TieBreaker == "" {
+		options.TieBreaker = primaryKey
+	}
+
+	var total int64
+	if err := filteredSet(db, builder, pagination).Count(&total).Error; err != nil {
+		return nil, PaginationResponse{}, fmt.Errorf("failed to count records: %w", err)
+	}
+
+	if options.Competitor != nil {
+		var position int64
+		err := db.Table("(?) ranked", rankedQuery(db, builder, pagination, options)).
+			Where(primaryKeyName(primaryKey)+" = ?", options.Competitor).
+			Select("leaderboard_position").
+			Row().Scan(&position)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, PaginationResponse{}, fmt.Errorf("competitor %v is not on the leaderboard: %w", options.Competitor, gorm.ErrRecordNotFound)
+		}
+		if err != nil {
+			return nil, PaginationResponse{}, fmt.Errorf("failed to locate competitor: %w", err)
+		}
+		pagination.Page = int((position-1)/int64(pagination.GetLimit())) + 1
+	}
+
+	query := db.Table("(?) ranked", rankedQuery(db, builder, pagination, options)).Order("leaderboard_position")
+	if !pagination.IsDisabled {
+		query = query.Offset(pagination.GetOffset()).Limit(pagination.GetLimit())
+	}
+
+	var rows []rankedRow[T]
+	if err := query.Scan(&rows).Error; err != nil {
+		return nil, PaginationResponse{}, fmt.Errorf("failed to fetch leaderboard: %w", err)
+	}
+
+	items := make([]RankedItem[T], len(rows))
+	for i, row := range rows {
+		items[i] = RankedItem[T]{Rank: row.LeaderboardRank, Position: row.LeaderboardPosition, Item: row.Item}
+	}
+	return items, CalculatePagination(pagination, total), nil
+}
+
+// rankedQuery selects the filtered rows with their dense rank and unique position;
+// it is aliased without AS, which Oracle rejects for tables
+func rankedQuery(db *gorm.DB, builder QueryBuilder, pagination PaginationRequest, options LeaderboardOptions) *gorm.DB {
+	direction := "DESC"
+	if options.Ascending {
+		direction = "ASC"
+	}
+	score := "ORDER BY " + options.ScoreColumn + " " + direction
+
+	return filteredSet(db, builder, pagination).Select(
+		tableQualifier(builder.GetTableName()) + ".*, " +
+			"DENSE_RANK() OVER (" + score + ") AS leaderboard_rank, " +
+			"ROW_NUMBER() OVER (" + score + ", " + options.TieBreaker + " ASC) AS leaderboard_position",
+	)
+}
+
+// primaryKeyName strips the table qualifier, columns of the ranked subquery are unqualified
+func primaryKeyName(column string) string {
+	return column[strings.LastIndex(column, ".")+1:]
+}
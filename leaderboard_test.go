@@ -0,0 +1,85 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type TestScore struct {
+	ID     uint
+	Player string
+	League string
+	Points int
+}
+
+func setupScoresDB() *gorm.DB {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	db.AutoMigrate(&TestScore{})
+	db.Create(&[]TestScore{
+		{Player: "ana", League: "a", Points: 90},
+		{Player: "bo", League: "b", Points: 120},
+		{Player: "cy", League: "a", Points: 90},
+		{Player: "di", League: "a", Points: 75},
+		{Player: "ed", League: "b", Points: 120},
+		{Player: "fu", League: "a", Points: 60},
+	})
+	return db
+}
+
+func leaderboardPlayers(items []RankedItem[TestScore]) ([]string, []int64) {
+	players := make([]string, len(items))
+	ranks := make([]int64, len(items))
+	for i, item := range items {
+		players[i] = item.Item.Player
+		ranks[i] = item.Rank
+	}
+	return players, ranks
+}
+
+func TestPaginateLeaderboard(t *testing.T) {
+	db := setupScoresDB()
+	builder := NewSimpleQueryBuilder("test_scores")
+	options := LeaderboardOptions{ScoreColumn: "points"}
+
+	items, meta, err := PaginateLeaderboard[TestScore](db, builder, PaginationRequest{Page: 1, PerPage: 4}, options)
+	assert.NoError(t, err)
+	players, ranks := leaderboardPlayers(items)
+	assert.Equal(t, []string{"bo", "ed", "ana", "cy"}, players, "ties are ordered by primary key")
+	assert.Equal(t, []int64{1, 1, 2, 2}, ranks)
+	assert.Equal(t, int64(6), meta.Total)
+
+	items, _, err = PaginateLeaderboard[TestScore](db, builder, PaginationRequest{Page: 2, PerPage: 4}, options)
+	assert.NoError(t, err)
+	players, ranks = leaderboardPlayers(items)
+	assert.Equal(t, []string{"di", "fu"}, players)
+	assert.Equal(t, []int64{3, 4}, ranks)
+	assert.Equal(t, int64(6), items[1].Position)
+
+	filtered := NewSimpleQueryBuilder("test_scores").WithFilters(func(query *gorm.DB) *gorm.DB { return query.Where("league = ?", "a") })
+	items, _, err = PaginateLeaderboard[TestScore](db, filtered, PaginationRequest{Page: 1, PerPage: 10}, LeaderboardOptions{ScoreColumn: "points", Ascending: true})
+	assert.NoError(t, err)
+	players, ranks = leaderboardPlayers(items)
+	assert.Equal(t, []string{"fu", "di", "ana", "cy"}, players)
+	assert.Equal(t, []int64{1, 2, 3, 3}, ranks)
+}
+
+func TestPaginateLeaderboard_Competitor(t *testing.T) {
+	db := setupScoresDB()
+	builder := NewSimpleQueryBuilder("test_scores")
+
+	// fu (id 6) is in position 6, on page 3 of 2 per page
+	items, meta, err := PaginateLeaderboard[TestScore](db, builder, PaginationRequest{Page: 1, PerPage: 2}, LeaderboardOptions{ScoreColumn: "points", Competitor: 6})
+	assert.NoError(t, err)
+	players, _ := leaderboardPlayers(items)
+	assert.Equal(t, []string{"di", "fu"}, players)
+	assert.Equal(t, 3, meta.Page)
+
+	_, _, err = PaginateLeaderboard[TestScore](db, builder, PaginationRequest{Page: 1, PerPage: 2}, LeaderboardOptions{ScoreColumn: "points", Competitor: 99})
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+
+	_, _, err = PaginateLeaderboard[TestScore](db, builder, PaginationRequest{}, LeaderboardOptions{ScoreColumn: "points desc"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+package pagination
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PageLinks are the URLs of the pages next to the current one, relative to the host;
+// links to pages that do not exist, or cannot be told, are empty
+type PageLinks struct {
+	First string `json:"first,omitempty"`
+	Prev  string `json:"prev,omitempty"`
+	Next  string `json:"next,omitempty"`
+	Last  string `json:"last,omitempty"`
+}
+
+// NewPageLinks returns the links of the pages next to the page meta describes, keeping
+// the other parameters of r. Cursor listings link their cursors as cursor; the last
+// page is only linked when the total is exact.
+func NewPageLinks(r *http.Request, meta PaginationResponse) PageLinks {
+	var links PageLinks
+	if r == nil || r.URL == nil || meta.IsDisabled {
+		return links
+	}
+
+	link := func(set func(url.Values)) string {
+		query := r.URL.Query()
+		for _, name := range []string{"cursor", "page", "offset", "page[number]"} {
+			query.Del(name)
+		}
+		set(query)
+		target := url.URL{Path: r.URL.Path, RawPath: r.URL.RawPath, RawQuery: query.Encode()}
+		return target.String()
+	}
+	cursorLink := func(cursor string) string {
+		return link(func(query url.Values) { query.Set("cursor", cursor) })
+	}
+	pageLink := func(page int64) string {
+		return link(func(query url.Values) {
+			switch CurrentConfig().ParamStyle {
+			case ParamStyleOffset:
+				query.Set("offset", strconv.FormatInt((page-1)*int64(meta.PerPage), 10))
+				query.Set("limit", strconv.Itoa(meta.PerPage))
+			case ParamStyleJSONAPI:
+				query.Set("page[number]", strconv.FormatInt(page, 10))
+				query.Set("page[size]", strconv.Itoa(meta.PerPage))
+			default:
+				query.Set("page", strconv.FormatInt(page, 10))
+			}
+		})
+	}
+
+	if meta.NextCursor != "" || meta.PrevCursor != "" {
+		links.First = link(func(url.Values) {})
+		if meta.PrevCursor != "" {
+			links.Prev = cursorLink(meta.PrevCursor)
+		}
+		if meta.NextCursor != "" {
+			links.Next = cursorLink(meta.NextCursor)
+		}
+		return links
+	}
+
+	page := int64(max(meta.Page, 1))
+	known := meta.TotalStatus == ""
+	links.First = pageLink(1)
+	if page > 1 {
+		links.Prev = pageLink(page - 1)
+	}
+	// Estimated totals still tell roughly how many pages follow, but not which is last
+	hasMore := (known || meta.TotalStatus == TotalStatusEstimated) && page < meta.MaxPage
+	if hasMore || (meta.HasMore != nil && *meta.HasMore) {
+		links.Next = pageLink(page + 1)
+	}
+	if known && meta.TotalRelation == "" && meta.MaxPage > 0 {
+		links.Last = pageLink(meta.MaxPage)
+	}
+	return links
+}
+
+// Header formats the links as the value of an RFC 8288 Link header
+func (l PageLinks) Header() string {
+	var values []string
+	for _, link := range []struct{ rel, target string }{
+		{"first", l.First}, {"prev", l.Prev}, {"next", l.Next}, {"last", l.Last},
+	} {
+		if link.target != "" {
+			values = append(values, "<"+link.target+`>; rel="`+link.rel+`"`)
+		}
+	}
+	return strings.Join(values, ", ")
+}
+
+// WithPath returns the metadata with the path of r, the base of its page URLs
+func (p PaginationResponse) WithPath(r *http.Request) PaginationResponse {
+	if r != nil && r.URL != nil {
+		p.Path = r.URL.Path
+	}
+	return p
+}
+
+// WithLinks returns the response with the page links of its pagination metadata
+func (p PaginatedResponse) WithLinks(r *http.Request) PaginatedResponse {
+	if p.Code < 400 {
+		links := NewPageLinks(r, p.Pagination)
+		p.Links = &links
+		p.Pagination = p.Pagination.WithPath(r)
+	}
+	return p
+}
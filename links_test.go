@@ -0,0 +1,57 @@
+package pagination
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPageLinks(t *testing.T) {
+	request := httptest.NewRequest("GET", "/users?page=2&per_page=10&search=jo", nil)
+	links := NewPageLinks(request, CalculatePagination(PaginationRequest{Page: 2, PerPage: 10}, 35))
+
+	assert.Equal(t, PageLinks{
+		First: "/users?page=1&per_page=10&search=jo",
+		Prev:  "/users?page=1&per_page=10&search=jo",
+		Next:  "/users?page=3&per_page=10&search=jo",
+		Last:  "/users?page=4&per_page=10&search=jo",
+	}, links)
+	assert.Equal(t, `</users?page=1&per_page=10&search=jo>; rel="first", </users?page=1&per_page=10&search=jo>; rel="prev", `+
+		`</users?page=3&per_page=10&search=jo>; rel="next", </users?page=4&per_page=10&search=jo>; rel="last"`, links.Header())
+
+	capped := NewPageLinks(request, CalculateCappedPagination(PaginationRequest{Page: 2, PerPage: 10}, 30, 30))
+	assert.NotEmpty(t, capped.Next)
+	assert.Empty(t, capped.Last, "a lower bound does not tell the last page")
+
+	skipped := NewPageLinks(request, CalculateCountModePagination(PaginationRequest{Page: 2, PerPage: 10}, 21, CountSkip))
+	assert.Equal(t, "/users?page=3&per_page=10&search=jo", skipped.Next)
+	assert.Empty(t, skipped.Last)
+
+	estimated := NewPageLinks(request, CalculateCountModePagination(PaginationRequest{Page: 2, PerPage: 10}, 35, CountEstimate))
+	assert.NotEmpty(t, estimated.Next)
+	assert.Empty(t, estimated.Last, "an estimate does not tell the last page")
+}
+
+func TestNewPageLinks_Cursors(t *testing.T) {
+	request := httptest.NewRequest("GET", "/events?cursor=abc&per_page=5", nil)
+	links := NewPageLinks(request, PaginationResponse{PerPage: 5, TotalStatus: TotalStatusUnknown, NextCursor: "def", PrevCursor: "xyz"})
+
+	assert.Equal(t, PageLinks{
+		First: "/events?per_page=5",
+		Prev:  "/events?cursor=xyz&per_page=5",
+		Next:  "/events?cursor=def&per_page=5",
+	}, links)
+}
+
+func TestPaginatedResponse_WithLinks(t *testing.T) {
+	request := httptest.NewRequest("GET", "/offsets?limit=10&offset=10", nil)
+	useTestConfig(t, map[string]string{EnvParamStyle: "offset"})
+
+	response := NewPaginatedResponse(200, "ok", nil, CalculatePagination(PaginationRequest{Page: 2, PerPage: 10}, 25)).WithLinks(request)
+	assert.Equal(t, "/offsets?limit=10&offset=20", response.Links.Next)
+	assert.Equal(t, "/offsets?limit=10&offset=0", response.Links.First)
+
+	failed := NewPaginatedResponse(500, "failed", nil, PaginationResponse{}).WithLinks(request)
+	assert.Nil(t, failed.Links)
+}
@@ -0,0 +1,85 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// IncludeLoader resolves an include for a fetched page; rows is a pointer to the
+// slice of results. Includes without a loader fall back to GORM Preload.
+type IncludeLoader func(ctx context.Context, rows interface{}) error
+
+// IncludeLoaderProvider is implemented by builders resolving includes with custom loaders
+type IncludeLoaderProvider interface {
+	GetIncludeLoaders() map[string]IncludeLoader
+}
+
+// BatchInclude creates an IncludeLoader fetching related data once per page: the keys
+// of every row are collected, deduplicated and passed to fetch, and each row receives
+// the related value of its key. Rows whose key has no related value are left untouched.
+func BatchInclude[T any, K comparable, R any](
+	key func(row T) K,
+	fetch func(ctx context.Context, keys []K) (map[K]R, error),
+	assign func(row *T, related R),
+) IncludeLoader {
+	return func(ctx context.Context, rows interface{}) error {
+		page, ok := rows.(*[]T)
+		if !ok {
+			return fmt.Errorf("include loader expects *[]%T, got %T", *new(T), rows)
+		}
+		if len(*page) == 0 {
+			return nil
+		}
+
+		keys := make([]K, 0, len(*page))
+		seen := make(map[K]bool, len(*page))
+		for _, row := range *page {
+			k := key(row)
+			if !seen[k] {
+				seen[k] = true
+				keys = append(keys, k)
+			}
+		}
+
+		related, err := fetch(ctx, keys)
+		if err != nil {
+			return err
+		}
+
+		for i := range *page {
+			if value, ok := related[key((*page)[i])]; ok {
+				assign(&(*page)[i], value)
+			}
+		}
+		return nil
+	}
+}
+
+// includeLoaders returns the custom loaders of the builder
+func includeLoaders(builder interface{}) map[string]IncludeLoader {
+	if provider, ok := builder.(IncludeLoaderProvider); ok {
+		return provider.GetIncludeLoaders()
+	}
+	return nil
+}
+
+// runIncludeLoaders resolves the includes handled by custom loaders
+func runIncludeLoaders(db *gorm.DB, loaders map[string]IncludeLoader, includes []string, rows interface{}) error {
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	for _, include := range includes {
+		loader, ok := loaders[include]
+		if !ok {
+			continue
+		}
+		if err := loader(ctx, rows); err != nil {
+			return fmt.Errorf("failed to load include %s: %w", include, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package pagination
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestUserProfile struct {
+	ID      uint   `json:"id"`
+	Name    string `json:"name"`
+	Age     int    `json:"age"`
+	Profile string `json:"profile,omitempty" gorm:"-"`
+}
+
+func TestBatchInclude(t *testing.T) {
+	db := setupTestDB()
+
+	var fetched [][]uint
+	loader := BatchInclude(
+		func(row TestUserProfile) uint { return row.ID },
+		func(ctx context.Context, ids []uint) (map[uint]string, error) {
+			fetched = append(fetched, ids)
+			return map[uint]string{1: "admin", 2: "editor"}, nil
+		},
+		func(row *TestUserProfile, profile string) { row.Profile = profile },
+	)
+
+	builder := NewSimpleQueryBuilder("test_users").WithIncludeLoader("Profile", loader)
+	data, _, err := PaginatedQuery[TestUserProfile](db, builder, PaginationRequest{Page: 1, PerPage: 3}, []string{"Profile"})
+	assert.NoError(t, err)
+
+	assert.Equal(t, [][]uint{{1, 2, 3}}, fetched, "related data is fetched once per page")
+	assert.Equal(t, "admin", data[0].Profile)
+	assert.Equal(t, "editor", data[1].Profile)
+	assert.Equal(t, "", data[2].Profile)
+
+	// Loaders only run when the include is requested
+	fetched = nil
+	_, _, err = PaginatedQuery[TestUserProfile](db, builder, PaginationRequest{Page: 1, PerPage: 3}, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, fetched)
+}
+
+func TestBatchInclude_WrongType(t *testing.T) {
+	loader := BatchInclude(
+		func(row TestUserProfile) uint { return row.ID },
+		func(ctx context.Context, ids []uint) (map[uint]string, error) { return nil, nil },
+		func(row *TestUserProfile, profile string) {},
+	)
+
+	assert.Error(t, loader(context.Background(), &[]TestUser{{ID: 1}}))
+}
@@ -0,0 +1,252 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/text/language"
+)
+
+// Locale describes how dates, times and numbers are written for one language
+type Locale struct {
+	// Tag is the BCP 47 language tag, e.g. "en-GB"
+	Tag            string
+	DateLayout     string
+	DateTimeLayout string
+	// DecimalSeparator and GroupSeparator write numbers, e.g. "," and "." for 1.234,5
+	DecimalSeparator string
+	GroupSeparator   string
+	// Location converts times before formatting; nil keeps the offset of each value
+	Location *time.Location
+}
+
+// DefaultLocale is used when a request names no known locale
+const DefaultLocale = "en"
+
+var (
+	localesMu sync.RWMutex
+	locales   = map[string]Locale{
+		"en":    {Tag: "en", DateLayout: "01/02/2006", DateTimeLayout: "01/02/2006 3:04 PM", DecimalSeparator: ".", GroupSeparator: ","},
+		"en-GB": {Tag: "en-GB", DateLayout: "02/01/2006", DateTimeLayout: "02/01/2006 15:04", DecimalSeparator: ".", GroupSeparator: ","},
+		"id":    {Tag: "id", DateLayout: "02/01/2006", DateTimeLayout: "02/01/2006 15.04", DecimalSeparator: ",", GroupSeparator: "."},
+		"de":    {Tag: "de", DateLayout: "02.01.2006", DateTimeLayout: "02.01.2006 15:04", DecimalSeparator: ",", GroupSeparator: "."},
+		"fr":    {Tag: "fr", DateLayout: "02/01/2006", DateTimeLayout: "02/01/2006 15:04", DecimalSeparator: ",", GroupSeparator: " "},
+		"es":    {Tag: "es", DateLayout: "02/01/2006", DateTimeLayout: "02/01/2006 15:04", DecimalSeparator: ",", GroupSeparator: "."},
+		"ja":    {Tag: "ja", DateLayout: "2006/01/02", DateTimeLayout: "2006/01/02 15:04", DecimalSeparator: ".", GroupSeparator: ","},
+	}
+)
+
+// RegisterLocale adds or replaces a locale, keyed by its tag
+func RegisterLocale(locale Locale) {
+	localesMu.Lock()
+	defer localesMu.Unlock()
+	locales[locale.Tag] = locale
+}
+
+// LookupLocale returns the registered locale best matching the given language
+// preferences, e.g. "id-ID" or an Accept-Language value, and DefaultLocale otherwise
+func LookupLocale(preferences ...string) Locale {
+	localesMu.RLock()
+	defer localesMu.RUnlock()
+
+	tags := make([]string, 0, len(locales))
+	for tag := range locales {
+		if tag != DefaultLocale {
+			tags = append(tags, tag)
+		}
+	}
+	sort.Strings(tags)
+	// The first supported tag is the fallback of the matcher
+	tags = append([]string{DefaultLocale}, tags...)
+	supported := make([]language.Tag, len(tags))
+	for i, tag := range tags {
+		supported[i] = language.Make(tag)
+	}
+
+	for _, preference := range preferences {
+		if preference == "" {
+			continue
+		}
+		desired, _, err := language.ParseAcceptLanguage(preference)
+		if err != nil || len(desired) == 0 {
+			continue
+		}
+		_, index, confidence := language.NewMatcher(supported).Match(desired...)
+		if confidence != language.No {
+			return locales[tags[index]]
+		}
+	}
+	return locales[DefaultLocale]
+}
+
+// RequestLocale returns the locale of r from its locale query parameter, falling back to
+// Accept-Language. The tz parameter, an IANA zone such as "Asia/Jakarta", sets Location.
+func RequestLocale(r *http.Request) Locale {
+	if r == nil {
+		return LookupLocale()
+	}
+	query := requestQuery(r)
+	locale := LookupLocale(query.Get("locale"), r.Header.Get("Accept-Language"))
+	if zone := query.Get("tz"); zone != "" {
+		if location, err := time.LoadLocation(zone); err == nil {
+			locale.Location = location
+		}
+	}
+	return locale
+}
+
+// FormatTime writes t with layout after converting it to the locale's location
+func (l Locale) FormatTime(t time.Time, layout string) string {
+	if l.Location != nil {
+		t = t.In(l.Location)
+	}
+	return t.Format(layout)
+}
+
+// FormatNumber writes value with the locale's separators; negative decimals keep the
+// shortest representation of value
+func (l Locale) FormatNumber(value float64, decimals int) string {
+	text := strconv.FormatFloat(value, 'f', decimals, 64)
+	sign := ""
+	if strings.HasPrefix(text, "-") {
+		sign, text = "-", text[1:]
+	}
+	integer, fraction, _ := strings.Cut(text, ".")
+
+	var grouped strings.Builder
+	for i, digit := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			grouped.WriteString(l.GroupSeparator)
+		}
+		grouped.WriteRune(digit)
+	}
+	if fraction != "" {
+		return sign + grouped.String() + l.DecimalSeparator + fraction
+	}
+	return sign + grouped.String()
+}
+
+// FormatFunc formats a JSON value of a response for a locale; values it cannot format
+// are returned unchanged
+type FormatFunc func(value interface{}, locale Locale) interface{}
+
+// OutputFormatter maps JSON field paths to formatting functions, like MaskingPolicy
+// Paths use the JSON names of the response, nested with dots ("event.start_date").
+type OutputFormatter struct {
+	Rules map[string]FormatFunc
+}
+
+// NewOutputFormatter creates an empty OutputFormatter
+func NewOutputFormatter() *OutputFormatter {
+	return &OutputFormatter{Rules: make(map[string]FormatFunc)}
+}
+
+// Format registers a formatting function for a field path
+func (f *OutputFormatter) Format(path string, format FormatFunc) *OutputFormatter {
+	if f.Rules == nil {
+		f.Rules = make(map[string]FormatFunc)
+	}
+	f.Rules[path] = format
+	return f
+}
+
+// Apply returns a copy of data formatted for locale, represented with generic JSON values
+func (f *OutputFormatter) Apply(data interface{}, locale Locale) (interface{}, error) {
+	if f == nil || len(f.Rules) == 0 || data == nil {
+		return data, nil
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to format data: %w", err)
+	}
+
+	// Numbers are kept as written so large integers survive until formatted
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("failed to format data: %w", err)
+	}
+
+	for path, format := range f.Rules {
+		generic = transformPath(generic, strings.Split(path, "."), func(value interface{}) interface{} {
+			return format(value, locale)
+		})
+	}
+	return generic, nil
+}
+
+// FormatPaginatedResponse formats the data of a response for the locale of r
+func FormatPaginatedResponse(response PaginatedResponse, formatter *OutputFormatter, r *http.Request) PaginatedResponse {
+	formatted, err := formatter.Apply(response.Data, RequestLocale(r))
+	if err != nil {
+		return NewPaginatedResponse(http.StatusInternalServerError, "Internal Server Error: "+err.Error(), nil, PaginationResponse{}).withRequestID(r)
+	}
+	response.Data = formatted
+	return response
+}
+
+// formattedTime parses the RFC 3339 timestamps and plain dates of JSON values
+func formattedTime(value interface{}) (time.Time, bool) {
+	text, ok := value.(string)
+	if !ok {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339Nano, time.DateOnly} {
+		if t, err := time.Parse(layout, text); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// FormatDate writes timestamps and dates with the locale's DateLayout
+func FormatDate() FormatFunc {
+	return func(value interface{}, locale Locale) interface{} {
+		t, ok := formattedTime(value)
+		if !ok {
+			return value
+		}
+		return locale.FormatTime(t, locale.DateLayout)
+	}
+}
+
+// FormatDateTime writes timestamps with the locale's DateTimeLayout
+func FormatDateTime() FormatFunc {
+	return func(value interface{}, locale Locale) interface{} {
+		t, ok := formattedTime(value)
+		if !ok {
+			return value
+		}
+		return locale.FormatTime(t, locale.DateTimeLayout)
+	}
+}
+
+// FormatNumber writes numbers with the locale's separators and the given decimals,
+// negative decimals keeping those of the value
+func FormatNumber(decimals int) FormatFunc {
+	return func(value interface{}, locale Locale) interface{} {
+		var text string
+		switch typed := value.(type) {
+		case json.Number:
+			text = typed.String()
+		case string:
+			text = typed
+		default:
+			return value
+		}
+		number, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return value
+		}
+		return locale.FormatNumber(number, decimals)
+	}
+}
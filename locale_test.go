@@ -0,0 +1,67 @@
+package pagination
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testFormattedEvent struct {
+	Name      string    `json:"name"`
+	StartDate time.Time `json:"start_date"`
+	Prize     float64   `json:"prize"`
+	Seats     int64     `json:"seats"`
+}
+
+func TestLookupLocale(t *testing.T) {
+	assert.Equal(t, "id", LookupLocale("id-ID").Tag)
+	assert.Equal(t, "en-GB", LookupLocale("fr-CH;q=0.5, en-GB;q=0.9").Tag)
+	assert.Equal(t, "de", LookupLocale("", "de-AT").Tag)
+	assert.Equal(t, DefaultLocale, LookupLocale("xx").Tag)
+	assert.Equal(t, DefaultLocale, LookupLocale().Tag)
+}
+
+func TestLocale_FormatNumber(t *testing.T) {
+	assert.Equal(t, "1,234,567.50", LookupLocale("en").FormatNumber(1234567.5, 2))
+	assert.Equal(t, "-1.234,5", LookupLocale("id").FormatNumber(-1234.5, -1))
+	assert.Equal(t, "999", LookupLocale("de").FormatNumber(999, 0))
+}
+
+func TestOutputFormatter_Apply(t *testing.T) {
+	events := []testFormattedEvent{
+		{Name: "Open", StartDate: time.Date(2024, 3, 9, 14, 30, 0, 0, time.UTC), Prize: 1500000.5, Seats: 12000},
+	}
+	formatter := NewOutputFormatter().
+		Format("start_date", FormatDateTime()).
+		Format("prize", FormatNumber(2)).
+		Format("seats", FormatNumber(0)).
+		Format("name", FormatDate())
+
+	request := httptest.NewRequest("GET", "/events?tz=Asia/Jakarta", nil)
+	request.Header.Set("Accept-Language", "id-ID,id;q=0.9")
+	formatted, err := formatter.Apply(events, RequestLocale(request))
+	assert.NoError(t, err)
+
+	first := formatted.([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "09/03/2024 21.30", first["start_date"])
+	assert.Equal(t, "1.500.000,50", first["prize"])
+	assert.Equal(t, "12.000", first["seats"])
+	assert.Equal(t, "Open", first["name"], "values that are not dates stay as they are")
+}
+
+func TestFormatPaginatedResponse(t *testing.T) {
+	response := NewPaginatedResponse(200, "ok", []testFormattedEvent{
+		{StartDate: time.Date(2024, 3, 9, 14, 30, 0, 0, time.UTC)},
+	}, PaginationResponse{})
+	formatter := NewOutputFormatter().Format("start_date", FormatDate())
+
+	request := httptest.NewRequest("GET", "/events?locale=en-US", nil)
+	request.Header.Set("Accept-Language", "de")
+	formatted := FormatPaginatedResponse(response, formatter, request)
+	assert.Equal(t, "03/09/2024", formatted.Data.([]interface{})[0].(map[string]interface{})["start_date"])
+
+	unformatted := FormatPaginatedResponse(response, nil, request)
+	assert.Equal(t, response.Data, unformatted.Data)
+}
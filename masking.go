@@ -0,0 +1,140 @@
+package pagination
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// MaskFunc transforms a sensitive value before it leaves the API
+type MaskFunc func(value string) string
+
+// MaskingPolicy maps JSON field paths to masking functions for one audience
+// Paths use the JSON names of the response, nested with dots ("province.code");
+// arrays are traversed transparently.
+type MaskingPolicy struct {
+	Rules map[string]MaskFunc
+}
+
+// NewMaskingPolicy creates an empty MaskingPolicy
+func NewMaskingPolicy() *MaskingPolicy {
+	return &MaskingPolicy{Rules: make(map[string]MaskFunc)}
+}
+
+// Mask registers a masking function for a field path
+func (p *MaskingPolicy) Mask(path string, mask MaskFunc) *MaskingPolicy {
+	if p.Rules == nil {
+		p.Rules = make(map[string]MaskFunc)
+	}
+	p.Rules[path] = mask
+	return p
+}
+
+// Apply returns a masked copy of data, represented with generic JSON values
+func (p *MaskingPolicy) Apply(data interface{}) (interface{}, error) {
+	if p == nil || len(p.Rules) == 0 || data == nil {
+		return data, nil
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mask data: %w", err)
+	}
+
+	var generic interface{}
+	if err := json.Unmarshal(encoded, &generic); err != nil {
+		return nil, fmt.Errorf("failed to mask data: %w", err)
+	}
+
+	for path, mask := range p.Rules {
+		generic = transformPath(generic, strings.Split(path, "."), func(value interface{}) interface{} {
+			if s, ok := value.(string); ok {
+				return mask(s)
+			}
+			return mask(fmt.Sprint(value))
+		})
+	}
+	return generic, nil
+}
+
+// transformPath replaces the non-null scalar found at path inside a generic JSON value
+// with the result of transform, traversing arrays transparently
+func transformPath(value interface{}, path []string, transform func(interface{}) interface{}) interface{} {
+	switch typed := value.(type) {
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = transformPath(item, path, transform)
+		}
+		return typed
+	case map[string]interface{}:
+		if len(path) == 0 {
+			return typed
+		}
+		child, ok := typed[path[0]]
+		if !ok {
+			return typed
+		}
+		typed[path[0]] = transformPath(child, path[1:], transform)
+		return typed
+	case nil:
+		return nil
+	default:
+		if len(path) > 0 {
+			return value
+		}
+		return transform(typed)
+	}
+}
+
+// MaskPaginatedResponse applies the policy to the data of a response
+func MaskPaginatedResponse(response PaginatedResponse, policy *MaskingPolicy) PaginatedResponse {
+	masked, err := policy.Apply(response.Data)
+	if err != nil {
+		failed := NewPaginatedResponse(http.StatusInternalServerError, "Internal Server Error: "+err.Error(), nil, PaginationResponse{})
+		failed.RequestID = response.RequestID
+		return failed
+	}
+	response.Data = masked
+	return response
+}
+
+// MaskKeepLast keeps the last n characters and replaces the rest with '*'
+func MaskKeepLast(n int) MaskFunc {
+	return func(value string) string {
+		runes := []rune(value)
+		if len(runes) <= n {
+			return value
+		}
+		return strings.Repeat("*", len(runes)-n) + string(runes[len(runes)-n:])
+	}
+}
+
+// MaskEmail keeps the first character of the local part and the domain
+func MaskEmail() MaskFunc {
+	return func(value string) string {
+		at := strings.LastIndex(value, "@")
+		if at <= 0 {
+			return MaskRedact("***")(value)
+		}
+		runes := []rune(value[:at])
+		return string(runes[0]) + strings.Repeat("*", len(runes)-1) + value[at:]
+	}
+}
+
+// MaskHash replaces the value with a salted SHA-256 digest, keeping values comparable
+func MaskHash(salt string) MaskFunc {
+	return func(value string) string {
+		sum := sha256.Sum256([]byte(salt + value))
+		return hex.EncodeToString(sum[:])
+	}
+}
+
+// MaskRedact replaces the value entirely
+func MaskRedact(replacement string) MaskFunc {
+	return func(string) string {
+		return replacement
+	}
+}
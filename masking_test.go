@@ -0,0 +1,50 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMaskedProfile struct {
+	Phone string `json:"phone"`
+}
+
+type testMaskedUser struct {
+	Name     string              `json:"name"`
+	Email    string              `json:"email"`
+	Profiles []testMaskedProfile `json:"profiles"`
+}
+
+func TestMaskingPolicy_Apply(t *testing.T) {
+	users := []testMaskedUser{
+		{Name: "John", Email: "john@example.com", Profiles: []testMaskedProfile{{Phone: "081234567890"}}},
+	}
+
+	policy := NewMaskingPolicy().
+		Mask("email", MaskEmail()).
+		Mask("profiles.phone", MaskKeepLast(4)).
+		Mask("missing.field", MaskRedact("x"))
+
+	masked, err := policy.Apply(users)
+	assert.NoError(t, err)
+
+	first := masked.([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "John", first["name"])
+	assert.Equal(t, "j***@example.com", first["email"])
+	assert.Equal(t, "********7890", first["profiles"].([]interface{})[0].(map[string]interface{})["phone"])
+
+	// The original data is left untouched
+	assert.Equal(t, "john@example.com", users[0].Email)
+}
+
+func TestMaskPaginatedResponse(t *testing.T) {
+	response := NewPaginatedResponse(200, "ok", []TestUser{{ID: 1, Email: "jane@example.com"}}, PaginationResponse{})
+
+	masked := MaskPaginatedResponse(response, NewMaskingPolicy().Mask("email", MaskHash("salt")))
+	email := masked.Data.([]interface{})[0].(map[string]interface{})["email"].(string)
+	assert.Len(t, email, 64)
+
+	unmasked := MaskPaginatedResponse(response, nil)
+	assert.Equal(t, response.Data, unmasked.Data)
+}
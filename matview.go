@@ -0,0 +1,152 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// MaterializedViewOptions configures how a materialized view is refreshed
+type MaterializedViewOptions struct {
+	// LastRefresh reads when the view was last refreshed, e.g. from a refresh log table;
+	// by default the time of the last Refresh through this package is reported
+	LastRefresh func(db *gorm.DB) (time.Time, error)
+	// RefreshFunc refreshes the view; by default PostgreSQL runs REFRESH MATERIALIZED VIEW
+	RefreshFunc func(db *gorm.DB) error
+	// Concurrently refreshes without blocking readers; the view needs a unique index
+	Concurrently bool
+	// MaxStaleness starts a background refresh when a read finds older data; zero never refreshes
+	MaxStaleness time.Duration
+}
+
+// MaterializedView tracks the freshness of a materialized view used for pagination
+type MaterializedView struct {
+	name    string
+	options MaterializedViewOptions
+
+	mu          sync.Mutex
+	refreshedAt time.Time
+	refreshing  bool
+	refreshErr  error
+}
+
+// NewMaterializedView creates the freshness tracker of a view
+func NewMaterializedView(name string, options MaterializedViewOptions) (*MaterializedView, error) {
+	if !isValidSortField(name) {
+		return nil, fmt.Errorf("invalid materialized view name %q", name)
+	}
+	return &MaterializedView{name: name, options: options}, nil
+}
+
+// Name returns the name of the view
+func (v *MaterializedView) Name() string {
+	return v.name
+}
+
+// Refresh refreshes the view and records the refresh time
+func (v *MaterializedView) Refresh(db *gorm.DB) error {
+	var err error
+	if v.options.RefreshFunc != nil {
+		err = v.options.RefreshFunc(db)
+	} else if DetectDialect(db) == PostgreSQL {
+		statement := "REFRESH MATERIALIZED VIEW "
+		if v.options.Concurrently {
+			statement += "CONCURRENTLY "
+		}
+		err = db.Exec(statement + v.name).Error
+	} else {
+		err = fmt.Errorf("materialized view %s needs a RefreshFunc on %s", v.name, DetectDialect(db))
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.refreshErr = err
+	if err != nil {
+		return fmt.Errorf("failed to refresh %s: %w", v.name, err)
+	}
+	v.refreshedAt = time.Now()
+	return nil
+}
+
+// DataAsOf returns when the data of the view was produced; zero when unknown
+func (v *MaterializedView) DataAsOf(db *gorm.DB) (time.Time, error) {
+	if v.options.LastRefresh != nil {
+		asOf, err := v.options.LastRefresh(db)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to read last refresh of %s: %w", v.name, err)
+		}
+		return asOf, nil
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.refreshedAt, nil
+}
+
+// LastRefreshError returns the error of the most recent refresh, including background ones
+func (v *MaterializedView) LastRefreshError() error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.refreshErr
+}
+
+// refreshIfStale starts one background refresh when the data is older than MaxStaleness;
+// an unknown refresh time counts as stale
+func (v *MaterializedView) refreshIfStale(db *gorm.DB, asOf time.Time) {
+	if v.options.MaxStaleness <= 0 || (!asOf.IsZero() && time.Since(asOf) <= v.options.MaxStaleness) {
+		return
+	}
+
+	v.mu.Lock()
+	if v.refreshing {
+		v.mu.Unlock()
+		return
+	}
+	v.refreshing = true
+	v.mu.Unlock()
+
+	// The request context ends with the response, the refresh must outlive it
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	background := db.WithContext(context.WithoutCancel(ctx))
+
+	go func() {
+		_ = v.Refresh(background)
+
+		v.mu.Lock()
+		v.refreshing = false
+		v.mu.Unlock()
+	}()
+}
+
+// PaginateMaterializedView paginates a builder reading from the view and reports the
+// refresh time of the view in data_as_of, refreshing it in the background when stale
+func PaginateMaterializedView[T any](
+	db *gorm.DB,
+	view *MaterializedView,
+	builder QueryBuilder,
+	pagination PaginationRequest,
+	includes []string,
+) ([]T, PaginationResponse, error) {
+	asOf, err := view.DataAsOf(db)
+	if err != nil {
+		return nil, PaginationResponse{}, err
+	}
+	view.refreshIfStale(db, asOf)
+
+	data, total, err := PaginatedQueryWithOptions[T](db, builder, pagination, includes, PaginatedQueryOptions{Dialect: DetectDialect(db)})
+	if err != nil {
+		return nil, PaginationResponse{}, err
+	}
+
+	response := CalculatePagination(pagination, total)
+	if !asOf.IsZero() {
+		response.DataAsOf = &asOf
+	}
+	return data, response, nil
+}
@@ -0,0 +1,95 @@
+package pagination
+
+import (
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// setupUserStatsView emulates a materialized view with a table rebuilt on refresh
+func setupUserStatsView(t *testing.T, options MaterializedViewOptions) (*gorm.DB, *MaterializedView, *int32) {
+	db := setupTestDB()
+	refreshes := new(int32)
+
+	options.RefreshFunc = func(db *gorm.DB) error {
+		atomic.AddInt32(refreshes, 1)
+		if err := db.Exec("DROP TABLE IF EXISTS user_stats").Error; err != nil {
+			return err
+		}
+		return db.Exec("CREATE TABLE user_stats AS SELECT id, name, age FROM test_users").Error
+	}
+	view, err := NewMaterializedView("user_stats", options)
+	assert.NoError(t, err)
+	assert.NoError(t, view.Refresh(db))
+	return db, view, refreshes
+}
+
+func TestPaginateMaterializedView_DataAsOf(t *testing.T) {
+	db, view, refreshes := setupUserStatsView(t, MaterializedViewOptions{})
+	before := time.Now()
+
+	db.Create(&TestUser{Name: "Dave", Email: "dave@example.com", Age: 40})
+
+	users, meta, err := PaginateMaterializedView[TestUser](db, view, NewSimpleQueryBuilder("user_stats"), PaginationRequest{Page: 1, PerPage: 10}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, users, 5, "the view serves the data of its last refresh")
+	assert.NotNil(t, meta.DataAsOf)
+	assert.False(t, meta.DataAsOf.After(before))
+
+	encoded, _ := json.Marshal(meta)
+	assert.Contains(t, string(encoded), `"data_as_of"`)
+	assert.Equal(t, int32(1), atomic.LoadInt32(refreshes), "no staleness threshold, no refresh")
+}
+
+func TestPaginateMaterializedView_RefreshesWhenStale(t *testing.T) {
+	db, view, refreshes := setupUserStatsView(t, MaterializedViewOptions{MaxStaleness: time.Millisecond})
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+
+	db.Create(&TestUser{Name: "Dave", Email: "dave@example.com", Age: 40})
+	time.Sleep(5 * time.Millisecond)
+
+	_, _, err := PaginateMaterializedView[TestUser](db, view, NewSimpleQueryBuilder("user_stats"), PaginationRequest{Page: 1, PerPage: 10}, nil)
+	assert.NoError(t, err)
+
+	assert.Eventually(t, func() bool { return atomic.LoadInt32(refreshes) == 2 }, time.Second, 5*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		var count int64
+		db.Table("user_stats").Count(&count)
+		return count == 6
+	}, time.Second, 5*time.Millisecond)
+	assert.NoError(t, view.LastRefreshError())
+}
+
+func TestMaterializedView_LastRefreshHook(t *testing.T) {
+	refreshed := time.Date(2024, 5, 1, 6, 0, 0, 0, time.UTC)
+	view, err := NewMaterializedView("user_stats", MaterializedViewOptions{
+		LastRefresh: func(*gorm.DB) (time.Time, error) { return refreshed, nil },
+	})
+	assert.NoError(t, err)
+
+	asOf, err := view.DataAsOf(setupTestDB())
+	assert.NoError(t, err)
+	assert.Equal(t, refreshed, asOf)
+
+	failing, _ := NewMaterializedView("user_stats", MaterializedViewOptions{
+		LastRefresh: func(*gorm.DB) (time.Time, error) { return time.Time{}, errors.New("no log") },
+	})
+	_, err = failing.DataAsOf(setupTestDB())
+	assert.Error(t, err)
+}
+
+func TestMaterializedView_DefaultRefreshNeedsPostgres(t *testing.T) {
+	view, err := NewMaterializedView("user_stats", MaterializedViewOptions{})
+	assert.NoError(t, err)
+	assert.Error(t, view.Refresh(setupTestDB()))
+	assert.Error(t, view.LastRefreshError())
+
+	_, err = NewMaterializedView("user_stats; DROP TABLE users", MaterializedViewOptions{})
+	assert.Error(t, err)
+}
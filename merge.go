@@ -0,0 +1,312 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// MergeSource is one pre-sorted source taking part in a k-way merge
+// Fetch returns items sorted by the same ordering as the merge, starting at cursor,
+// together with the cursor of the following page ("" when the source is exhausted).
+// Sources are fetched concurrently, so Fetch must be safe for concurrent use.
+type MergeSource[T any] struct {
+	Name  string
+	Fetch func(ctx context.Context, cursor string, limit int) ([]T, string, error)
+}
+
+// MergeResult holds one globally sorted page produced from several sources
+type MergeResult[T any] struct {
+	Data       []T        `json:"data"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+	HasMore    bool       `json:"has_more"`
+	Meta       *MergeMeta `json:"meta,omitempty"`
+}
+
+// Source statuses reported in MergeMeta
+const (
+	SourceStatusOK      = "ok"
+	SourceStatusTimeout = "timeout"
+	SourceStatusError   = "error"
+)
+
+// MergeMeta reports how each source fared when partial results are allowed
+type MergeMeta struct {
+	// Partial is set when a source failed and its items are missing from the page
+	Partial bool                    `json:"partial"`
+	Sources map[string]SourceStatus `json:"sources"`
+}
+
+// SourceStatus is the outcome of one source for a merged page
+type SourceStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// MergeOptions configures MergePaginateWithOptions
+type MergeOptions[T any] struct {
+	// Key identifies duplicates across sources; items already emitted are skipped
+	Key func(T) string
+	// AllowPartial returns the items of the sources that answered instead of failing
+	// the page when some do not; failed sources resume where they stopped next page
+	AllowPartial bool
+	// SourceTimeout bounds every fetch of a source, zero leaves it to ctx
+	SourceTimeout time.Duration
+	// Scope binds cursors to the query parameters, see CursorScope; the names of
+	// the sources are always part of the scope
+	Scope string
+}
+
+// mergePosition records where a source stopped: the page it was read from and
+// how many items of that page were already emitted
+type mergePosition struct {
+	Cursor string `json:"c,omitempty"`
+	Skip   int    `json:"s,omitempty"`
+	Done   bool   `json:"d,omitempty"`
+}
+
+type mergeCursor struct {
+	Positions map[string]mergePosition `json:"p"`
+}
+
+type mergeState[T any] struct {
+	source     MergeSource[T]
+	pageCursor string
+	buffer     []T
+	index      int
+	next       string
+	done       bool
+
+	options *MergeOptions[T]
+	// failed is the error of a source left out of a partial page, resume where it stopped
+	failed error
+	resume mergePosition
+}
+
+func (s *mergeState[T]) fetch(ctx context.Context, cursor string, limit int) error {
+	if s.options.SourceTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.options.SourceTimeout)
+		defer cancel()
+	}
+
+	items, next, err := s.source.Fetch(ctx, cursor, limit)
+	if err != nil {
+		err = fmt.Errorf("failed to fetch source %s: %w", s.source.Name, err)
+		if s.options.AllowPartial {
+			s.failed = err
+			s.resume = mergePosition{Cursor: cursor}
+			return nil
+		}
+		return err
+	}
+	s.pageCursor = cursor
+	s.buffer = items
+	s.index = 0
+	s.next = next
+	return nil
+}
+
+// head returns the next item of the source, fetching the following page when needed
+func (s *mergeState[T]) head(ctx context.Context, limit int) (T, bool, error) {
+	var zero T
+	for !s.done && s.failed == nil && s.index >= len(s.buffer) {
+		if s.next == "" {
+			s.done = true
+			break
+		}
+		if err := s.fetch(ctx, s.next, limit); err != nil {
+			return zero, false, err
+		}
+	}
+	if s.done || s.failed != nil {
+		return zero, false, nil
+	}
+	return s.buffer[s.index], true, nil
+}
+
+func (s *mergeState[T]) position() mergePosition {
+	switch {
+	case s.failed != nil:
+		return s.resume
+	case s.index < len(s.buffer):
+		return mergePosition{Cursor: s.pageCursor, Skip: s.index}
+	case s.next != "":
+		return mergePosition{Cursor: s.next}
+	default:
+		return mergePosition{Done: true}
+	}
+}
+
+// MergePaginate produces a globally sorted page from several pre-sorted sources
+// less defines the shared ordering; ties are broken by source order. The returned
+// NextCursor encodes the position of every source.
+func MergePaginate[T any](
+	ctx context.Context,
+	sources []MergeSource[T],
+	less func(a, b T) bool,
+	cursor string,
+	limit int,
+) (MergeResult[T], error) {
+	return MergePaginateWithOptions(ctx, sources, less, cursor, limit, MergeOptions[T]{})
+}
+
+// MergePaginateWithOptions is MergePaginate with deduplication and partial results.
+// With AllowPartial the result carries the status of every source in Meta; a page
+// missing a failed source can place its items out of order once it answers again.
+func MergePaginateWithOptions[T any](
+	ctx context.Context,
+	sources []MergeSource[T],
+	less func(a, b T) bool,
+	cursor string,
+	limit int,
+	options MergeOptions[T],
+) (MergeResult[T], error) {
+	key := options.Key
+	if limit <= 0 {
+		limit = 10
+	}
+
+	names := make([]string, len(sources))
+	for i, source := range sources {
+		names[i] = mergeSourceKey(source, i)
+	}
+	scope := CursorScope("merge", names, options.Scope)
+
+	decoded := mergeCursor{Positions: map[string]mergePosition{}}
+	if cursor != "" {
+		if err := decodeScopedToken(cursor, scope, &decoded); err != nil {
+			return MergeResult[T]{}, err
+		}
+	}
+
+	// Fan out the first fetch of every source concurrently
+	states := make([]*mergeState[T], len(sources))
+	errs := make([]error, len(sources))
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		state := &mergeState[T]{source: source, options: &options}
+		states[i] = state
+		position := decoded.Positions[mergeSourceKey(source, i)]
+
+		if position.Done {
+			state.done = true
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = state.fetch(ctx, position.Cursor, limit+position.Skip)
+			state.index = position.Skip
+			state.resume = position
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return MergeResult[T]{}, err
+		}
+	}
+
+	seen := make(map[string]bool)
+	// skipEmitted advances a source past items whose key was already emitted
+	skipEmitted := func(state *mergeState[T]) error {
+		for key != nil {
+			item, ok, err := state.head(ctx, limit)
+			if err != nil || !ok || !seen[key(item)] {
+				return err
+			}
+			state.index++
+		}
+		return nil
+	}
+
+	result := make([]T, 0, limit)
+	for len(result) < limit {
+		best := -1
+		var bestItem T
+
+		for i, state := range states {
+			if err := skipEmitted(state); err != nil {
+				return MergeResult[T]{}, err
+			}
+
+			item, ok, err := state.head(ctx, limit)
+			if err != nil {
+				return MergeResult[T]{}, err
+			}
+			if ok && (best == -1 || less(item, bestItem)) {
+				best = i
+				bestItem = item
+			}
+		}
+
+		if best == -1 {
+			break
+		}
+
+		result = append(result, bestItem)
+		states[best].index++
+		if key != nil {
+			seen[key(bestItem)] = true
+		}
+	}
+
+	next := mergeCursor{Positions: make(map[string]mergePosition, len(states))}
+	hasMore := false
+	for i, state := range states {
+		// Duplicates of items on this page must not leak onto the next one
+		if err := skipEmitted(state); err != nil {
+			return MergeResult[T]{}, err
+		}
+
+		if _, ok, err := state.head(ctx, limit); err != nil {
+			return MergeResult[T]{}, err
+		} else if ok || state.failed != nil {
+			hasMore = true
+		}
+		next.Positions[mergeSourceKey(state.source, i)] = state.position()
+	}
+
+	response := MergeResult[T]{Data: result, HasMore: hasMore}
+	if options.AllowPartial {
+		response.Meta = mergeMeta(states)
+	}
+	if hasMore {
+		token, err := encodeScopedToken(next, scope)
+		if err != nil {
+			return MergeResult[T]{}, err
+		}
+		response.NextCursor = token
+	}
+
+	return response, nil
+}
+
+func mergeSourceKey[T any](source MergeSource[T], index int) string {
+	if source.Name != "" {
+		return source.Name
+	}
+	return fmt.Sprintf("%d", index)
+}
+
+// mergeMeta reports the status of every source of a merged page
+func mergeMeta[T any](states []*mergeState[T]) *MergeMeta {
+	meta := &MergeMeta{Sources: make(map[string]SourceStatus, len(states))}
+	for i, state := range states {
+		status := SourceStatus{Status: SourceStatusOK}
+		if state.failed != nil {
+			meta.Partial = true
+			status = SourceStatus{Status: SourceStatusError, Error: state.failed.Error()}
+			if errors.Is(state.failed, context.DeadlineExceeded) {
+				status.Status = SourceStatusTimeout
+			}
+		}
+		meta.Sources[mergeSourceKey(state.source, i)] = status
+	}
+	return meta
+}
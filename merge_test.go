@@ -0,0 +1,112 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sliceMergeSource serves a sorted slice in pages of two using offset cursors
+func sliceMergeSource(name string, items []int) MergeSource[int] {
+	return MergeSource[int]{
+		Name: name,
+		Fetch: func(ctx context.Context, cursor string, limit int) ([]int, string, error) {
+			offset, _ := strconv.Atoi(cursor)
+			end := offset + 2
+			if end > len(items) {
+				end = len(items)
+			}
+			next := ""
+			if end < len(items) {
+				next = strconv.Itoa(end)
+			}
+			return items[offset:end], next, nil
+		},
+	}
+}
+
+func TestMergePaginate(t *testing.T) {
+	sources := []MergeSource[int]{
+		sliceMergeSource("a", []int{1, 4, 7, 10}),
+		sliceMergeSource("b", []int{2, 5, 8}),
+		sliceMergeSource("c", []int{3, 6, 9, 11, 12}),
+	}
+	less := func(a, b int) bool { return a < b }
+
+	var all []int
+	cursor := ""
+	for {
+		page, err := MergePaginate(context.Background(), sources, less, cursor, 5)
+		assert.NoError(t, err)
+		all = append(all, page.Data...)
+		if !page.HasMore {
+			assert.Empty(t, page.NextCursor)
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}, all)
+}
+
+func TestMergePaginate_InvalidCursor(t *testing.T) {
+	_, err := MergePaginate(context.Background(), []MergeSource[int]{}, func(a, b int) bool { return a < b }, "%%%", 5)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
+func TestMergePaginateWithOptions_Partial(t *testing.T) {
+	healthy := sliceMergeSource("a", []int{1, 3, 5, 7})
+	slow := MergeSource[int]{Name: "slow", Fetch: func(ctx context.Context, cursor string, limit int) ([]int, string, error) {
+		<-ctx.Done()
+		return nil, "", ctx.Err()
+	}}
+	broken := MergeSource[int]{Name: "broken", Fetch: func(ctx context.Context, cursor string, limit int) ([]int, string, error) {
+		return nil, "", errors.New("503 from upstream")
+	}}
+	less := func(a, b int) bool { return a < b }
+
+	_, err := MergePaginate(context.Background(), []MergeSource[int]{healthy, broken}, less, "", 3)
+	assert.ErrorContains(t, err, "failed to fetch source broken")
+
+	options := MergeOptions[int]{AllowPartial: true, SourceTimeout: 20 * time.Millisecond}
+	page, err := MergePaginateWithOptions(context.Background(), []MergeSource[int]{healthy, slow, broken}, less, "", 3, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 3, 5}, page.Data)
+	assert.True(t, page.HasMore)
+	assert.True(t, page.Meta.Partial)
+	assert.Equal(t, SourceStatus{Status: SourceStatusOK}, page.Meta.Sources["a"])
+	assert.Equal(t, SourceStatusTimeout, page.Meta.Sources["slow"].Status)
+	assert.Equal(t, SourceStatusError, page.Meta.Sources["broken"].Status)
+	assert.Contains(t, page.Meta.Sources["broken"].Error, "503 from upstream")
+}
+
+func TestMergePaginateWithOptions_FailedSourceResumes(t *testing.T) {
+	down := true
+	flaky := sliceMergeSource("b", []int{2, 4, 6})
+	fetch := flaky.Fetch
+	flaky.Fetch = func(ctx context.Context, cursor string, limit int) ([]int, string, error) {
+		if down && cursor != "" {
+			return nil, "", errors.New("connection reset")
+		}
+		return fetch(ctx, cursor, limit)
+	}
+	sources := []MergeSource[int]{sliceMergeSource("a", []int{1, 3, 5, 7}), flaky}
+	less := func(a, b int) bool { return a < b }
+	options := MergeOptions[int]{AllowPartial: true}
+
+	page, err := MergePaginateWithOptions(context.Background(), sources, less, "", 4, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4}, page.Data, "b fails fetching its second page")
+	assert.True(t, page.Meta.Partial)
+
+	down = false
+	page, err = MergePaginateWithOptions(context.Background(), sources, less, page.NextCursor, 4, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{5, 6, 7}, page.Data, "b resumes where it failed")
+	assert.False(t, page.Meta.Partial)
+	assert.False(t, page.HasMore)
+}
@@ -0,0 +1,41 @@
+package pagination
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// ParseMultiValue returns every value of param in query, accepting repeated parameters,
+// the array syntax param[] and comma-separated lists, so ?province_id=1,2,3 and
+// ?province_id[]=1&province_id[]=2,3 both give each id. Values are trimmed and empty
+// ones dropped.
+func ParseMultiValue(query url.Values, param string) []string {
+	values := append(append([]string{}, query[param]...), query[param+"[]"]...)
+	return splitMultiValue(values)
+}
+
+// splitMultiValue splits comma-separated values, dropping empty ones
+func splitMultiValue(values []string) []string {
+	var split []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				split = append(split, part)
+			}
+		}
+	}
+	return split
+}
+
+// multiValueOperator reports whether the filter tag of field compares it with a list,
+// as `filter:"op=in"` does, so its parameter may be comma-separated
+func multiValueOperator(field reflect.StructField) bool {
+	for _, option := range strings.Split(field.Tag.Get("filter"), ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		if key == "op" && (value == "in" || value == "not_in") {
+			return true
+		}
+	}
+	return false
+}
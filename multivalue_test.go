@@ -0,0 +1,65 @@
+package pagination
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type multiValueUserFilter struct {
+	BaseFilter
+	Ages   []int    `form:"age" filter:"op=in"`
+	Names  []string `form:"name"`
+	Emails string   `form:"email" filter:"op=not_in"`
+}
+
+func (f *multiValueUserFilter) GetTableName() string      { return "test_users" }
+func (f *multiValueUserFilter) GetDefaultSort() string    { return "id asc" }
+func (f *multiValueUserFilter) GetSearchFields() []string { return []string{"name"} }
+
+func TestParseMultiValue(t *testing.T) {
+	query, _ := url.ParseQuery("province_id=1,2&province_id=3&province_id[]=4,%205&province_id[]=&other=6")
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, ParseMultiValue(query, "province_id"))
+	assert.Empty(t, ParseMultiValue(query, "missing"))
+}
+
+func TestPaginateRequest_MultiValue(t *testing.T) {
+	db := setupTestDB()
+
+	tests := []struct {
+		query string
+		names []string
+	}{
+		{"age=25,35,40", []string{"John Doe", "Bob Johnson"}},
+		{"age[]=28&age[]=30,32", []string{"Jane Smith", "Alice Brown", "Charlie Wilson"}},
+		{"name[]=John+Doe&name[]=Alice+Brown", []string{"John Doe", "Alice Brown"}},
+		{"name=Bob+Johnson&name[]=Jane+Smith", []string{"Jane Smith", "Bob Johnson"}},
+		{"email=john@example.com,jane@example.com&age=25,30,35", []string{"Bob Johnson"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			users, _, err := PaginateRequest[TestUser](db, httptest.NewRequest("GET", "/users?"+tt.query, nil), &multiValueUserFilter{})
+			assert.NoError(t, err)
+
+			var names []string
+			for _, user := range users {
+				names = append(names, user.Name)
+			}
+			assert.Equal(t, tt.names, names)
+		})
+	}
+
+	response := PaginatedAPIResponseRequest[TestUser](db, httptest.NewRequest("GET", "/users?age=25,old", nil), &multiValueUserFilter{}, "ok")
+	assert.Equal(t, 422, response.Code)
+}
+
+func TestParseFilterSet_ArraySyntax(t *testing.T) {
+	query, _ := url.ParseQuery("status[]=active&status[]=pending,archived")
+	set, err := ParseFilterSet(query, map[string]string{"status": "state"})
+	assert.NoError(t, err)
+	assert.Equal(t, FilterSet{
+		{Param: "status", Column: "state", Operator: "in", Values: []string{"active", "pending", "archived"}},
+	}, set)
+}
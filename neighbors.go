@@ -0,0 +1,121 @@
+package pagination
+
+import (
+	"fmt"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Neighborhood holds a record with the records around it in a listing
+type Neighborhood[T any] struct {
+	Previous []T `json:"previous"`
+	Current  T   `json:"current"`
+	Next     []T `json:"next"`
+}
+
+// Neighbors returns up to n records before and after the record with the given primary key,
+// under the filters, search and sort of filter; Previous is in listing order.
+// Filters exposing GetPagination supply the active search and sort, others use the default sort.
+// Rows with NULL in a sort column are not ordered reliably.
+func Neighbors[T any](db *gorm.DB, filter QueryBuilder, id interface{}, n int) (Neighborhood[T], error) {
+	var neighborhood Neighborhood[T]
+
+	var pagination PaginationRequest
+	if provider, ok := filter.(interface{ GetPagination() PaginationRequest }); ok {
+		pagination = provider.GetPagination()
+	}
+
+	idColumn, err := primaryKeyColumn[T](db)
+	if err != nil {
+		return neighborhood, err
+	}
+
+	columns, err := parseSortColumns(sortClause(filter, pagination), idColumn)
+	if err != nil {
+		return neighborhood, err
+	}
+
+	listing := func() *gorm.DB {
+		return filteredSet(db, filter, pagination)
+	}
+
+	if err := listing().Where(idColumn+" = ?", id).First(&neighborhood.Current).Error; err != nil {
+		return neighborhood, fmt.Errorf("failed to fetch record: %w", err)
+	}
+	if n <= 0 {
+		return neighborhood, nil
+	}
+
+	values, err := keysetValues(db, &neighborhood.Current, columns)
+	if err != nil {
+		return neighborhood, err
+	}
+
+	if err := applyKeyset(listing(), columns, values).Limit(n).Find(&neighborhood.Next).Error; err != nil {
+		return neighborhood, fmt.Errorf("failed to fetch next records: %w", err)
+	}
+
+	reversed := make([]KeysetColumn, len(columns))
+	for i, column := range columns {
+		reversed[i] = KeysetColumn{Name: column.Name, Desc: !column.Desc}
+	}
+	if err := applyKeyset(listing(), reversed, values).Limit(n).Find(&neighborhood.Previous).Error; err != nil {
+		return neighborhood, fmt.Errorf("failed to fetch previous records: %w", err)
+	}
+	for i, j := 0, len(neighborhood.Previous)-1; i < j; i, j = i+1, j-1 {
+		neighborhood.Previous[i], neighborhood.Previous[j] = neighborhood.Previous[j], neighborhood.Previous[i]
+	}
+
+	return neighborhood, nil
+}
+
+// parseSortColumns converts an ORDER BY clause such as "age desc, name" into keyset columns,
+// appending tiebreaker so the ordering is total
+func parseSortColumns(sort string, tiebreaker string) ([]KeysetColumn, error) {
+	var columns []KeysetColumn
+	hasTiebreaker := false
+
+	for _, part := range strings.Split(sort, ",") {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) > 2 || !isValidSortField(fields[0]) {
+			return nil, fmt.Errorf("unsupported sort %q", strings.TrimSpace(part))
+		}
+
+		column := KeysetColumn{Name: fields[0]}
+		if len(fields) == 2 {
+			switch strings.ToLower(fields[1]) {
+			case "asc":
+			case "desc":
+				column.Desc = true
+			default:
+				return nil, fmt.Errorf("unsupported sort %q", strings.TrimSpace(part))
+			}
+		}
+
+		if column.Name == tiebreaker {
+			hasTiebreaker = true
+		}
+		columns = append(columns, column)
+	}
+
+	if !hasTiebreaker {
+		columns = append(columns, KeysetColumn{Name: tiebreaker})
+	}
+	return columns, nil
+}
+
+// primaryKeyColumn returns the primary key column of model T, defaulting to "id"
+func primaryKeyColumn[T any](db *gorm.DB) (string, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return "", fmt.Errorf("failed to parse model schema: %w", err)
+	}
+	if field := stmt.Schema.PrioritizedPrimaryField; field != nil {
+		return field.DBName, nil
+	}
+	return "id", nil
+}
@@ -0,0 +1,69 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func userNames(users []TestUser) []string {
+	names := make([]string, len(users))
+	for i, user := range users {
+		names[i] = user.Name
+	}
+	return names
+}
+
+func TestNeighbors_ActiveSort(t *testing.T) {
+	db := setupTestDB()
+	filter := &testUserFilter{BaseFilter: BaseFilter{Pagination: PaginationRequest{Sort: "age", Order: "asc"}}}
+
+	neighborhood, err := Neighbors[TestUser](db, filter, 2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane Smith", neighborhood.Current.Name)
+	assert.Equal(t, []string{"Alice Brown"}, userNames(neighborhood.Previous))
+	assert.Equal(t, []string{"Charlie Wilson"}, userNames(neighborhood.Next))
+
+	neighborhood, err = Neighbors[TestUser](db, filter, 2, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"John Doe", "Alice Brown"}, userNames(neighborhood.Previous))
+	assert.Equal(t, []string{"Charlie Wilson", "Bob Johnson"}, userNames(neighborhood.Next))
+}
+
+func TestNeighbors_Filtered(t *testing.T) {
+	db := setupTestDB()
+	filter := &testUserFilter{MinAge: 28, BaseFilter: BaseFilter{Pagination: PaginationRequest{Sort: "age", Order: "desc"}}}
+
+	neighborhood, err := Neighbors[TestUser](db, filter, 4, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Charlie Wilson", "Jane Smith"}, userNames(neighborhood.Previous))
+	assert.Empty(t, neighborhood.Next)
+
+	// John Doe (25) is not part of the filtered listing
+	_, err = Neighbors[TestUser](db, filter, 1, 2)
+	assert.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestNeighbors_DefaultSort(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users").WithDefaultSort("id desc")
+
+	neighborhood, err := Neighbors[TestUser](db, builder, 3, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Alice Brown"}, userNames(neighborhood.Previous))
+	assert.Equal(t, []string{"Jane Smith"}, userNames(neighborhood.Next))
+}
+
+func TestParseSortColumns(t *testing.T) {
+	columns, err := parseSortColumns("age DESC, name", "id")
+	assert.NoError(t, err)
+	assert.Equal(t, []KeysetColumn{{Name: "age", Desc: true}, {Name: "name"}, {Name: "id"}}, columns)
+
+	columns, err = parseSortColumns("id desc", "id")
+	assert.NoError(t, err)
+	assert.Equal(t, []KeysetColumn{{Name: "id", Desc: true}}, columns)
+
+	_, err = parseSortColumns("RANDOM()", "id")
+	assert.Error(t, err)
+}
@@ -0,0 +1,74 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ObjectEntry is one entry of an object-store listing; entries for common
+// prefixes ("folders" under a delimiter) only have Key and IsPrefix set
+type ObjectEntry struct {
+	Key          string     `json:"key"`
+	Size         int64      `json:"size,omitempty"`
+	LastModified *time.Time `json:"last_modified,omitempty"`
+	ETag         string     `json:"etag,omitempty"`
+	IsPrefix     bool       `json:"is_prefix,omitempty"`
+}
+
+// ObjectListing is one ListObjectsV2 response
+type ObjectListing struct {
+	Objects               []ObjectEntry
+	CommonPrefixes        []string
+	NextContinuationToken string
+	IsTruncated           bool
+}
+
+// ObjectStoreSource lists an S3-compatible bucket, e.g. ListObjectsV2 with a fixed
+// Prefix and Delimiter
+type ObjectStoreSource struct {
+	// List passes token as ContinuationToken, "" for the first page, and maxKeys as MaxKeys
+	List func(ctx context.Context, token string, maxKeys int) (ObjectListing, error)
+	// Scope binds cursors to the bucket, prefix and delimiter, see CursorScope
+	Scope string
+}
+
+// PaginateObjects returns the page of source following cursor in the standard envelope
+// metadata, with the continuation token kept in pagination.next_cursor. Objects and
+// common prefixes count towards the page size alike and are merged in key order, as S3
+// lists them. Buckets have no cheap count, so the total is reported as unknown.
+func PaginateObjects(ctx context.Context, source ObjectStoreSource, cursor string, pageSize int) ([]ObjectEntry, PaginationResponse, error) {
+	if pageSize <= 0 {
+		pageSize = CurrentConfig().DefaultPageSize
+	}
+
+	var token string
+	if cursor != "" {
+		if err := decodeScopedToken(cursor, source.Scope, &token); err != nil {
+			return nil, PaginationResponse{}, err
+		}
+	}
+
+	listing, err := source.List(ctx, token, pageSize)
+	if err != nil {
+		return nil, PaginationResponse{}, fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	entries := make([]ObjectEntry, 0, len(listing.Objects)+len(listing.CommonPrefixes))
+	entries = append(entries, listing.Objects...)
+	for _, prefix := range listing.CommonPrefixes {
+		entries = append(entries, ObjectEntry{Key: prefix, IsPrefix: true})
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].Key < entries[j].Key
+	})
+
+	meta := PaginationResponse{PerPage: pageSize, TotalStatus: TotalStatusUnknown}
+	if listing.IsTruncated && listing.NextContinuationToken != "" {
+		if meta.NextCursor, err = encodeScopedToken(listing.NextContinuationToken, source.Scope); err != nil {
+			return nil, PaginationResponse{}, err
+		}
+	}
+	return entries, meta, nil
+}
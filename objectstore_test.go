@@ -0,0 +1,88 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeBucket lists keys under "reports/" with "/" as delimiter, like ListObjectsV2
+func fakeBucket(entries []ObjectEntry, tokens *[]string) ObjectStoreSource {
+	return ObjectStoreSource{
+		Scope: CursorScope("reports/"),
+		List: func(ctx context.Context, token string, maxKeys int) (ObjectListing, error) {
+			*tokens = append(*tokens, token)
+			start := 0
+			if token != "" {
+				start, _ = strconv.Atoi(token[len("opaque-"):])
+			}
+			var listing ObjectListing
+			end := start + maxKeys
+			if end > len(entries) {
+				end = len(entries)
+			}
+			for _, entry := range entries[start:end] {
+				if entry.IsPrefix {
+					listing.CommonPrefixes = append(listing.CommonPrefixes, entry.Key)
+				} else {
+					listing.Objects = append(listing.Objects, entry)
+				}
+			}
+			if end < len(entries) {
+				listing.IsTruncated = true
+				listing.NextContinuationToken = "opaque-" + strconv.Itoa(end)
+			}
+			return listing, nil
+		},
+	}
+}
+
+func TestPaginateObjects(t *testing.T) {
+	var tokens []string
+	source := fakeBucket([]ObjectEntry{
+		{Key: "reports/2023/", IsPrefix: true},
+		{Key: "reports/a.csv", Size: 10},
+		{Key: "reports/b.csv", Size: 20},
+		{Key: "reports/archive/", IsPrefix: true},
+		{Key: "reports/c.csv", Size: 30},
+	}, &tokens)
+
+	entries, meta, err := PaginateObjects(context.Background(), source, "", 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []ObjectEntry{
+		{Key: "reports/2023/", IsPrefix: true},
+		{Key: "reports/a.csv", Size: 10},
+		{Key: "reports/b.csv", Size: 20},
+	}, entries)
+	assert.Equal(t, TotalStatusUnknown, meta.TotalStatus)
+	assert.NotContains(t, meta.NextCursor, "opaque-3")
+
+	entries, meta, err = PaginateObjects(context.Background(), source, meta.NextCursor, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []ObjectEntry{
+		{Key: "reports/archive/", IsPrefix: true},
+		{Key: "reports/c.csv", Size: 30},
+	}, entries)
+	assert.Empty(t, meta.NextCursor)
+	assert.Equal(t, []string{"", "opaque-3"}, tokens)
+}
+
+func TestPaginateObjects_Errors(t *testing.T) {
+	var tokens []string
+	source := fakeBucket([]ObjectEntry{{Key: "a"}, {Key: "b"}}, &tokens)
+	_, meta, err := PaginateObjects(context.Background(), source, "", 1)
+	assert.NoError(t, err)
+
+	source.Scope = CursorScope("invoices/")
+	_, _, err = PaginateObjects(context.Background(), source, meta.NextCursor, 1)
+	assert.ErrorIs(t, err, ErrTokenScope)
+
+	failing := ObjectStoreSource{List: func(context.Context, string, int) (ObjectListing, error) {
+		return ObjectListing{}, errors.New("access denied")
+	}}
+	_, _, err = PaginateObjects(context.Background(), failing, "", 1)
+	assert.ErrorContains(t, err, "failed to list objects: access denied")
+}
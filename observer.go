@@ -0,0 +1,147 @@
+package pagination
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// QueryEvent describes a count or data query of a paginated query
+type QueryEvent struct {
+	// Kind is "count" or "data"
+	Kind    string
+	Table   string
+	Page    int
+	PerPage int
+	// Rows is the total counted or the number of rows fetched, set once the query ended
+	Rows int64
+	// Cache names the cache that answered instead of the database on OnCacheHit:
+	// "total", "total_token" or "page"
+	Cache string
+	// RequestID is the ID carried by the context of the query, see WithRequestID
+	RequestID string
+}
+
+// Observer instruments paginated queries with metrics, traces or logs. A query calls
+// OnQueryStart, then OnError if it failed, then OnQueryEnd; a query answered by a cache
+// only calls OnCacheHit. Observers are called concurrently by background counts and
+// must be safe for concurrent use. The prometheusobserver and otelobserver packages and
+// NewSlogObserver provide implementations, MultiObserver combines them.
+type Observer interface {
+	// OnQueryStart returns the context passed to the other calls for the query, e.g.
+	// carrying a span
+	OnQueryStart(ctx context.Context, event QueryEvent) context.Context
+	OnQueryEnd(ctx context.Context, event QueryEvent, duration time.Duration)
+	OnCacheHit(ctx context.Context, event QueryEvent)
+	OnError(ctx context.Context, event QueryEvent, err error)
+}
+
+// observed runs a query of db between the calls of observer; run returns the rows of
+// the event. Dry runs are not observed.
+func observed(observer Observer, db *gorm.DB, event QueryEvent, run func() (int64, error)) error {
+	if observer == nil || db.DryRun {
+		_, err := run()
+		return err
+	}
+
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	event.RequestID = RequestIDFromContext(ctx)
+	ctx = observer.OnQueryStart(ctx, event)
+	started := time.Now()
+	rows, err := run()
+	event.Rows = rows
+	if err != nil {
+		observer.OnError(ctx, event, err)
+	}
+	observer.OnQueryEnd(ctx, event, time.Since(started))
+	return err
+}
+
+// observeCacheHit reports that cache answered the query of event
+func observeCacheHit(observer Observer, db *gorm.DB, event QueryEvent, cache string) {
+	if observer == nil || db.DryRun {
+		return
+	}
+	ctx := db.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	event.Cache = cache
+	event.RequestID = RequestIDFromContext(ctx)
+	observer.OnCacheHit(ctx, event)
+}
+
+// MultiObserver calls every observer in order
+func MultiObserver(observers ...Observer) Observer {
+	return multiObserver(observers)
+}
+
+type multiObserver []Observer
+
+func (m multiObserver) OnQueryStart(ctx context.Context, event QueryEvent) context.Context {
+	for _, observer := range m {
+		ctx = observer.OnQueryStart(ctx, event)
+	}
+	return ctx
+}
+
+func (m multiObserver) OnQueryEnd(ctx context.Context, event QueryEvent, duration time.Duration) {
+	for _, observer := range m {
+		observer.OnQueryEnd(ctx, event, duration)
+	}
+}
+
+func (m multiObserver) OnCacheHit(ctx context.Context, event QueryEvent) {
+	for _, observer := range m {
+		observer.OnCacheHit(ctx, event)
+	}
+}
+
+func (m multiObserver) OnError(ctx context.Context, event QueryEvent, err error) {
+	for _, observer := range m {
+		observer.OnError(ctx, event, err)
+	}
+}
+
+// SlogObserver logs finished queries and cache hits at debug level and failed queries
+// at error level
+type SlogObserver struct {
+	Logger *slog.Logger
+}
+
+// NewSlogObserver logs to logger, slog.Default() when nil
+func NewSlogObserver(logger *slog.Logger) *SlogObserver {
+	if logger == nil {
+		logger = slog.Default()
+	}
+	return &SlogObserver{Logger: logger}
+}
+
+func (o *SlogObserver) OnQueryStart(ctx context.Context, event QueryEvent) context.Context {
+	return ctx
+}
+
+func (o *SlogObserver) OnQueryEnd(ctx context.Context, event QueryEvent, duration time.Duration) {
+	o.Logger.DebugContext(ctx, "paginated query", append(eventAttrs(event), "rows", event.Rows, "duration", duration)...)
+}
+
+func (o *SlogObserver) OnCacheHit(ctx context.Context, event QueryEvent) {
+	o.Logger.DebugContext(ctx, "paginated query cache hit", append(eventAttrs(event), "cache", event.Cache)...)
+}
+
+func (o *SlogObserver) OnError(ctx context.Context, event QueryEvent, err error) {
+	o.Logger.ErrorContext(ctx, "paginated query failed", append(eventAttrs(event), "error", err)...)
+}
+
+func eventAttrs(event QueryEvent) []interface{} {
+	attrs := []interface{}{"kind", event.Kind, "table", event.Table, "page", event.Page, "per_page", event.PerPage}
+	if event.RequestID != "" {
+		attrs = append(attrs, "request_id", event.RequestID)
+	}
+	return attrs
+}
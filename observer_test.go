@@ -0,0 +1,82 @@
+package pagination
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingObserver struct {
+	mu     sync.Mutex
+	events []string
+}
+
+func (o *recordingObserver) record(event string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.events = append(o.events, event)
+}
+
+func (o *recordingObserver) OnQueryStart(ctx context.Context, event QueryEvent) context.Context {
+	o.record("start " + event.Kind)
+	return ctx
+}
+
+func (o *recordingObserver) OnQueryEnd(ctx context.Context, event QueryEvent, duration time.Duration) {
+	o.record("end " + event.Kind)
+}
+
+func (o *recordingObserver) OnCacheHit(ctx context.Context, event QueryEvent) {
+	o.record("hit " + event.Kind + " " + event.Cache)
+}
+
+func (o *recordingObserver) OnError(ctx context.Context, event QueryEvent, err error) {
+	o.record("error " + event.Kind)
+}
+
+func TestObserver(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users")
+	observer := &recordingObserver{}
+	options := PaginatedQueryOptions{
+		Dialect:    SQLite,
+		Observer:   observer,
+		TotalCache: NewTotalCache(time.Minute),
+		PageCache:  NewPageCache(time.Minute, 0),
+	}
+	request := PaginationRequest{Page: 1, PerPage: 2}
+
+	_, _, err := PaginatedQueryWithOptions[TestUser](db, builder, request, nil, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"start data", "end data", "start count", "end count"}, observer.events)
+
+	observer.events = nil
+	_, _, err = PaginatedQueryWithOptions[TestUser](db, builder, request, nil, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"hit data page", "hit count total"}, observer.events)
+
+	observer.events = nil
+	_, _, err = PaginatedQueryWithOptions[TestUser](db, NewSimpleQueryBuilder("missing_table"), request, nil, PaginatedQueryOptions{Dialect: SQLite, Observer: observer})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"start data", "error data", "end data"}, observer.events)
+}
+
+func TestSlogObserver(t *testing.T) {
+	db := setupTestDB()
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	observer := MultiObserver(NewSlogObserver(logger), &recordingObserver{})
+
+	_, _, err := PaginatedQueryWithOptions[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 1, PerPage: 10}, nil, PaginatedQueryOptions{Dialect: SQLite, Observer: observer})
+	assert.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	assert.Len(t, lines, 1, "a short first page needs no count")
+	assert.Contains(t, lines[0], `msg="paginated query" kind=data table=test_users page=1 per_page=10 rows=5`)
+}
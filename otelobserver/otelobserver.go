@@ -0,0 +1,69 @@
+// Package otelobserver traces the queries of pagination with OpenTelemetry: each count
+// and data query is a span named pagination.count or pagination.data, child of the span
+// in the query context, and cache hits are events of that span.
+package otelobserver
+
+import (
+	"context"
+	"time"
+
+	pagination "github.com/Caknoooo/go-pagination"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/Caknoooo/go-pagination"
+
+// Observer is a pagination.Observer recording spans
+type Observer struct {
+	tracer trace.Tracer
+}
+
+// New traces with the tracer of provider, the global provider when nil
+func New(provider trace.TracerProvider) *Observer {
+	if provider == nil {
+		provider = otel.GetTracerProvider()
+	}
+	return &Observer{tracer: provider.Tracer(instrumentationName)}
+}
+
+func (o *Observer) OnQueryStart(ctx context.Context, event pagination.QueryEvent) context.Context {
+	ctx, _ = o.tracer.Start(ctx, "pagination."+event.Kind,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attributes(event)...),
+	)
+	return ctx
+}
+
+func (o *Observer) OnQueryEnd(ctx context.Context, event pagination.QueryEvent, duration time.Duration) {
+	span := trace.SpanFromContext(ctx)
+	span.SetAttributes(attribute.Int64("pagination.rows", event.Rows))
+	span.End()
+}
+
+func (o *Observer) OnCacheHit(ctx context.Context, event pagination.QueryEvent) {
+	trace.SpanFromContext(ctx).AddEvent("pagination.cache_hit", trace.WithAttributes(
+		append(attributes(event), attribute.String("pagination.cache", event.Cache))...,
+	))
+}
+
+func (o *Observer) OnError(ctx context.Context, event pagination.QueryEvent, err error) {
+	span := trace.SpanFromContext(ctx)
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+func attributes(event pagination.QueryEvent) []attribute.KeyValue {
+	attributes := []attribute.KeyValue{
+		attribute.String("pagination.kind", event.Kind),
+		attribute.String("db.sql.table", event.Table),
+		attribute.Int("pagination.page", event.Page),
+		attribute.Int("pagination.per_page", event.PerPage),
+	}
+	if event.RequestID != "" {
+		attributes = append(attributes, attribute.String("pagination.request_id", event.RequestID))
+	}
+	return attributes
+}
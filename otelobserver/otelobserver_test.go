@@ -0,0 +1,40 @@
+package otelobserver
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pagination "github.com/Caknoooo/go-pagination"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestObserver(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	observer := New(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	event := pagination.QueryEvent{Kind: "data", Table: "users", Page: 2, PerPage: 10}
+	ctx := observer.OnQueryStart(context.Background(), event)
+	observer.OnCacheHit(ctx, pagination.QueryEvent{Kind: "count", Table: "users", Cache: "total"})
+	observer.OnError(ctx, event, errors.New("timeout"))
+	event.Rows = 10
+	observer.OnQueryEnd(ctx, event, time.Millisecond)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 1)
+	assert.Equal(t, "pagination.data", spans[0].Name())
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	assert.Len(t, spans[0].Events(), 2, "the cache hit and the error")
+
+	attributes := map[string]interface{}{}
+	for _, attribute := range spans[0].Attributes() {
+		attributes[string(attribute.Key)] = attribute.Value.AsInterface()
+	}
+	assert.Equal(t, "users", attributes["db.sql.table"])
+	assert.Equal(t, int64(2), attributes["pagination.page"])
+	assert.Equal(t, int64(10), attributes["pagination.rows"])
+}
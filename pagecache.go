@@ -0,0 +1,202 @@
+package pagination
+
+import (
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PageCache caches the rows of data queries, so pages served again or prefetched by
+// PaginatedQueryOptions.Prefetch skip the database. Register it with a CacheInvalidator
+// to drop the pages of a table on writes.
+type PageCache struct {
+	ttl time.Duration
+	// workers holds a slot per running prefetch
+	workers chan struct{}
+
+	mu         sync.Mutex
+	entries    map[string]pageEntry
+	pending    map[string]bool
+	hits       int64
+	misses     int64
+	prefetched int64
+	dropped    int64
+	generation uint64
+}
+
+// PageCacheStats reports the usage of a PageCache
+type PageCacheStats struct {
+	Entries    int   `json:"entries"`
+	Pending    int   `json:"pending"`
+	Hits       int64 `json:"hits"`
+	Misses     int64 `json:"misses"`
+	Prefetched int64 `json:"prefetched"`
+	// Dropped counts prefetches skipped because every worker was busy
+	Dropped int64 `json:"dropped"`
+}
+
+type pageEntry struct {
+	rows      interface{}
+	table     string
+	expiresAt time.Time
+}
+
+// NewPageCache creates a PageCache; a zero TTL keeps pages until they are invalidated.
+// At most prefetchWorkers next pages are fetched at a time, zero disables prefetching.
+func NewPageCache(ttl time.Duration, prefetchWorkers int) *PageCache {
+	return &PageCache{
+		ttl:     ttl,
+		workers: make(chan struct{}, max(prefetchWorkers, 0)),
+		entries: make(map[string]pageEntry),
+		pending: make(map[string]bool),
+	}
+}
+
+// get returns the cached rows of key
+func (c *PageCache) get(key string) (interface{}, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if ok && !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	return entry.rows, true
+}
+
+// storeFetched stores rows fetched since generation unless the cache was invalidated meanwhile
+func (c *PageCache) storeFetched(key, table string, rows interface{}, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation != generation {
+		return
+	}
+	entry := pageEntry{rows: rows, table: table}
+	if c.ttl > 0 {
+		entry.expiresAt = time.Now().Add(c.ttl)
+	}
+	c.entries[key] = entry
+}
+
+// currentGeneration returns the invalidation generation, taken before fetching
+func (c *PageCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// Stats returns the current usage counters
+func (c *PageCache) Stats() PageCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return PageCacheStats{
+		Entries:    len(c.entries),
+		Pending:    len(c.pending),
+		Hits:       c.hits,
+		Misses:     c.misses,
+		Prefetched: c.prefetched,
+		Dropped:    c.dropped,
+	}
+}
+
+// Invalidate drops every cached page
+func (c *PageCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]pageEntry)
+	c.generation++
+}
+
+// InvalidateTable drops the cached pages of table
+func (c *PageCache) InvalidateTable(table string) {
+	table = baseTableName(table)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if entry.table == table {
+			delete(c.entries, key)
+		}
+	}
+	c.generation++
+}
+
+// prefetch runs fetch on a free worker unless key is cached or already being fetched;
+// with every worker busy the prefetch is dropped rather than queued
+func (c *PageCache) prefetch(key, table string, fetch func() (interface{}, error)) {
+	if cap(c.workers) == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	if _, ok := c.entries[key]; ok || c.pending[key] {
+		c.mu.Unlock()
+		return
+	}
+	select {
+	case c.workers <- struct{}{}:
+	default:
+		c.dropped++
+		c.mu.Unlock()
+		return
+	}
+	c.pending[key] = true
+	generation := c.generation
+	c.mu.Unlock()
+
+	go func() {
+		defer func() { <-c.workers }()
+
+		rows, err := fetch()
+		if err == nil {
+			c.storeFetched(key, table, rows, generation)
+		}
+
+		c.mu.Lock()
+		delete(c.pending, key)
+		if err == nil {
+			c.prefetched++
+		}
+		c.mu.Unlock()
+	}()
+}
+
+// cachedPage resolves the rows of query through cache, fetching them on a miss.
+// Every caller receives its own slice; the elements themselves are shared.
+func cachedPage[T any](cache *PageCache, query *gorm.DB, table string, fetch func(*gorm.DB) ([]T, error)) ([]T, error) {
+	key := findKey[T](query)
+	if rows, ok := cache.get(key); ok {
+		return append([]T(nil), rows.([]T)...), nil
+	}
+
+	generation := cache.currentGeneration()
+	result, err := fetch(query)
+	if err != nil {
+		return nil, err
+	}
+	cache.storeFetched(key, baseTableName(table), append([]T(nil), result...), generation)
+	return result, nil
+}
+
+// prefetchPage fetches the rows of query into cache in the background
+func prefetchPage[T any](cache *PageCache, query *gorm.DB, table string, fetch func(*gorm.DB) ([]T, error)) {
+	cache.prefetch(findKey[T](query), baseTableName(table), func() (interface{}, error) {
+		return fetch(query)
+	})
+}
+
+// hasNextPage reports whether a full page of limit rows at offset may be followed by
+// another; a pending total cannot rule it out
+func hasNextPage(offset, limit, rows int, total int64) bool {
+	if limit <= 0 || rows < limit {
+		return false
+	}
+	return total == TotalPending || int64(offset+limit) < total
+}
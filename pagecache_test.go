@@ -0,0 +1,136 @@
+package pagination
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// executedSQL records the statements run on db, leaving out those only rendered for cache keys
+func executedSQL(db *gorm.DB) *[]string {
+	var mu sync.Mutex
+	statements := &[]string{}
+	record := func(tx *gorm.DB) {
+		if !tx.DryRun {
+			mu.Lock()
+			*statements = append(*statements, tx.Statement.SQL.String())
+			mu.Unlock()
+		}
+	}
+	db.Callback().Query().After("gorm:query").Register("test:executed_sql", record)
+	db.Callback().Row().After("gorm:row").Register("test:executed_sql", record)
+	return statements
+}
+
+func TestPageCache_ServesRepeatedPages(t *testing.T) {
+	db := setupTestDB()
+	statements := executedSQL(db)
+	builder := NewSimpleQueryBuilder("test_users")
+	cache := NewPageCache(time.Minute, 0)
+	options := PaginatedQueryOptions{Dialect: SQLite, PageCache: cache, TotalCache: NewTotalCache(time.Minute)}
+	request := PaginationRequest{Page: 1, PerPage: 2}
+
+	first, _, err := PaginatedQueryWithOptions[TestUser](db, builder, request, nil, options)
+	assert.NoError(t, err)
+	queries := len(*statements)
+
+	again, total, err := PaginatedQueryWithOptions[TestUser](db, builder, request, nil, options)
+	assert.NoError(t, err)
+	assert.Equal(t, userNames(first), userNames(again))
+	assert.Equal(t, int64(5), total)
+	assert.Len(t, *statements, queries, "page and total come from the caches")
+
+	again[0].Name = "changed"
+	cached, _, _ := PaginatedQueryWithOptions[TestUser](db, builder, request, nil, options)
+	assert.Equal(t, "John Doe", cached[0].Name, "callers receive their own rows")
+	assert.Equal(t, PageCacheStats{Entries: 1, Hits: 2, Misses: 1}, cache.Stats())
+
+	assert.NoError(t, db.Use(CacheInvalidator{Caches: []TableInvalidator{cache}}))
+	db.Create(&TestUser{Name: "Dave", Email: "dave@example.com", Age: 40})
+	assert.Equal(t, 0, cache.Stats().Entries)
+}
+
+func TestPageCache_PreparedPagesDoNotCollide(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users")
+	options := PaginatedQueryOptions{Dialect: SQLite, PageCache: NewPageCache(time.Minute, 0), PrepareStatements: true}
+
+	first, _, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil, options)
+	assert.NoError(t, err)
+	second, _, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 2, PerPage: 2}, nil, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"John Doe", "Jane Smith"}, userNames(first))
+	assert.Equal(t, []string{"Bob Johnson", "Alice Brown"}, userNames(second), "bound page windows are part of the key")
+}
+
+func TestPageCache_PrefetchesNextPage(t *testing.T) {
+	db := setupTestDB()
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+
+	builder := NewSimpleQueryBuilder("test_users")
+	cache := NewPageCache(time.Minute, 2)
+	options := PaginatedQueryOptions{Dialect: SQLite, PageCache: cache, Prefetch: true}
+
+	_, _, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil, options)
+	assert.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		return cache.Stats().Prefetched == 1
+	}, time.Second, 10*time.Millisecond)
+
+	statements := executedSQL(db)
+	second, total, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 2, PerPage: 2}, nil, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bob Johnson", "Alice Brown"}, userNames(second))
+	assert.Equal(t, int64(5), total)
+	assert.Eventually(t, func() bool {
+		return cache.Stats().Prefetched == 2
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"SELECT count(*) FROM `test_users`", "SELECT * FROM `test_users` ORDER BY id asc LIMIT 2 OFFSET 4"}, *statements,
+		"the prefetched page is served and the following one fetched")
+
+	// The short last page has no next page to fetch, only its total is counted
+	last, total, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 3, PerPage: 2}, nil, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Charlie Wilson"}, userNames(last))
+	assert.Equal(t, int64(5), total)
+	assert.Len(t, *statements, 3)
+	assert.Equal(t, "SELECT count(*) FROM `test_users`", (*statements)[2])
+	assert.Equal(t, PageCacheStats{Entries: 3, Hits: 2, Misses: 1, Prefetched: 2}, cache.Stats())
+}
+
+func TestPageCache_PrefetchWorkersBound(t *testing.T) {
+	cache := NewPageCache(time.Minute, 1)
+	release := make(chan struct{})
+	fetch := func() (interface{}, error) {
+		<-release
+		return []TestUser{}, nil
+	}
+
+	cache.prefetch("a", "test_users", fetch)
+	cache.prefetch("a", "test_users", fetch)
+	cache.prefetch("b", "test_users", fetch)
+	stats := cache.Stats()
+	assert.Equal(t, 1, stats.Pending, "a key is fetched once")
+	assert.Equal(t, int64(1), stats.Dropped, "a busy pool drops the prefetch")
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return cache.Stats().Entries == 1
+	}, time.Second, 10*time.Millisecond)
+
+	disabled := NewPageCache(time.Minute, 0)
+	disabled.prefetch("a", "test_users", fetch)
+	assert.Equal(t, PageCacheStats{}, disabled.Stats())
+}
+
+func TestHasNextPage(t *testing.T) {
+	assert.True(t, hasNextPage(0, 2, 2, 5))
+	assert.False(t, hasNextPage(4, 2, 1, 5))
+	assert.False(t, hasNextPage(2, 2, 2, 4))
+	assert.True(t, hasNextPage(0, 2, 2, TotalPending))
+	assert.False(t, hasNextPage(0, -1, 5, 5))
+}
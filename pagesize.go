@@ -0,0 +1,44 @@
+package pagination
+
+import (
+	"context"
+	"net/http"
+)
+
+type maxPageSizeKey struct{}
+
+// WithMaxPageSize caps the page size bound from requests with ctx at max, replacing the
+// global and table maximums for one endpoint; larger sizes are clamped with a warning
+func WithMaxPageSize(ctx context.Context, max int) context.Context {
+	return context.WithValue(ctx, maxPageSizeKey{}, max)
+}
+
+// requestMaxPageSize returns the maximum set by WithMaxPageSize, if any
+func requestMaxPageSize(ctx context.Context) (int, bool) {
+	if ctx == nil {
+		return 0, false
+	}
+	max, ok := ctx.Value(maxPageSizeKey{}).(int)
+	return max, ok && max > 0
+}
+
+// MaxPageSizeHandler caps the page size of the request helpers of a route at max
+func MaxPageSizeHandler(max int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(WithMaxPageSize(r.Context(), max)))
+		})
+	}
+}
+
+// ClampPageSize caps PerPage at max and reports whether the request was changed;
+// disabled pagination would return every row, so it is turned off. A max of zero
+// leaves the request alone.
+func (p *PaginationRequest) ClampPageSize(max int) bool {
+	if max <= 0 || (!p.IsDisabled && p.PerPage <= max) {
+		return false
+	}
+	p.IsDisabled = false
+	p.PerPage = min(p.PerPage, max)
+	return true
+}
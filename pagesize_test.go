@@ -0,0 +1,84 @@
+package pagination
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindPagination_ClampsToMaxPageSize(t *testing.T) {
+	pagination, warnings := BindPaginationRequest(newTestRequest("/users?per_page=100000"))
+	assert.Equal(t, 100, pagination.PerPage)
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, "100", warnings[0].Applied)
+	}
+}
+
+func TestMaxPageSizeHandler(t *testing.T) {
+	db := setupTestDB()
+	var response PaginatedResponse
+	router := MaxPageSizeHandler(2)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response = PaginateResponse[TestUser](db, r, "ok", WithTable("test_users"))
+	}))
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users?per_page=50", nil))
+	assert.Equal(t, 2, response.Pagination.PerPage, "the meta reports the clamped size")
+	assert.Equal(t, int64(3), response.Pagination.MaxPage)
+	assert.Len(t, response.Data, 2)
+	assert.Len(t, response.Pagination.Warnings, 1)
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users", nil))
+	assert.Equal(t, 2, response.Pagination.PerPage, "the default size stays within the maximum")
+	assert.Empty(t, response.Pagination.Warnings)
+}
+
+func TestMaxPageSizeHandler_AboveGlobalMaximum(t *testing.T) {
+	var bound PaginationRequest
+	handler := MaxPageSizeHandler(500)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bound, _ = BindPaginationRequest(r)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users?per_page=250", nil))
+	assert.Equal(t, 250, bound.PerPage)
+}
+
+func TestPaginatedQueryOptions_MaxPageSize(t *testing.T) {
+	db := setupTestDB()
+	request := PaginationRequest{Page: 1, PerPage: 100000}
+
+	users, _, err := PaginatedQueryWithOptions[TestUser](db, NewSimpleQueryBuilder("test_users"), request, nil, PaginatedQueryOptions{Dialect: SQLite, MaxPageSize: 3})
+	assert.NoError(t, err)
+	assert.Len(t, users, 3)
+
+	assert.True(t, request.ClampPageSize(3))
+	assert.Equal(t, 3, request.PerPage)
+	assert.False(t, request.ClampPageSize(3))
+
+	disabled := PaginationRequest{Page: 1, PerPage: 500, IsDisabled: true}
+	assert.True(t, disabled.ClampPageSize(3))
+	assert.False(t, disabled.IsDisabled)
+	assert.Equal(t, 3, disabled.PerPage)
+
+	users, _, err = PaginatedQueryWithOptions[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 1, PerPage: 10, IsDisabled: true}, nil, PaginatedQueryOptions{Dialect: SQLite, MaxPageSize: 3})
+	assert.NoError(t, err)
+	assert.Len(t, users, 3, "disabled pagination does not bypass the maximum")
+}
+
+func TestBindPaginationRequest_DisabledIsCapped(t *testing.T) {
+	request, warnings := BindPaginationRequest(newTestRequest("/users?is_disabled=true"))
+	assert.False(t, request.IsDisabled)
+	assert.Equal(t, CurrentConfig().DefaultPageSize, request.PerPage)
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, PaginationWarning{Param: "is_disabled", Value: "true", Applied: "false", Message: "is_disabled is not allowed, per_page is capped at 100"}, warnings[0])
+	}
+
+	var bound PaginationRequest
+	handler := MaxPageSizeHandler(2)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bound, _ = BindPaginationRequest(r)
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users?per_page=50&is_disabled=1", nil))
+	assert.False(t, bound.IsDisabled)
+	assert.Equal(t, 2, bound.PerPage)
+}
@@ -1,28 +1,107 @@
 package pagination
 
 import (
+	"encoding/json"
 	"math"
-	"strconv"
-	"strings"
-
-	"github.com/gin-gonic/gin"
+	"time"
 )
 
 type PaginationRequest struct {
-	Page       int    `json:"page" form:"page"`
-	PerPage    int    `json:"per_page" form:"per_page"`
-	Search     string `json:"search" form:"search"`
-	Sort       string `json:"sort" form:"sort"`
-	Order      string `json:"order" form:"order"`
-	IsDisabled bool   `json:"is_disabled,omitempty" form:"is_disabled"`
+	Page    int    `json:"page" form:"page"`
+	PerPage int    `json:"per_page" form:"per_page"`
+	Search  string `json:"search" form:"search"`
+	// SearchMode is the search_mode parameter, empty for the mode of the endpoint
+	SearchMode SearchMode `json:"search_mode,omitempty" form:"search_mode"`
+	Sort       string     `json:"sort" form:"sort"`
+	Order      string     `json:"order" form:"order"`
+	IsDisabled bool       `json:"is_disabled,omitempty" form:"is_disabled"`
 }
 
 type PaginationResponse struct {
-	Page       int   `json:"page"`
-	PerPage    int   `json:"per_page"`
-	MaxPage    int64 `json:"max_page"`
-	Total      int64 `json:"total"`
-	IsDisabled bool  `json:"is_disabled,omitempty"`
+	Page    int   `json:"page"`
+	PerPage int   `json:"per_page"`
+	MaxPage int64 `json:"max_page"`
+	Total   int64 `json:"total"`
+	// LastPage is the number of the last page, equal to MaxPage
+	LastPage int64 `json:"last_page"`
+	// Path is the request path without its query string, set with WithPath
+	Path       string `json:"path,omitempty"`
+	IsDisabled bool   `json:"is_disabled,omitempty"`
+	// Sampled marks a random sample of the filtered set instead of a page
+	Sampled bool `json:"sampled,omitempty"`
+
+	// Warnings lists request parameters that were invalid or adjusted
+	Warnings []PaginationWarning `json:"warnings,omitempty"`
+
+	// TotalStatus is "pending" while the total is computed in the background and
+	// "unknown" for sources without totals; total and max_page are then rendered as null,
+	// as for "skipped" counts. Estimated totals are "estimated".
+	TotalStatus string `json:"total_status,omitempty"`
+
+	// HasMore tells whether another page follows when the count was skipped
+	HasMore *bool `json:"has_more,omitempty"`
+
+	// TotalRelation is "gte" when the count stopped at a limit and total is a lower bound
+	TotalRelation string `json:"total_relation,omitempty"`
+
+	// NextCursor resumes cursor-paged sources such as DynamoDB; empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+	// PrevCursor returns to the previous page of keyset listings; empty on the first page
+	PrevCursor string `json:"prev_cursor,omitempty"`
+
+	// TotalToken lets the next page reuse this total when echoed as total_token
+	TotalToken string `json:"total_token,omitempty"`
+
+	// BoundaryToken lets the next page drop rows of this one when echoed as boundary_token
+	BoundaryToken string `json:"boundary_token,omitempty"`
+	// VisibilityToken lets the next page of a listing filtered by a Visibility start
+	// where this one stopped reading when echoed as visibility_token
+	VisibilityToken string `json:"visibility_token,omitempty"`
+
+	// AsOfToken hides rows written after this page from later pages when echoed as as_of_token
+	AsOfToken string `json:"as_of_token,omitempty"`
+
+	// Includes reports the related rows loaded per include and truncated child lists
+	Includes map[string]IncludeStats `json:"includes,omitempty"`
+
+	// Checksum changes when a row of this page is added, removed, reordered or updated
+	Checksum string `json:"checksum,omitempty"`
+
+	// DataAsOf is when precomputed data such as a materialized view was last refreshed
+	DataAsOf *time.Time `json:"data_as_of,omitempty"`
+
+	// Histograms holds the bucket counts of declared numeric fields over the filtered set
+	Histograms map[string][]HistogramBucket `json:"histograms,omitempty"`
+
+	// Bounds holds the minimum and maximum of declared fields over the filtered set
+	Bounds map[string]FieldBounds `json:"bounds,omitempty"`
+
+	// Debug holds query diagnostics such as the rewrites of a QueryRewriter
+	Debug *DebugMeta `json:"debug,omitempty"`
+}
+
+// MarshalJSON renders pending, skipped and unknown totals as null; cursor listings
+// without totals have no page number either
+func (p PaginationResponse) MarshalJSON() ([]byte, error) {
+	type plain PaginationResponse
+	switch p.TotalStatus {
+	case TotalStatusPending, TotalStatusSkipped:
+		return json.Marshal(struct {
+			plain
+			MaxPage  *int64 `json:"max_page"`
+			Total    *int64 `json:"total"`
+			LastPage *int64 `json:"last_page"`
+		}{plain: plain(p)})
+	case TotalStatusUnknown:
+		return json.Marshal(struct {
+			plain
+			Page     *int   `json:"page,omitempty"`
+			MaxPage  *int64 `json:"max_page"`
+			Total    *int64 `json:"total"`
+			LastPage *int64 `json:"last_page"`
+		}{plain: plain(p)})
+	}
+	return json.Marshal(plain(p))
 }
 
 type PaginatedResponse struct {
@@ -31,6 +110,12 @@ type PaginatedResponse struct {
 	Message    string             `json:"message"`
 	Data       interface{}        `json:"data"`
 	Pagination PaginationResponse `json:"pagination"`
+	// Errors lists the query parameters of a 422 response that did not fit their filter fields
+	Errors []ParamError `json:"errors,omitempty"`
+	// Links are the page links added by WithLinks
+	Links *PageLinks `json:"links,omitempty"`
+	// RequestID correlates an error response with the logs of its request, see RequestID
+	RequestID string `json:"request_id,omitempty"`
 }
 
 func (p *PaginationRequest) GetOffset() int {
@@ -42,7 +127,7 @@ func (p *PaginationRequest) GetOffset() int {
 
 func (p *PaginationRequest) GetLimit() int {
 	if p.PerPage <= 0 {
-		p.PerPage = 10
+		p.PerPage = CurrentConfig().DefaultPageSize
 	}
 	return p.PerPage
 }
@@ -53,7 +138,7 @@ func (p *PaginationRequest) Validate() {
 	}
 
 	if p.PerPage <= 0 {
-		p.PerPage = 10
+		p.PerPage = CurrentConfig().DefaultPageSize
 	}
 
 	if p.Order == "" {
@@ -65,50 +150,15 @@ func (p *PaginationRequest) Validate() {
 	}
 }
 
-func BindPagination(ctx *gin.Context) PaginationRequest {
-	pagination := PaginationRequest{
-		Page:       1,
-		PerPage:    10,
-		Search:     "",
-		Sort:       "",
-		Order:      "asc",
-		IsDisabled: false,
-	}
-
-	if pageStr := ctx.Query("page"); pageStr != "" {
-		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
-			pagination.Page = page
-		}
-	}
-
-	if perPageStr := ctx.Query("per_page"); perPageStr != "" {
-		if perPage, err := strconv.Atoi(perPageStr); err == nil && perPage > 0 && perPage <= 100 {
-			pagination.PerPage = perPage
-		}
-	}
-
-	pagination.Search = ctx.Query("search")
-
-	pagination.Sort = ctx.Query("sort")
-
-	if order := ctx.Query("order"); order == "desc" || order == "asc" {
-		pagination.Order = order
-	}
-
-	if isDisabled := ctx.Query("is_disabled"); isDisabled != "" {
-		switch strings.ToLower(isDisabled) {
-		case "1", "true", "yes", "y", "on":
-			pagination.IsDisabled = true
-		default:
-			pagination.IsDisabled = false
+func CalculatePagination(pagination PaginationRequest, totalCount int64) PaginationResponse {
+	if totalCount == TotalPending {
+		return PaginationResponse{
+			Page:        pagination.Page,
+			PerPage:     pagination.PerPage,
+			TotalStatus: TotalStatusPending,
 		}
 	}
 
-	pagination.Validate()
-	return pagination
-}
-
-func CalculatePagination(pagination PaginationRequest, totalCount int64) PaginationResponse {
 	// When pagination disabled, return minimal metadata
 	if pagination.IsDisabled {
 		return PaginationResponse{
@@ -116,6 +166,7 @@ func CalculatePagination(pagination PaginationRequest, totalCount int64) Paginat
 			PerPage:    int(totalCount),
 			MaxPage:    1,
 			Total:      totalCount,
+			LastPage:   1,
 			IsDisabled: true,
 		}
 	}
@@ -131,6 +182,7 @@ func CalculatePagination(pagination PaginationRequest, totalCount int64) Paginat
 		PerPage:    pagination.PerPage,
 		MaxPage:    maxPage,
 		Total:      totalCount,
+		LastPage:   maxPage,
 		IsDisabled: false,
 	}
 }
@@ -1,31 +1,53 @@
 package pagination
 
 import (
+	"context"
+	"net/http"
+	"net/url"
 	"reflect"
 	"strings"
 
-	"github.com/gin-gonic/gin"
 	"gorm.io/gorm"
 )
 
 type BaseFilter struct {
 	Pagination PaginationRequest `json:"pagination"`
 	Includes   []string          `json:"includes"`
+
+	warnings      []PaginationWarning
+	includeFields map[string][]string
+	ctx           context.Context
+	// self is the filter embedding BaseFilter, see ApplyFilters
+	self         interface{}
+	filterSet    FilterSet
+	selectFields []string
 }
 
-func (f *BaseFilter) BindPagination(ctx *gin.Context) {
-	f.Pagination = BindPagination(ctx)
+// bindRequest binds pagination, includes and include fields from the query string of r
+func (f *BaseFilter) bindRequest(r *http.Request) {
+	query := requestQuery(r)
+	if r != nil {
+		f.ctx = r.Context()
+	}
+	f.Pagination, f.warnings = BindPaginationRequest(r)
+	f.includeFields = ParseIncludeFieldsValues(query)
 
-	// Bind includes from query parameter
-	if includesStr := ctx.Query("includes"); includesStr != "" {
-		f.Includes = strings.Split(includesStr, ",")
-		// Clean whitespace from includes
-		for i, include := range f.Includes {
-			f.Includes[i] = strings.TrimSpace(include)
-		}
+	if includes := ParseIncludes(query); len(includes) > 0 {
+		f.Includes = includes
 	}
 }
 
+// applySortDirections applies the directions of a filter implementing SortDirectionProvider
+func (f *BaseFilter) applySortDirections(query url.Values, directions map[string]string) {
+	applySortDirections(query, &f.Pagination, directions)
+}
+
+// restrictSort drops the sort fields of a filter implementing SortableFieldsProvider
+// that it does not declare
+func (f *BaseFilter) restrictSort(allowed []string) {
+	f.warnings = append(f.warnings, restrictSort(&f.Pagination, allowed)...)
+}
+
 func (f *BaseFilter) GetOffset() int {
 	return f.Pagination.GetOffset()
 }
@@ -46,6 +68,22 @@ func (f *BaseFilter) GetIncludes() []string {
 	return f.Includes
 }
 
+// GetIncludeFields returns the columns requested per include with fields[include]=a,b
+func (f *BaseFilter) GetIncludeFields() map[string][]string {
+	return f.includeFields
+}
+
+// RequestContext returns the context of the request the filter was bound from; the
+// query layer helpers run their queries with it
+func (f *BaseFilter) RequestContext() context.Context {
+	return f.ctx
+}
+
+// GetPaginationWarnings returns the problems found while binding pagination parameters
+func (f *BaseFilter) GetPaginationWarnings() []PaginationWarning {
+	return f.warnings
+}
+
 type Filterable interface {
 	ApplyFilters(query *gorm.DB) *gorm.DB
 	GetTableName() string
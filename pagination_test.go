@@ -1,12 +1,10 @@
 package pagination
 
 import (
-	"net/http"
 	"net/http/httptest"
 	"strings"
 	"testing"
 
-	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
@@ -92,9 +90,7 @@ func TestPaginationRequest_Validate(t *testing.T) {
 	assert.Equal(t, "asc", p.Order)
 }
 
-func TestBindPagination(t *testing.T) {
-	gin.SetMode(gin.TestMode)
-
+func TestBindPaginationRequest(t *testing.T) {
 	tests := []struct {
 		name            string
 		query           string
@@ -127,11 +123,7 @@ func TestBindPagination(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			w := httptest.NewRecorder()
-			c, _ := gin.CreateTestContext(w)
-			c.Request, _ = http.NewRequest("GET", "/?"+tt.query, nil)
-
-			pagination := BindPagination(c)
+			pagination := bindTestPagination("/?" + tt.query)
 
 			assert.Equal(t, tt.expectedPage, pagination.Page)
 			assert.Equal(t, tt.expectedPerPage, pagination.PerPage)
@@ -150,6 +142,9 @@ func TestCalculatePagination(t *testing.T) {
 	assert.Equal(t, 10, result.PerPage)
 	assert.Equal(t, int64(3), result.MaxPage)
 	assert.Equal(t, int64(25), result.Total)
+	assert.Equal(t, int64(3), result.LastPage)
+	assert.Empty(t, result.Path)
+	assert.Equal(t, "/users", result.WithPath(httptest.NewRequest("GET", "/users?page=2", nil)).Path)
 }
 
 func TestSimpleQueryBuilder(t *testing.T) {
@@ -230,16 +225,11 @@ func TestDynamicFilter(t *testing.T) {
 	assert.Len(t, users, 2)
 }
 
-func TestPaginateModel(t *testing.T) {
+func TestPaginate(t *testing.T) {
 	db := setupTestDB()
-	gin.SetMode(gin.TestMode)
-
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-	c.Request, _ = http.NewRequest("GET", "/?page=1&per_page=2", nil)
 
-	users, paginationResponse, err := PaginateModel[TestUser](
-		db, c, "test_users", []string{"name", "email"},
+	users, paginationResponse, err := Paginate[TestUser](
+		db, newTestRequest("/?page=1&per_page=2"), WithTable("test_users"), WithSearchFields("name", "email"),
 	)
 
 	assert.NoError(t, err)
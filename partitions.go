@@ -0,0 +1,143 @@
+package pagination
+
+import (
+	"fmt"
+	"sort"
+
+	"gorm.io/gorm"
+)
+
+// PartitionStrategy controls how key range boundaries are chosen
+type PartitionStrategy string
+
+const (
+	// PartitionByMinMax splits [min, max] into equally sized key spans
+	PartitionByMinMax PartitionStrategy = "minmax"
+	// PartitionBySample picks boundaries from the quantiles of a key sample, balancing
+	// row counts when keys are sparse or skewed; the sample is read without sorting the
+	// table, see sampleKeys
+	PartitionBySample PartitionStrategy = "sample"
+)
+
+// PartitionOptions configures key range partitioning
+type PartitionOptions struct {
+	KeyColumn  string
+	Strategy   PartitionStrategy
+	SampleSize int
+	Dialect    DatabaseDialect
+	FilterFunc func(*gorm.DB) *gorm.DB
+}
+
+func (o *PartitionOptions) validate(db *gorm.DB) {
+	if o.KeyColumn == "" {
+		o.KeyColumn = "id"
+	}
+	if o.Strategy == "" {
+		o.Strategy = PartitionByMinMax
+	}
+	if o.SampleSize <= 0 {
+		o.SampleSize = 1000
+	}
+	if o.Dialect == "" {
+		o.Dialect = DetectDialect(db)
+	}
+}
+
+// Partitions returns n balanced primary key ranges for the model using min/max bounds
+// model may be a table name or a GORM model value.
+func Partitions(db *gorm.DB, model interface{}, n int) ([]KeyRange, error) {
+	return PartitionsWithOptions(db, model, n, PartitionOptions{})
+}
+
+// PartitionsWithOptions returns n primary key ranges using the configured strategy
+func PartitionsWithOptions(db *gorm.DB, model interface{}, n int, options PartitionOptions) ([]KeyRange, error) {
+	options.validate(db)
+
+	tableName, err := resolveTableName(db, model)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges, err := partitionKeyRanges(db, tableName, options.KeyColumn, n, options.FilterFunc)
+	if err != nil || options.Strategy != PartitionBySample || len(ranges) <= 1 {
+		return ranges, err
+	}
+
+	min, max := ranges[0].Start, ranges[len(ranges)-1].End
+	sample, err := sampleKeys(db, tableName, min, max, options)
+	if err != nil {
+		return nil, err
+	}
+	// Too few keys to place the boundaries, the equal spans are the better guess
+	if len(sample) < n {
+		return ranges, nil
+	}
+
+	return quantileKeyRanges(min, max, sample, n), nil
+}
+
+// sampleKeys reads about SampleSize keys of the filtered table between min and max without
+// sorting it. Dialects with TABLESAMPLE read a random share of the table, the others keep
+// the keys divisible by a step. Both are sized from the estimated row count, or from the
+// key span where the dialect has no statistics, so sparse keys may yield a smaller sample.
+func sampleKeys(db *gorm.DB, tableName string, min, max int64, options PartitionOptions) ([]int64, error) {
+	rows := max - min + 1
+	if estimate, ok, err := estimatedCount(db, tableName); err == nil && ok && estimate > 0 && estimate < rows {
+		rows = estimate
+	}
+
+	table, step := tableName, int64(1)
+	if rows > int64(options.SampleSize) {
+		if clause := tableSampleClause(options.Dialect, float64(options.SampleSize)/float64(rows)*100*sampleOversampling); clause != "" {
+			table += clause
+		} else {
+			step = (rows + int64(options.SampleSize) - 1) / int64(options.SampleSize)
+		}
+	}
+
+	query := db.Table(table)
+	if options.FilterFunc != nil {
+		query = options.FilterFunc(query)
+	}
+	if step > 1 {
+		query = query.Where(options.KeyColumn+" % ? = 0", step)
+	}
+
+	var sample []int64
+	if err := query.Pluck(options.KeyColumn, &sample).Error; err != nil {
+		return nil, fmt.Errorf("failed to sample keys: %w", err)
+	}
+	return sample, nil
+}
+
+// quantileKeyRanges builds contiguous ranges covering [min, max] with boundaries at sample quantiles
+func quantileKeyRanges(min, max int64, sample []int64, n int) []KeyRange {
+	sort.Slice(sample, func(i, j int) bool { return sample[i] < sample[j] })
+
+	ranges := make([]KeyRange, 0, n)
+	start := min
+
+	for i := 1; i < n && len(sample) > 0; i++ {
+		boundary := sample[i*len(sample)/n]
+		if boundary <= start || boundary > max {
+			continue
+		}
+		ranges = append(ranges, KeyRange{Start: start, End: boundary - 1})
+		start = boundary
+	}
+
+	return append(ranges, KeyRange{Start: start, End: max})
+}
+
+// resolveTableName returns the table name for a table name string or GORM model
+func resolveTableName(db *gorm.DB, model interface{}) (string, error) {
+	if tableName, ok := model.(string); ok {
+		return tableName, nil
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return "", fmt.Errorf("failed to parse model schema: %w", err)
+	}
+	return stmt.Schema.Table, nil
+}
@@ -0,0 +1,78 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestPartitions(t *testing.T) {
+	db := setupBenchmarkDB(100)
+
+	ranges, err := Partitions(db, &TestUser{}, 4)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []KeyRange{{1, 25}, {26, 50}, {51, 75}, {76, 100}}, ranges)
+}
+
+func TestPartitionsWithOptions_Sample(t *testing.T) {
+	db := setupBenchmarkDB(100)
+	db.Where("id BETWEEN ? AND ?", 10, 80).Delete(&TestUser{})
+
+	ranges, err := PartitionsWithOptions(db, "test_users", 3, PartitionOptions{
+		Strategy:   PartitionBySample,
+		SampleSize: 1000,
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, ranges, 3)
+	assert.Equal(t, int64(1), ranges[0].Start)
+	assert.Equal(t, int64(100), ranges[len(ranges)-1].End)
+	for i := 1; i < len(ranges); i++ {
+		assert.Equal(t, ranges[i-1].End+1, ranges[i].Start)
+	}
+
+	// Every range holds roughly a third of the 29 remaining rows
+	for _, keyRange := range ranges {
+		var count int64
+		db.Table("test_users").Where("id BETWEEN ? AND ?", keyRange.Start, keyRange.End).Count(&count)
+		assert.InDelta(t, 10, count, 2)
+	}
+}
+
+func TestDetectDialect(t *testing.T) {
+	assert.Equal(t, SQLite, DetectDialect(setupTestDB()))
+	assert.Equal(t, MySQL, DetectDialect(nil))
+}
+
+func TestPartitionsWithOptions_SampleWithoutSorting(t *testing.T) {
+	db := setupBenchmarkDB(1000)
+	db.Where("id BETWEEN ? AND ?", 101, 700).Delete(&TestUser{})
+
+	var statements []string
+	db.Callback().Query().After("gorm:query").Register("test:statements", func(tx *gorm.DB) {
+		statements = append(statements, tx.Statement.SQL.String())
+	})
+
+	ranges, err := PartitionsWithOptions(db, "test_users", 4, PartitionOptions{
+		Strategy:   PartitionBySample,
+		SampleSize: 50,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, ranges, 4)
+
+	// Keys divisible by the step stand in for the table, nothing sorts it
+	for _, statement := range statements {
+		assert.NotContains(t, statement, "RANDOM()")
+		assert.NotContains(t, statement, "ORDER BY")
+	}
+	assert.Contains(t, statements[len(statements)-1], "id % ? = 0")
+
+	// Every range holds roughly a quarter of the 400 remaining rows
+	for _, keyRange := range ranges {
+		var count int64
+		db.Table("test_users").Where("id BETWEEN ? AND ?", keyRange.Start, keyRange.End).Count(&count)
+		assert.InDelta(t, 100, count, 25)
+	}
+}
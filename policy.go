@@ -0,0 +1,184 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrPolicyDepth is returned by paginated queries reaching deeper than the MaxOffset of
+// the policy of their context
+var ErrPolicyDepth = errors.New("page is deeper than the policy allows")
+
+type policyKey struct{}
+
+// PagePolicy limits how a caller may page, e.g. anonymous callers against admins. The
+// policy of a request is resolved by PolicyHandler and enforced by the request
+// helpers while binding, clamping with warnings, and by PaginatedQueryWithOptions for any
+// query running with its context.
+type PagePolicy struct {
+	// Name identifies the policy in warnings, typically the role it applies to
+	Name string
+	// MaxPageSize caps per_page, replacing the global, table and endpoint maximums, and
+	// forbids is_disabled; zero leaves the endpoint limits
+	MaxPageSize int
+	// MaxOffset caps how many rows may precede a page; deeper pages are clamped while
+	// binding and fail with ErrPolicyDepth in queries
+	MaxOffset int
+	// AllowedIncludes restricts the includes a caller may request, matched case
+	// insensitively; nil allows every include of the builder
+	AllowedIncludes []string
+	// DisableCount replaces full counts with one stopping just past the page, so totals
+	// are lower bounds telling whether a next page exists
+	DisableCount bool
+	// CountLimit stops counts after this many rows, see PaginatedQueryOptions.CountLimit
+	CountLimit int64
+}
+
+// PolicyResolver returns the policy of a request, nil for none
+type PolicyResolver func(r *http.Request) *PagePolicy
+
+// RolePolicies resolves the policy of the role returned by role, falling back to the
+// policy of the empty role
+func RolePolicies(role func(r *http.Request) string, policies map[string]PagePolicy) PolicyResolver {
+	return func(r *http.Request) *PagePolicy {
+		policy, ok := policies[role(r)]
+		if !ok {
+			if policy, ok = policies[""]; !ok {
+				return nil
+			}
+		}
+		return &policy
+	}
+}
+
+// WithPolicy returns a context carrying policy, pass it to the queries with db.WithContext
+func WithPolicy(ctx context.Context, policy *PagePolicy) context.Context {
+	return context.WithValue(ctx, policyKey{}, policy)
+}
+
+// PolicyFromContext returns the policy carried by ctx
+func PolicyFromContext(ctx context.Context) (*PagePolicy, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+	policy, ok := ctx.Value(policyKey{}).(*PagePolicy)
+	return policy, ok && policy != nil
+}
+
+// PolicyHandler resolves the policy of each request into its context, which the
+// request helpers pass on to their queries
+func PolicyHandler(resolve PolicyResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if policy := resolve(r); policy != nil {
+				r = r.WithContext(WithPolicy(r.Context(), policy))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// maxPage returns the last page the policy allows at perPage rows, zero when unlimited
+func (p *PagePolicy) maxPage(perPage int) int {
+	if p.MaxOffset <= 0 || perPage <= 0 {
+		return 0
+	}
+	return p.MaxOffset/perPage + 1
+}
+
+// countLimit returns the count limit of the policy for pagination combined with limit,
+// the count limit configured otherwise
+func (p *PagePolicy) countLimit(pagination PaginationRequest, limit int64) int64 {
+	policyLimit := p.CountLimit
+	if p.DisableCount && !pagination.IsDisabled {
+		// One row past the page tells whether another page follows
+		policyLimit = int64(pagination.GetOffset() + pagination.GetLimit() + 1)
+	}
+	if policyLimit > 0 && (limit <= 0 || policyLimit < limit) {
+		return policyLimit
+	}
+	return limit
+}
+
+// allowsInclude reports whether the policy lets callers request include
+func (p *PagePolicy) allowsInclude(include string) bool {
+	if p.AllowedIncludes == nil {
+		return true
+	}
+	return slices.ContainsFunc(p.AllowedIncludes, func(allowed string) bool {
+		return strings.EqualFold(allowed, include)
+	})
+}
+
+// bindPolicy clamps bound pagination to the policy of ctx, reporting every adjustment
+func bindPolicy(ctx context.Context, pagination *PaginationRequest, warn func(param, value string, applied interface{}, message string)) {
+	policy, ok := PolicyFromContext(ctx)
+	if !ok {
+		return
+	}
+	pageParam, sizeParam := CurrentConfig().ParamStyle.pageParams()
+
+	if pagination.IsDisabled && policy.MaxPageSize > 0 {
+		pagination.IsDisabled = false
+		pagination.ClampPageSize(policy.MaxPageSize)
+		warn("is_disabled", "true", false, "is_disabled is not allowed"+policy.suffix())
+	}
+	if maxPage := policy.maxPage(pagination.PerPage); maxPage > 0 && pagination.Page > maxPage && !pagination.IsDisabled {
+		// Offset style requests name the row to start at rather than the page
+		position := func() int { return pagination.Page }
+		if CurrentConfig().ParamStyle == ParamStyleOffset {
+			position = pagination.GetOffset
+		}
+		value := position()
+		pagination.Page = maxPage
+		warn(pageParam, strconv.Itoa(value), position(), fmt.Sprintf("pages may start at most %d rows deep at %s %d%s", policy.MaxOffset, sizeParam, pagination.PerPage, policy.suffix()))
+	}
+}
+
+// enforcePolicy applies the policy of ctx to a query, returning the includes it allows
+func enforcePolicy(ctx context.Context, pagination *PaginationRequest, includes []string, options *PaginatedQueryOptions) ([]string, error) {
+	policy, ok := PolicyFromContext(ctx)
+	if !ok {
+		return includes, nil
+	}
+
+	if pagination.IsDisabled && policy.MaxPageSize > 0 {
+		pagination.IsDisabled = false
+		pagination.PerPage = policy.MaxPageSize
+	}
+	pagination.ClampPageSize(policy.MaxPageSize)
+	if maxPage := policy.maxPage(pagination.GetLimit()); maxPage > 0 && pagination.Page > maxPage && !pagination.IsDisabled {
+		return nil, fmt.Errorf("%w: at most %d rows may precede a page%s", ErrPolicyDepth, policy.MaxOffset, policy.suffix())
+	}
+	options.CountLimit = policy.countLimit(*pagination, options.CountLimit)
+
+	allowed := make([]string, 0, len(includes))
+	for _, include := range includes {
+		if policy.allowsInclude(include) {
+			allowed = append(allowed, include)
+		}
+	}
+	return allowed, nil
+}
+
+// requestCountLimit returns the count limit of the helpers for pagination: the
+// configured one, lowered by the policy of ctx
+func requestCountLimit(ctx context.Context, pagination PaginationRequest) int64 {
+	limit := CurrentConfig().CountLimit
+	if policy, ok := PolicyFromContext(ctx); ok {
+		return policy.countLimit(pagination, limit)
+	}
+	return limit
+}
+
+func (p *PagePolicy) suffix() string {
+	if p.Name == "" {
+		return ""
+	}
+	return " for " + p.Name
+}
@@ -0,0 +1,69 @@
+package pagination
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testPolicies = RolePolicies(func(r *http.Request) string { return r.Header.Get("X-Role") }, map[string]PagePolicy{
+	"admin": {Name: "admin", MaxPageSize: 500},
+	"":      {Name: "anonymous", MaxPageSize: 2, MaxOffset: 4, DisableCount: true},
+})
+
+func TestPolicyHandler(t *testing.T) {
+	db := setupTestDB()
+	var response PaginatedResponse
+	router := PolicyHandler(testPolicies)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		response = PaginateResponse[TestUser](db, r, "ok", WithTable("test_users"))
+	}))
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users?per_page=50&page=9", nil))
+	assert.Equal(t, 2, response.Pagination.PerPage)
+	assert.Equal(t, 3, response.Pagination.Page, "pages deeper than MaxOffset are clamped")
+	assert.Equal(t, []string{"Charlie Wilson"}, userNames(response.Data.([]TestUser)))
+	if assert.Len(t, response.Pagination.Warnings, 2) {
+		assert.Equal(t, "per_page", response.Pagination.Warnings[0].Param)
+		assert.Equal(t, PaginationWarning{Param: "page", Value: "9", Applied: "3", Message: "pages may start at most 4 rows deep at per_page 2 for anonymous"}, response.Pagination.Warnings[1])
+	}
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users?per_page=2&is_disabled=true", nil))
+	assert.False(t, response.Pagination.IsDisabled)
+	assert.Len(t, response.Data, 2)
+	assert.Equal(t, int64(3), response.Pagination.Total, "the count stops one row past the page")
+	assert.Equal(t, TotalRelationGTE, response.Pagination.TotalRelation)
+	assert.Equal(t, int64(2), response.Pagination.MaxPage)
+
+	admin := httptest.NewRequest("GET", "/users?per_page=250", nil)
+	admin.Header.Set("X-Role", "admin")
+	router.ServeHTTP(httptest.NewRecorder(), admin)
+	assert.Equal(t, 250, response.Pagination.PerPage, "the admin policy exceeds the global maximum")
+	assert.Equal(t, int64(5), response.Pagination.Total)
+	assert.Empty(t, response.Pagination.TotalRelation)
+}
+
+func TestPaginatedQueryWithOptions_Policy(t *testing.T) {
+	db := setupTeamsDB()
+	policy := &PagePolicy{MaxPageSize: 1, MaxOffset: 1, AllowedIncludes: []string{}}
+	scoped := db.WithContext(WithPolicy(context.Background(), policy))
+	builder := NewSimpleQueryBuilder("test_members").WithDialect(SQLite)
+
+	members, _, err := PaginatedQueryWithOptions[TestMember](scoped, builder, PaginationRequest{Page: 1, PerPage: 10}, []string{"Team"}, PaginatedQueryOptions{Dialect: SQLite})
+	assert.NoError(t, err)
+	if assert.Len(t, members, 1, "the page size is clamped") {
+		assert.Nil(t, members[0].Team, "includes outside AllowedIncludes are dropped")
+	}
+
+	_, _, err = PaginatedQueryWithOptions[TestMember](scoped, builder, PaginationRequest{Page: 3, PerPage: 1}, nil, PaginatedQueryOptions{Dialect: SQLite})
+	assert.ErrorIs(t, err, ErrPolicyDepth)
+
+	policy.AllowedIncludes = []string{"team"}
+	members, _, err = PaginatedQueryWithOptions[TestMember](scoped, builder, PaginationRequest{Page: 2, PerPage: 1}, []string{"Team"}, PaginatedQueryOptions{Dialect: SQLite})
+	assert.NoError(t, err)
+	if assert.Len(t, members, 1) {
+		assert.NotNil(t, members[0].Team)
+	}
+}
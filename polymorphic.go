@@ -0,0 +1,147 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// PolymorphicFilter restricts a polymorphic association to one owner type and optional ids
+// Columns follow the GORM convention <Name>_type and <Name>_id.
+type PolymorphicFilter struct {
+	Name string
+	Type string
+	IDs  []string
+}
+
+// BindPolymorphicFilterValues reads ?<name>_type=athlete&<name>_id=1,2 or the short form
+// ?<name>=athlete:1; types maps public type names to stored values, nil accepts any type.
+// ok is false when the query string does not filter on the association.
+func BindPolymorphicFilterValues(query url.Values, name string, types map[string]string) (filter PolymorphicFilter, ok bool, err error) {
+	filter = PolymorphicFilter{Name: name}
+
+	typeName, ids := query.Get(name+"_type"), query.Get(name+"_id")
+	if short := query.Get(name); short != "" && typeName == "" {
+		typeName, ids, _ = strings.Cut(short, ":")
+	}
+	if typeName == "" {
+		if ids != "" {
+			return filter, false, &FilterBindingError{Err: fmt.Errorf("%s_id requires %s_type", name, name)}
+		}
+		return filter, false, nil
+	}
+
+	filter.Type = typeName
+	if types != nil {
+		stored, known := types[typeName]
+		if !known {
+			return filter, false, &FilterBindingError{Err: fmt.Errorf("unknown %s type %q", name, typeName)}
+		}
+		filter.Type = stored
+	}
+
+	for _, id := range strings.Split(ids, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			filter.IDs = append(filter.IDs, id)
+		}
+	}
+
+	return filter, true, nil
+}
+
+// Apply adds the owner type and id conditions to the query
+func (f PolymorphicFilter) Apply(query *gorm.DB) *gorm.DB {
+	if f.Type == "" || !isValidSortField(f.Name) {
+		return query
+	}
+
+	query = query.Where(f.Name+"_type = ?", f.Type)
+	if len(f.IDs) == 1 {
+		return query.Where(f.Name+"_id = ?", f.IDs[0])
+	}
+	if len(f.IDs) > 1 {
+		return query.Where(f.Name+"_id IN ?", f.IDs)
+	}
+	return query
+}
+
+// PolymorphicOwnerFetcher loads the owners of one type by id
+type PolymorphicOwnerFetcher[K comparable] func(ctx context.Context, ids []K) (map[K]interface{}, error)
+
+// FetchPolymorphicOwners creates a fetcher loading owners of model M by idColumn
+func FetchPolymorphicOwners[M any, K comparable](db *gorm.DB, idColumn string) PolymorphicOwnerFetcher[K] {
+	return func(ctx context.Context, ids []K) (map[K]interface{}, error) {
+		owners, err := FetchByIDs[M](db.WithContext(ctx), ids, BatchByIDsOptions{IDColumn: idColumn})
+		if err != nil {
+			return nil, err
+		}
+
+		byID := make(map[string]interface{}, len(owners))
+		for i := range owners {
+			id, err := columnValue(db, &owners[i], idColumn)
+			if err != nil {
+				return nil, err
+			}
+			byID[fmt.Sprint(id)] = owners[i]
+		}
+
+		found := make(map[K]interface{}, len(owners))
+		for _, id := range ids {
+			if owner, ok := byID[fmt.Sprint(id)]; ok {
+				found[id] = owner
+			}
+		}
+		return found, nil
+	}
+}
+
+// PolymorphicInclude creates an IncludeLoader attaching the owner of each row of a
+// polymorphic table, with one batched fetch per owner type present on the page
+func PolymorphicInclude[T any, K comparable](
+	owner func(row T) (ownerType string, id K),
+	fetchers map[string]PolymorphicOwnerFetcher[K],
+	assign func(row *T, owner interface{}),
+) IncludeLoader {
+	return func(ctx context.Context, rows interface{}) error {
+		page, ok := rows.(*[]T)
+		if !ok {
+			return fmt.Errorf("include loader expects *[]%T, got %T", *new(T), rows)
+		}
+
+		idsByType := make(map[string][]K)
+		seen := make(map[string]map[K]bool)
+		for _, row := range *page {
+			ownerType, id := owner(row)
+			if fetchers[ownerType] == nil {
+				continue
+			}
+			if seen[ownerType] == nil {
+				seen[ownerType] = make(map[K]bool)
+			}
+			if !seen[ownerType][id] {
+				seen[ownerType][id] = true
+				idsByType[ownerType] = append(idsByType[ownerType], id)
+			}
+		}
+
+		owners := make(map[string]map[K]interface{}, len(idsByType))
+		for ownerType, ids := range idsByType {
+			found, err := fetchers[ownerType](ctx, ids)
+			if err != nil {
+				return fmt.Errorf("failed to load %s owners: %w", ownerType, err)
+			}
+			owners[ownerType] = found
+		}
+
+		for i := range *page {
+			ownerType, id := owner((*page)[i])
+			if found, ok := owners[ownerType][id]; ok {
+				assign(&(*page)[i], found)
+			}
+		}
+		return nil
+	}
+}
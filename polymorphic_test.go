@@ -0,0 +1,82 @@
+package pagination
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestComment struct {
+	ID              uint        `json:"id"`
+	CommentableType string      `json:"commentable_type"`
+	CommentableID   uint        `json:"commentable_id"`
+	Body            string      `json:"body"`
+	Commentable     interface{} `json:"commentable,omitempty" gorm:"-"`
+}
+
+func TestBindPolymorphicFilter(t *testing.T) {
+	types := map[string]string{"user": "test_users", "team": "test_teams"}
+
+	filter, ok, err := BindPolymorphicFilterValues(newTestRequest("/comments?commentable_type=user&commentable_id=1,2").URL.Query(), "commentable", types)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, PolymorphicFilter{Name: "commentable", Type: "test_users", IDs: []string{"1", "2"}}, filter)
+
+	filter, ok, err = BindPolymorphicFilterValues(newTestRequest("/comments?commentable=team:7").URL.Query(), "commentable", types)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"7"}, filter.IDs)
+
+	_, ok, err = BindPolymorphicFilterValues(newTestRequest("/comments").URL.Query(), "commentable", types)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	_, _, err = BindPolymorphicFilterValues(newTestRequest("/comments?commentable_type=admin").URL.Query(), "commentable", types)
+	var bindingErr *FilterBindingError
+	assert.ErrorAs(t, err, &bindingErr)
+
+	_, _, err = BindPolymorphicFilterValues(newTestRequest("/comments?commentable_id=1").URL.Query(), "commentable", types)
+	assert.Error(t, err)
+}
+
+func TestPolymorphicFilterAndInclude(t *testing.T) {
+	db := setupTestDB()
+	db.AutoMigrate(&TestComment{}, &TestTeam{})
+	db.Create(&TestTeam{Name: "Red"})
+	db.Create(&[]TestComment{
+		{CommentableType: "test_users", CommentableID: 1, Body: "on john"},
+		{CommentableType: "test_teams", CommentableID: 1, Body: "on red"},
+		{CommentableType: "test_users", CommentableID: 2, Body: "on jane"},
+		{CommentableType: "legacy", CommentableID: 9, Body: "unknown owner"},
+	})
+
+	loader := PolymorphicInclude(
+		func(c TestComment) (string, uint) { return c.CommentableType, c.CommentableID },
+		map[string]PolymorphicOwnerFetcher[uint]{
+			"test_users": FetchPolymorphicOwners[TestUser, uint](db, "id"),
+			"test_teams": FetchPolymorphicOwners[TestTeam, uint](db, "id"),
+		},
+		func(c *TestComment, owner interface{}) { c.Commentable = owner },
+	)
+	builder := NewSimpleQueryBuilder("test_comments").WithIncludeLoader("Commentable", loader)
+
+	data, total, err := PaginatedQuery[TestComment](db, builder, PaginationRequest{Page: 1, PerPage: 10}, []string{"Commentable"})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), total)
+	assert.Equal(t, "John Doe", data[0].Commentable.(TestUser).Name)
+	assert.Equal(t, "Red", data[1].Commentable.(TestTeam).Name)
+	assert.Equal(t, "Jane Smith", data[2].Commentable.(TestUser).Name)
+	assert.Nil(t, data[3].Commentable)
+
+	filter, _, err := BindPolymorphicFilterValues(newTestRequest("/comments?commentable=users:1,2").URL.Query(), "commentable", map[string]string{"users": "test_users"})
+	assert.NoError(t, err)
+	builder = NewSimpleQueryBuilder("test_comments").WithFilters(filter.Apply)
+	data, total, err = PaginatedQuery[TestComment](db, builder, PaginationRequest{Page: 1, PerPage: 10}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+	assert.Len(t, data, 2)
+
+	_, err = FetchPolymorphicOwners[TestUser, uint](db, "id")(context.Background(), nil)
+	assert.NoError(t, err)
+}
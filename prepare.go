@@ -0,0 +1,84 @@
+package pagination
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// stableLimitClause replaces LIMIT in the clauses of data queries running as prepared
+// statements; the SQLite and SQL Server drivers write LIMIT and OFFSET as literals,
+// which would prepare a new statement for every page
+const stableLimitClause = "PAGINATION:LIMIT"
+
+// stableLimit binds the page window as parameters and always renders its offset,
+// so every page of a query shape shares one SQL string
+type stableLimit struct {
+	dialect DatabaseDialect
+	limit   int
+	offset  int
+}
+
+// Name implements clause.Interface
+func (stableLimit) Name() string {
+	return stableLimitClause
+}
+
+// Build implements clause.Expression
+func (l stableLimit) Build(builder clause.Builder) {
+	if l.dialect == SQLServer || l.dialect == Oracle {
+		builder.WriteString("OFFSET ")
+		builder.AddVar(builder, l.offset)
+		builder.WriteString(" ROWS FETCH NEXT ")
+		builder.AddVar(builder, l.limit)
+		builder.WriteString(" ROWS ONLY")
+		return
+	}
+	builder.WriteString("LIMIT ")
+	builder.AddVar(builder, l.limit)
+	builder.WriteString(" OFFSET ")
+	builder.AddVar(builder, l.offset)
+}
+
+// MergeClause implements clause.Interface
+func (l stableLimit) MergeClause(c *clause.Clause) {
+	c.Name = ""
+	c.Expression = l
+}
+
+// preparedSession runs the queries of db as cached prepared statements, shared by every
+// session of the connection
+func preparedSession(db *gorm.DB) *gorm.DB {
+	return db.Session(&gorm.Session{PrepareStmt: true})
+}
+
+// stablePage applies the page window to a prepared data query
+func stablePage(query *gorm.DB, offset, limit int) *gorm.DB {
+	query = query.Clauses(stableLimit{
+		dialect: DetectDialect(query),
+		limit:   limit,
+		offset:  offset,
+	})
+	return replaceBuildClause(query, "LIMIT", stableLimitClause)
+}
+
+// replaceBuildClause builds the clause named with in place of the clause named name;
+// an empty name appends it
+func replaceBuildClause(query *gorm.DB, name, with string) *gorm.DB {
+	clauses := query.Statement.BuildClauses
+	if len(clauses) == 0 {
+		clauses = query.Callback().Query().Clauses
+	}
+
+	buildClauses := make([]string, 0, len(clauses)+1)
+	for _, clauseName := range clauses {
+		if clauseName == name {
+			clauseName = with
+		}
+		buildClauses = append(buildClauses, clauseName)
+	}
+	if name == "" {
+		buildClauses = append(buildClauses, with)
+	}
+	query.Statement.BuildClauses = buildClauses
+	return query
+}
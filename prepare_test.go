@@ -0,0 +1,78 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func preparedStatements(t *testing.T, db *gorm.DB) int {
+	stmtDB, ok := db.ConnPool.(*gorm.PreparedStmtDB)
+	assert.True(t, ok)
+	stmtDB.Mux.RLock()
+	defer stmtDB.Mux.RUnlock()
+	return len(stmtDB.Stmts)
+}
+
+func TestPaginatedQuery_PrepareStatementsReuseAcrossPages(t *testing.T) {
+	builder := NewSimpleQueryBuilder("test_users")
+
+	paginate := func(options PaginatedQueryOptions) ([]string, int) {
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{PrepareStmt: true})
+		assert.NoError(t, err)
+		assert.NoError(t, db.AutoMigrate(&TestUser{}))
+		for _, name := range []string{"Ann", "Ben", "Cid", "Dee", "Eve"} {
+			db.Create(&TestUser{Name: name, Email: name + "@example.com", Age: 30})
+		}
+
+		before := preparedStatements(t, db)
+		var names []string
+		for page := 1; page <= 3; page++ {
+			users, total, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: page, PerPage: 2}, nil, options)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(5), total)
+			names = append(names, userNames(users)...)
+		}
+		return names, preparedStatements(t, db) - before
+	}
+
+	names, prepared := paginate(PaginatedQueryOptions{Dialect: SQLite})
+	assert.Equal(t, []string{"Ann", "Ben", "Cid", "Dee", "Eve"}, names)
+	assert.Equal(t, 4, prepared, "inlined windows prepare one statement per page")
+
+	names, prepared = paginate(PaginatedQueryOptions{Dialect: SQLite, PrepareStatements: true})
+	assert.Equal(t, []string{"Ann", "Ben", "Cid", "Dee", "Eve"}, names)
+	assert.Equal(t, 2, prepared, "one count and one data statement serve every page")
+}
+
+func TestPaginatedQuery_PrepareStatementsStableSQL(t *testing.T) {
+	db := setupTestDB()
+	statements := captureSQL(db)
+	builder := NewSimpleQueryBuilder("test_users").WithFilters(func(query *gorm.DB) *gorm.DB {
+		return query.Where("age > ?", 26)
+	})
+	options := PaginatedQueryOptions{Dialect: SQLite, PrepareStatements: true}
+
+	first, _, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil, options)
+	assert.NoError(t, err)
+	second, total, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 2, PerPage: 2}, nil, options)
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(4), total)
+	assert.Equal(t, []string{"Jane Smith", "Bob Johnson"}, userNames(first))
+	assert.Equal(t, []string{"Alice Brown", "Charlie Wilson"}, userNames(second))
+
+	assert.Len(t, *statements, 4)
+	assert.Equal(t, (*statements)[0], (*statements)[2], "pages differ only in their parameters")
+	assert.Contains(t, (*statements)[0], "LIMIT ? OFFSET ?")
+}
+
+func TestPaginatedQuery_PrepareStatementsDisabledPagination(t *testing.T) {
+	db := setupTestDB()
+	users, total, err := PaginatedQueryWithOptions[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 1, PerPage: 2, IsDisabled: true}, nil, PaginatedQueryOptions{Dialect: SQLite, PrepareStatements: true})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.Len(t, users, 5)
+}
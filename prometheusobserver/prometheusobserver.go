@@ -0,0 +1,66 @@
+// Package prometheusobserver exports the queries of pagination as Prometheus metrics:
+//
+//	pagination_query_duration_seconds{kind,table}  histogram of count and data queries
+//	pagination_query_errors_total{kind,table}      failed queries
+//	pagination_cache_hits_total{kind,table,cache}  queries answered by a cache
+package prometheusobserver
+
+import (
+	"context"
+	"time"
+
+	pagination "github.com/Caknoooo/go-pagination"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Observer is a pagination.Observer recording Prometheus metrics
+type Observer struct {
+	duration  *prometheus.HistogramVec
+	errors    *prometheus.CounterVec
+	cacheHits *prometheus.CounterVec
+}
+
+// New registers the metrics with registerer, prometheus.DefaultRegisterer when nil
+func New(registerer prometheus.Registerer) (*Observer, error) {
+	if registerer == nil {
+		registerer = prometheus.DefaultRegisterer
+	}
+
+	o := &Observer{
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "pagination_query_duration_seconds",
+			Help:    "Duration of the count and data queries of paginated listings.",
+			Buckets: prometheus.DefBuckets,
+		}, []string{"kind", "table"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pagination_query_errors_total",
+			Help: "Count and data queries of paginated listings that failed.",
+		}, []string{"kind", "table"}),
+		cacheHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pagination_cache_hits_total",
+			Help: "Count and data queries of paginated listings answered by a cache.",
+		}, []string{"kind", "table", "cache"}),
+	}
+	for _, collector := range []prometheus.Collector{o.duration, o.errors, o.cacheHits} {
+		if err := registerer.Register(collector); err != nil {
+			return nil, err
+		}
+	}
+	return o, nil
+}
+
+func (o *Observer) OnQueryStart(ctx context.Context, event pagination.QueryEvent) context.Context {
+	return ctx
+}
+
+func (o *Observer) OnQueryEnd(ctx context.Context, event pagination.QueryEvent, duration time.Duration) {
+	o.duration.WithLabelValues(event.Kind, event.Table).Observe(duration.Seconds())
+}
+
+func (o *Observer) OnCacheHit(ctx context.Context, event pagination.QueryEvent) {
+	o.cacheHits.WithLabelValues(event.Kind, event.Table, event.Cache).Inc()
+}
+
+func (o *Observer) OnError(ctx context.Context, event pagination.QueryEvent, err error) {
+	o.errors.WithLabelValues(event.Kind, event.Table).Inc()
+}
@@ -0,0 +1,37 @@
+package prometheusobserver
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	pagination "github.com/Caknoooo/go-pagination"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObserver(t *testing.T) {
+	registry := prometheus.NewRegistry()
+	observer, err := New(registry)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	event := pagination.QueryEvent{Kind: "count", Table: "users"}
+	observer.OnQueryEnd(ctx, event, 20*time.Millisecond)
+	observer.OnError(ctx, event, errors.New("timeout"))
+	observer.OnCacheHit(ctx, pagination.QueryEvent{Kind: "data", Table: "users", Cache: "page"})
+
+	assert.Equal(t, 1, testutil.CollectAndCount(observer.duration))
+	assert.NoError(t, testutil.CollectAndCompare(observer.errors, strings.NewReader(`
+# HELP pagination_query_errors_total Count and data queries of paginated listings that failed.
+# TYPE pagination_query_errors_total counter
+pagination_query_errors_total{kind="count",table="users"} 1
+`)))
+	assert.Equal(t, 1.0, testutil.ToFloat64(observer.cacheHits.WithLabelValues("data", "users", "page")))
+
+	_, err = New(registry)
+	assert.Error(t, err, "metrics are registered once per registry")
+}
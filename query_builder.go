@@ -1,8 +1,12 @@
 package pagination
 
 import (
+	"context"
 	"fmt"
+	"reflect"
+	"slices"
 	"strings"
+	"time"
 
 	"gorm.io/gorm"
 )
@@ -21,6 +25,40 @@ type IncludableQueryBuilder interface {
 	Validate()
 }
 
+// SortDirectionProvider is implemented by filters declaring the direction of sort fields
+// requested without a sign or order, e.g. {"created_at": "desc", "name": "asc"}
+type SortDirectionProvider interface {
+	GetSortDirections() map[string]string
+}
+
+// SortPresetProvider is implemented by builders offering named orderings such as
+// ?sort=newest, keeping product-defined orderings and column names out of the API
+type SortPresetProvider interface {
+	GetSortPresets() map[string]string
+}
+
+// sortClause returns the ordering of a listing: the preset named by the sort parameter,
+// the requested fields, or the default sort. Presets fix their own directions; builders
+// implementing SortableFieldsProvider only sort by the fields they declare.
+func sortClause(builder QueryBuilder, pagination PaginationRequest) string {
+	if provider, ok := builder.(SortPresetProvider); ok && pagination.Sort != "" {
+		if preset, ok := provider.GetSortPresets()[pagination.Sort]; ok {
+			return preset
+		}
+	}
+	// Validate sort fields to prevent SQL injection
+	if pagination.Sort != "" && isValidSort(pagination.Sort) {
+		fields := pagination.SortFields()
+		if provider, ok := builder.(SortableFieldsProvider); ok {
+			fields = allowedSortFields(fields, provider.GetSortableFields())
+		}
+		if len(fields) > 0 {
+			return sortFieldsClause(fields)
+		}
+	}
+	return builder.GetDefaultSort()
+}
+
 type AllowedIncludesProvider interface {
 	GetAllowedIncludes() map[string]bool
 }
@@ -36,34 +74,9 @@ type QueryLayerBuilder interface {
 	DatabaseProvider
 }
 
-// applyAutoSearch applies search automatically based on provided search fields
-func applyAutoSearch(query *gorm.DB, searchTerm string, searchFields []string, dialect DatabaseDialect) *gorm.DB {
-	if len(searchFields) == 0 || searchTerm == "" {
-		return query
-	}
-
-	searchPattern := "%" + searchTerm + "%"
-	operator := getSearchOperator(dialect)
-
-	if len(searchFields) == 1 {
-		return query.Where(searchFields[0]+" "+operator+" ?", searchPattern)
-	}
-
-	conditions := make([]string, len(searchFields))
-	args := make([]interface{}, len(searchFields))
-
-	for i, field := range searchFields {
-		conditions[i] = field + " " + operator + " ?"
-		args[i] = searchPattern
-	}
-
-	whereClause := "(" + strings.Join(conditions, " OR ") + ")"
-	return query.Where(whereClause, args...)
-}
-
 func getSearchOperator(dialect DatabaseDialect) string {
 	switch dialect {
-	case PostgreSQL:
+	case PostgreSQL, ClickHouse:
 		return "ILIKE"
 	case MySQL, SQLite, SQLServer:
 		return "LIKE"
@@ -80,13 +93,164 @@ const (
 	PostgreSQL DatabaseDialect = "postgresql"
 	SQLite     DatabaseDialect = "sqlite"
 	SQLServer  DatabaseDialect = "sqlserver"
+	Oracle     DatabaseDialect = "oracle"
+	ClickHouse DatabaseDialect = "clickhouse"
 )
 
+// DetectDialect returns the DatabaseDialect of the GORM connection, defaulting to MySQL
+func DetectDialect(db *gorm.DB) DatabaseDialect {
+	if db == nil || db.Dialector == nil {
+		return MySQL
+	}
+
+	switch db.Dialector.Name() {
+	case "postgres", "postgresql":
+		return PostgreSQL
+	case "sqlite", "sqlite3":
+		return SQLite
+	case "sqlserver", "mssql":
+		return SQLServer
+	case "oracle", "godror":
+		return Oracle
+	case "clickhouse":
+		return ClickHouse
+	default:
+		return MySQL
+	}
+}
+
+func getRandomFunction(dialect DatabaseDialect) string {
+	switch dialect {
+	case PostgreSQL, SQLite:
+		return "RANDOM()"
+	case SQLServer:
+		return "NEWID()"
+	case Oracle:
+		return "DBMS_RANDOM.VALUE"
+	case ClickHouse:
+		return "rand()"
+	default:
+		return "RAND()"
+	}
+}
+
+// RequestContextProvider is implemented by filters remembering the context of the
+// request they were bound from, such as those embedding BaseFilter
+type RequestContextProvider interface {
+	RequestContext() context.Context
+}
+
 // PaginatedQueryOptions provides configuration for paginated queries
 type PaginatedQueryOptions struct {
 	Dialect          DatabaseDialect
 	EnableSoftDelete bool
 	CustomCountQuery string
+	Hints            QueryHints
+
+	// Context cancels the count and data queries once done, typically the request
+	// context so queries stop when the client disconnects; by default the context of
+	// db is used, see PaginatedQueryContext
+	Context context.Context
+
+	// StatementTimeout is enforced by the database itself (MySQL MAX_EXECUTION_TIME,
+	// PostgreSQL statement_timeout) as a last line of defense next to context deadlines
+	StatementTimeout time.Duration
+
+	// QueryTags returns sqlcommenter tags (route, request ID, controller) appended to the
+	// generated SQL; requires the QueryCommenter plugin to be registered on the connection
+	QueryTags func(ctx context.Context) map[string]string
+
+	// TotalCache reuses COUNT results for identical filters; with AsyncTotal a cache miss
+	// returns TotalPending immediately and the count runs in the background
+	TotalCache *TotalCache
+	AsyncTotal bool
+
+	// SingleFlight lets concurrent identical requests on the same connection pool share
+	// one COUNT and one data query; queries inside transactions are never shared. The
+	// shared query outlives the callers' contexts up to SingleFlightTimeout (default 30s),
+	// while each caller stops waiting once its own context ends.
+	SingleFlight        bool
+	SingleFlightTimeout time.Duration
+	// SingleFlightKey further separates shared queries, e.g. by tenant when the SQL alone
+	// does not tell them apart
+	SingleFlightKey string
+
+	// TotalReuse skips the COUNT when the client echoes the total token of an earlier page
+	TotalReuse *TotalReuse
+
+	// DisableJoinDeduplication keeps the rows repeated by filter joins; by default joined
+	// queries count DISTINCT primary keys and select distinct rows
+	DisableJoinDeduplication bool
+
+	// Rewriter replaces filter predicates that defeat indexes and reports the rewrites
+	Rewriter *QueryRewriter
+
+	// RowNumPaging pages with ROWNUM for Oracle versions before 12c, which lack OFFSET ... FETCH
+	RowNumPaging bool
+
+	// PrepareStatements runs both queries as prepared statements cached on the connection
+	// (GORM PrepareStmt) and binds the page window as parameters, so every page of a
+	// listing reuses the same two statements
+	PrepareStatements bool
+
+	// PageCache serves the rows of pages fetched before
+	PageCache *PageCache
+	// Prefetch fetches the next page into PageCache in the background after a full page,
+	// so sequential paging is served from memory
+	Prefetch bool
+
+	// Dedup drops rows the previous page already showed when the client echoes its
+	// boundary token, see BoundaryDedup
+	Dedup *BoundaryDedup
+
+	// Visibility hides the rows a per-row predicate rejects and refills the page with
+	// the rows after it, see Visibility
+	Visibility *Visibility
+	// VisibilityReport carries the position of the listing between pages and receives
+	// the rows hidden, see VisibilityReport
+	VisibilityReport *VisibilityReport
+
+	// Observer is told about every count and data query and the cache hits replacing
+	// them, see Observer
+	Observer Observer
+
+	// IncludeReport receives the rows loaded per include and whether include limits
+	// truncated them, see IncludeLimitsProvider
+	IncludeReport *IncludeReport
+
+	// Checksum hashes the keys and versions of the returned rows, see PageChecksum
+	Checksum *PageChecksum
+
+	// AsOf pins the listing to the rows that existed when its first page was read, once
+	// the client echoes the as_of_token, see HighWaterMark
+	AsOf *HighWaterMark
+
+	// MaxPageSize caps the page size of this query; build the meta from a request
+	// clamped with ClampPageSize so per_page matches the rows returned
+	MaxPageSize int
+
+	// CountLimit stops counting after this many rows, bounding the cost of counts over
+	// huge tables; a total equal to it is a lower bound, see CalculateCappedPagination
+	CountLimit int64
+
+	// CountMode skips the count or estimates it from table statistics, see CountMode
+	CountMode CountMode
+	// CountReport receives the count mode the query used
+	CountReport *CountReport
+
+	// AlwaysCount runs the count on every page; by default a first page shorter than the
+	// page size gives the total without it
+	AlwaysCount bool
+
+	// rowLimit caps the rows fetched, set from the Budget of the context
+	rowLimit int
+
+	// Retry reruns the count and data queries on transient errors; inside the
+	// transaction of PostgreSQL session settings the whole transaction is rerun
+	Retry *RetryPolicy
+
+	// stream reads the data query instead of Find, see StreamPaginatedResponse
+	stream func(query *gorm.DB, total int64) error
 }
 
 func PaginatedQuery[T any](
@@ -100,10 +264,44 @@ func PaginatedQuery[T any](
 	})
 }
 
+// PaginatedQueryContext is PaginatedQuery running its queries with ctx
+func PaginatedQueryContext[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	builder QueryBuilder,
+	pagination PaginationRequest,
+	includes []string,
+) ([]T, int64, error) {
+	return PaginatedQueryWithOptions[T](db, builder, pagination, includes, PaginatedQueryOptions{
+		Dialect: MySQL, // Default to MySQL for backward compatibility
+		Context: ctx,
+	})
+}
+
 // PaginatedQueryWithIncludable handles queries with includable query builders
 func PaginatedQueryWithIncludable[T any](
 	db *gorm.DB,
 	builder IncludableQueryBuilder,
+) ([]T, int64, error) {
+	return paginatedQueryWithIncludable[T](nil, db, builder)
+}
+
+// PaginatedQueryWithIncludableContext is PaginatedQueryWithIncludable running its
+// queries with ctx
+func PaginatedQueryWithIncludableContext[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	builder IncludableQueryBuilder,
+) ([]T, int64, error) {
+	return paginatedQueryWithIncludable[T](ctx, db, builder)
+}
+
+// paginatedQueryWithIncludable runs the query of an includable builder with ctx, or
+// the context of db when nil
+func paginatedQueryWithIncludable[T any](
+	ctx context.Context,
+	db *gorm.DB,
+	builder IncludableQueryBuilder,
 ) ([]T, int64, error) {
 	// If db is nil, try to get it from the builder (for query layer pattern)
 	if db == nil {
@@ -113,9 +311,16 @@ func PaginatedQueryWithIncludable[T any](
 			return nil, 0, fmt.Errorf("database connection not provided")
 		}
 	}
+	// Filters bound from a request stop their queries when the client disconnects
+	if provider, ok := builder.(RequestContextProvider); ok && ctx == nil {
+		ctx = provider.RequestContext()
+	}
 
 	// Validate the builder
 	builder.Validate()
+	if err := checkIncludeLimit(builder); err != nil {
+		return nil, 0, &FilterBindingError{Err: err}
+	}
 
 	// Get pagination and includes from the builder
 	pagination := builder.GetPagination()
@@ -123,6 +328,7 @@ func PaginatedQueryWithIncludable[T any](
 
 	return PaginatedQueryWithOptions[T](db, builder, pagination, includes, PaginatedQueryOptions{
 		Dialect: MySQL, // Default to MySQL for backward compatibility
+		Context: ctx,
 	})
 }
 
@@ -132,8 +338,15 @@ func PaginatedQueryWithIncludableAndOptions[T any](
 	builder IncludableQueryBuilder,
 	options PaginatedQueryOptions,
 ) ([]T, int64, error) {
+	if provider, ok := builder.(RequestContextProvider); ok && options.Context == nil {
+		options.Context = provider.RequestContext()
+	}
+
 	// Validate the builder
 	builder.Validate()
+	if err := checkIncludeLimit(builder); err != nil {
+		return nil, 0, &FilterBindingError{Err: err}
+	}
 
 	// Get pagination and includes from the builder
 	pagination := builder.GetPagination()
@@ -148,71 +361,424 @@ func PaginatedQueryWithOptions[T any](
 	pagination PaginationRequest,
 	includes []string,
 	options PaginatedQueryOptions,
+) ([]T, int64, error) {
+	pagination.ClampPageSize(options.MaxPageSize)
+	if err := options.Visibility.checkRowType(reflect.TypeOf((*T)(nil)).Elem()); err != nil {
+		return nil, 0, err
+	}
+	if options.Context != nil {
+		db = db.WithContext(options.Context)
+	}
+	includes, err := enforcePolicy(db.Statement.Context, &pagination, includes, &options)
+	if err != nil {
+		return nil, 0, err
+	}
+	if budget, ok := BudgetFromContext(db.Statement.Context); ok {
+		return budgetedQuery[T](db, budget, builder, pagination, includes, options)
+	}
+	return paginatedQuery[T](db, builder, pagination, includes, options)
+}
+
+func paginatedQuery[T any](
+	db *gorm.DB,
+	builder QueryBuilder,
+	pagination PaginationRequest,
+	includes []string,
+	options PaginatedQueryOptions,
+) ([]T, int64, error) {
+	hints := resolveQueryHints(db, builder, options)
+
+	started := time.Now()
+	defer func() {
+		queryShapes.record(queryShapeOf(builder, pagination), time.Since(started))
+	}()
+
+	// Session settings only exist for the lifetime of a transaction
+	if len(hints.Settings) > 0 && DetectDialect(db) == PostgreSQL {
+		var result []T
+		var totalCount int64
+		// A failed statement aborts the transaction, so retries rerun all of it
+		retry := options.Retry
+		options.Retry = nil
+		err := retry.run(db.Statement.Context, func(bool) error {
+			return db.Transaction(func(tx *gorm.DB) error {
+				if err := applySessionSettings(tx, hints.Settings); err != nil {
+					return err
+				}
+				var err error
+				result, totalCount, err = runPaginatedQuery[T](tx, builder, pagination, includes, options, hints)
+				return err
+			})
+		})
+		return result, totalCount, err
+	}
+
+	return runPaginatedQuery[T](db, builder, pagination, includes, options, hints)
+}
+
+// runPaginatedQuery executes the count and data queries of PaginatedQueryWithOptions
+func runPaginatedQuery[T any](
+	db *gorm.DB,
+	builder QueryBuilder,
+	pagination PaginationRequest,
+	includes []string,
+	options PaginatedQueryOptions,
+	hints QueryHints,
 ) ([]T, int64, error) {
 	var result []T
-	var totalCount int64
+
+	if options.PrepareStatements {
+		db = preparedSession(db)
+	}
+
+	// Hint syntax must match the actual connection rather than the search dialect
+	hintDialect := DetectDialect(db)
+	countIndexHints := hints.IndexHints
+	if len(hints.CountIndexHints) > 0 {
+		countIndexHints = hints.CountIndexHints
+	}
 
 	// Build count query
-	countQuery := db.Table(builder.GetTableName())
+	countQuery := hintedTable(db, builder.GetTableName(), countIndexHints, hintDialect)
+	countQuery = applyOptimizerHints(countQuery, hints.OptimizerHints)
+	countQuery = annotateQuery(countQuery, options.QueryTags)
 	countQuery = builder.ApplyFilters(countQuery)
+	countQuery = options.Rewriter.rewrite(countQuery, true)
+	// The count matches the rows of the data query, search included
+	if pagination.Search != "" {
+		countQuery = applySearch(countQuery, builder, pagination, options.Dialect)
+	}
 
 	// Apply soft delete handling if enabled
 	if options.EnableSoftDelete {
 		countQuery = countQuery.Where("deleted_at IS NULL")
 	}
+	if hintDialect == ClickHouse {
+		countQuery = withClickHouseSettings(countQuery, mergeSettings(hints.Settings, hints.CountSettings))
+	}
 
-	// Execute count query
-	if options.CustomCountQuery != "" {
-		if err := countQuery.Raw(options.CustomCountQuery).Count(&totalCount).Error; err != nil {
-			return nil, 0, fmt.Errorf("failed to count records: %w", err)
+	// A listing pinned by a high water mark leaves out rows written after its first page
+	var markColumn string
+	if options.AsOf != nil {
+		var err error
+		markColumn = options.AsOf.Column
+		if markColumn == "" {
+			if markColumn, err = primaryKeyColumn[T](db); err != nil {
+				return nil, 0, err
+			}
 		}
-	} else {
-		if err := countQuery.Count(&totalCount).Error; err != nil {
-			return nil, 0, fmt.Errorf("failed to count records: %w", err)
+		if countQuery, err = options.AsOf.apply(countQuery, markColumn, CursorScope(totalCacheKey(countQuery), markColumn)); err != nil {
+			return nil, 0, err
 		}
 	}
 
-	// Build data query
-	dataQuery := db.Table(builder.GetTableName())
-	dataQuery = builder.ApplyFilters(dataQuery)
+	// Joins can repeat rows of the main table, count and fetch each of them once
+	var distinctKey string
+	if !options.DisableJoinDeduplication && multipliesRows(countQuery) {
+		distinctKey = distinctPrimaryKey[T](db, builder.GetTableName())
+	}
 
-	if pagination.Search != "" {
-		dataQuery = applyAutoSearch(dataQuery, pagination.Search, builder.GetSearchFields(), options.Dialect)
+	event := func(kind string) QueryEvent {
+		return QueryEvent{Kind: kind, Table: builder.GetTableName(), Page: pagination.Page, PerPage: pagination.PerPage}
 	}
 
-	// Apply soft delete handling if enabled
-	if options.EnableSoftDelete {
-		dataQuery = dataQuery.Where("deleted_at IS NULL")
+	// Execute count query
+	count := func(query *gorm.DB) (int64, error) {
+		var total int64
+		if options.CustomCountQuery != "" {
+			query = query.Raw(options.CustomCountQuery)
+		} else if options.CountLimit > 0 {
+			query = cappedCountQuery(query, distinctKey, options.CountLimit)
+		} else if distinctKey != "" {
+			query = query.Distinct(distinctKey)
+		}
+		started := time.Now()
+		err := observed(options.Observer, query, event("count"), func() (int64, error) {
+			err := options.Retry.run(query.Statement.Context, func(retrying bool) error {
+				if retrying {
+					query.Error = nil
+				}
+				return query.Count(&total).Error
+			})
+			return total, err
+		})
+		if err != nil {
+			return 0, fmt.Errorf("failed to count records: %w", err)
+		}
+		observeQuery("count", builder.GetTableName(), query, pagination, time.Since(started))
+		return total, nil
 	}
 
-	// Apply sorting
-	if pagination.Sort != "" {
-		// Validate sort field to prevent SQL injection
-		if isValidSortField(pagination.Sort) {
-			orderClause := pagination.Sort + " " + pagination.Order
-			dataQuery = dataQuery.Order(orderClause)
-		} else {
-			dataQuery = dataQuery.Order(builder.GetDefaultSort())
+	if options.SingleFlight {
+		runCount := count
+		count = func(query *gorm.DB) (int64, error) {
+			return sharedCount(query, &options, runCount)
 		}
-	} else {
-		dataQuery = dataQuery.Order(builder.GetDefaultSort())
+	}
+
+	// Background counts cannot reuse a transaction carrying session settings
+	async := options.AsyncTotal && !pagination.IsDisabled && (len(hints.Settings) == 0 || hintDialect != PostgreSQL)
+	options.CountReport.record(CountExact)
+	estimate := options.CountMode == CountEstimate && pagination.Search == "" && distinctKey == "" &&
+		options.CustomCountQuery == "" && unfilteredCount(countQuery)
+	total := func() (int64, error) {
+		if estimate {
+			estimated, ok, err := estimatedCount(db, builder.GetTableName())
+			if err != nil {
+				return 0, err
+			}
+			if ok {
+				options.CountReport.record(CountEstimate)
+				return estimated, nil
+			}
+		}
+		reused, cached := true, true
+		counted, err := reusableTotal(countQuery, options, func() (int64, error) {
+			reused = false
+			return cachedTotal(countQuery, builder.GetTableName(), options, async, func(query *gorm.DB) (int64, error) {
+				cached = false
+				return count(query)
+			})
+		})
+		switch {
+		case err != nil || counted == TotalPending:
+		case reused:
+			observeCacheHit(options.Observer, countQuery, event("count"), "total_token")
+		case cached:
+			observeCacheHit(options.Observer, countQuery, event("count"), "total")
+		}
+		return counted, err
 	}
 
 	// Apply pagination unless disabled
-	if !pagination.IsDisabled {
-		dataQuery = dataQuery.Offset(pagination.GetOffset()).Limit(pagination.GetLimit())
+	offset, limit := pagination.GetOffset(), pagination.GetLimit()
+	if pagination.IsDisabled {
+		offset, limit = 0, -1
+	}
+	// A request budget may have fewer rows left than a page
+	if options.rowLimit > 0 && (limit < 0 || options.rowLimit < limit) {
+		limit = options.rowLimit
+	}
+
+	// Offsets count hidden rows, so pages filtered by a visibility predicate start where
+	// the previous page stopped reading, or at the start of the listing
+	visibility := options.Visibility != nil && options.stream == nil && !db.DryRun
+	var window visibilityWindow
+	if visibility {
+		window = options.Visibility.window(options.VisibilityReport, CursorScope(totalCacheKey(countQuery)), pagination.Page, offset)
+		offset = window.start
 	}
 
 	// Validate and apply preloads
 	validatedIncludes := validateIncludes(builder, includes)
-	for _, include := range validatedIncludes {
-		dataQuery = dataQuery.Preload(include)
+	loaders := includeLoaders(builder)
+	selected := selectColumns[T](db, builder, validatedIncludes)
+
+	// Build data query, also for the next page when prefetching
+	pageQuery := func(db *gorm.DB, offset, limit int) (*gorm.DB, error) {
+		dataQuery := hintedTable(db, builder.GetTableName(), hints.IndexHints, hintDialect)
+		dataQuery = applyOptimizerHints(dataQuery, hints.OptimizerHints)
+		dataQuery = annotateQuery(dataQuery, options.QueryTags)
+		if hintDialect == ClickHouse {
+			dataQuery = withClickHouseSettings(dataQuery, hints.Settings)
+		}
+		dataQuery = builder.ApplyFilters(dataQuery)
+		if len(selected) > 0 && len(dataQuery.Statement.Selects) == 0 {
+			dataQuery = dataQuery.Select(qualifiedColumns(builder.GetTableName(), selected))
+		}
+		dataQuery = options.Rewriter.rewrite(dataQuery, false)
+		if mark := options.AsOf.Mark(); mark != nil {
+			dataQuery = dataQuery.Where(markColumn+" <= ?", mark)
+		}
+		if distinctKey != "" {
+			dataQuery = distinctRows(dataQuery, builder.GetTableName())
+		}
+
+		if pagination.Search != "" {
+			dataQuery = applySearch(dataQuery, builder, pagination, options.Dialect)
+		}
+
+		// Apply soft delete handling if enabled
+		if options.EnableSoftDelete {
+			dataQuery = dataQuery.Where("deleted_at IS NULL")
+		}
+
+		// Apply sorting
+		dataQuery = dataQuery.Order(sortClause(builder, pagination))
+
+		switch {
+		case limit < 0:
+		case options.RowNumPaging:
+			dataQuery = rowNumPage(db, dataQuery, offset, limit)
+		case options.PrepareStatements:
+			dataQuery = stablePage(dataQuery, offset, limit)
+		default:
+			dataQuery = dataQuery.Offset(offset).Limit(limit)
+		}
+
+		for _, include := range validatedIncludes {
+			if _, ok := loaders[include]; ok {
+				continue
+			}
+			var err error
+			if dataQuery, err = preloadInclude[T](dataQuery, builder, include); err != nil {
+				return nil, err
+			}
+		}
+		return dataQuery, nil
+	}
+
+	// Rows already shown at the end of the previous page are fetched again and dropped
+	dedup := options.Dedup != nil && limit > 0 && options.stream == nil
+	var seen map[string]bool
+	var dedupScope string
+	if dedup {
+		dedupScope = CursorScope(totalCacheKey(countQuery))
+		seen = options.Dedup.seen(dedupScope, pagination.Page)
+	}
+	fetchLimit := limit
+	if limit > 0 {
+		fetchLimit += len(seen)
+	}
+
+	// Without a count, one row past the page tells whether another page follows
+	skipCount := options.CountMode == CountSkip && fetchLimit > 0 && options.stream == nil
+	queryLimit := fetchLimit
+	if skipCount {
+		queryLimit++
+	}
+
+	dataQuery, err := pageQuery(db, offset, queryLimit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// A short first page tells the total, so the count waits for the data unless streamed
+	// rows need it first; dry runs fetch nothing and still render the count
+	shortPageTotal := !options.AlwaysCount && !skipCount && options.stream == nil && options.CustomCountQuery == "" && !db.DryRun
+	var totalCount int64
+	if !shortPageTotal && !skipCount {
+		if totalCount, err = total(); err != nil {
+			return nil, 0, err
+		}
 	}
 
 	// Execute data query
-	if err := dataQuery.Find(&result).Error; err != nil {
+	find := func(query *gorm.DB) ([]T, error) {
+		var result []T
+		err := options.Retry.run(query.Statement.Context, func(retrying bool) error {
+			if retrying {
+				result, query.Error = nil, nil
+			}
+			return query.Find(&result).Error
+		})
+		return result, err
+	}
+	fetch := find
+	if options.SingleFlight {
+		fetch = func(query *gorm.DB) ([]T, error) {
+			return sharedFind(query, &options, find)
+		}
+	}
+	observedFetch := func(query *gorm.DB) ([]T, error) {
+		var rows []T
+		err := observed(options.Observer, query, event("data"), func() (int64, error) {
+			var err error
+			rows, err = fetch(query)
+			return int64(len(rows)), err
+		})
+		return rows, err
+	}
+	started := time.Now()
+	switch {
+	case options.stream != nil:
+		err = observed(options.Observer, dataQuery, event("data"), func() (int64, error) {
+			return 0, options.stream(dataQuery, totalCount)
+		})
+	case options.PageCache != nil:
+		fetched := false
+		result, err = cachedPage(options.PageCache, dataQuery, builder.GetTableName(), func(query *gorm.DB) ([]T, error) {
+			fetched = true
+			return observedFetch(query)
+		})
+		if err == nil && !fetched {
+			observeCacheHit(options.Observer, dataQuery, event("data"), "page")
+		}
+	default:
+		result, err = observedFetch(dataQuery)
+	}
+	if err != nil {
 		return nil, 0, fmt.Errorf("failed to fetch records: %w", err)
 	}
+	observeQuery("data", builder.GetTableName(), dataQuery, pagination, time.Since(started))
+
+	if skipCount {
+		options.CountReport.record(CountSkip)
+		totalCount = int64(offset + len(result))
+		if len(result) > fetchLimit {
+			result = result[:fetchLimit]
+			totalCount = int64(offset + fetchLimit + 1)
+		}
+	}
+
+	if shortPageTotal {
+		// Only a short first page holds every row; later pages depend on the rows before them
+		if offset == 0 && (fetchLimit < 0 || len(result) < fetchLimit) {
+			totalCount = int64(offset + len(result))
+			if options.CountLimit > 0 {
+				totalCount = min(totalCount, options.CountLimit)
+			}
+		} else if totalCount, err = total(); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if visibility {
+		result, err = visibleRows(db.Statement.Context, options.Visibility, options.VisibilityReport, window, pagination.Page, result, fetchLimit, func(offset, limit int) ([]T, error) {
+			refillQuery, err := pageQuery(db, offset, limit)
+			if err != nil {
+				return nil, err
+			}
+			rows, err := observedFetch(refillQuery)
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch records: %w", err)
+			}
+			return rows, nil
+		})
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if dedup {
+		if result, err = dedupPage(db, options.Dedup, dedupScope, pagination.Page, limit, seen, result); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if options.Prefetch && options.PageCache != nil && hasNextPage(offset, limit, len(result), totalCount) && !db.DryRun && options.stream == nil && options.rowLimit == 0 {
+		// Transactions end with the request, as does its context
+		if _, inTransaction := db.Statement.ConnPool.(gorm.TxCommitter); !inTransaction {
+			ctx := db.Statement.Context
+			if ctx == nil {
+				ctx = context.Background()
+			}
+			if nextQuery, err := pageQuery(db.WithContext(context.WithoutCancel(ctx)), offset+limit, limit); err == nil {
+				prefetchPage(options.PageCache, nextQuery, builder.GetTableName(), fetch)
+			}
+		}
+	}
+
+	if options.Checksum != nil && !db.DryRun && options.stream == nil {
+		if err := checksumPage(db, options.Checksum, result); err != nil {
+			return nil, 0, err
+		}
+	}
+
+	if err := runIncludeLoaders(dataQuery, loaders, validatedIncludes, &result); err != nil {
+		return nil, 0, err
+	}
+	limitIncludes(builder, validatedIncludes, result, options.IncludeReport)
 
 	return result, totalCount, nil
 }
@@ -245,19 +811,37 @@ func isValidInclude(include string) bool {
 	return len(include) > 0
 }
 
-// validateIncludes validates includes against allowed includes for the builder
+// validateIncludes validates includes against allowed includes for the builder and adds
+// the default includes registered for its table
 func validateIncludes(builder interface{}, includes []string) []string {
-	if includeValidator, ok := builder.(AllowedIncludesProvider); ok {
-		allowedIncludes := includeValidator.GetAllowedIncludes()
-		var validIncludes []string
-		for _, include := range includes {
-			if isValidInclude(include) && allowedIncludes[include] {
-				validIncludes = append(validIncludes, include)
+	validIncludes := permittedIncludes(builder, includes)
+	if tableBuilder, ok := builder.(QueryBuilder); ok {
+		if config, ok := LookupTable(tableBuilder.GetTableName()); ok {
+			for _, include := range config.DefaultIncludes {
+				if !slices.Contains(validIncludes, include) {
+					validIncludes = append(validIncludes, include)
+				}
 			}
 		}
+	}
+	return validIncludes
+}
+
+// permittedIncludes keeps the includes the builder allows
+func permittedIncludes(builder interface{}, includes []string) []string {
+	if includeValidator, ok := builder.(AllowedIncludesProvider); ok {
+		validIncludes, _ := allowIncludes(includes, includeValidator.GetAllowedIncludes())
 		return validIncludes
 	}
 
+	// Registered table defaults apply to builders without their own allow list
+	if tableBuilder, ok := builder.(QueryBuilder); ok {
+		if config, ok := LookupTable(tableBuilder.GetTableName()); ok && len(config.AllowedIncludes) > 0 {
+			validIncludes, _ := allowIncludes(includes, config.allowedIncludes())
+			return validIncludes
+		}
+	}
+
 	// Fallback: just validate syntax if no allowed includes defined
 	var validIncludes []string
 	for _, include := range includes {
@@ -274,6 +858,21 @@ type SimpleQueryBuilder struct {
 	SearchFields []string
 	DefaultSort  string
 	Dialect      DatabaseDialect
+	Hints        QueryHints
+	// SearchClauses extend the search beyond SearchFields, see SearchRelated
+	SearchClauses []SearchClause
+	// IncludeLoaders resolve includes without GORM Preload, see BatchInclude
+	IncludeLoaders map[string]IncludeLoader
+	// IncludeLimits cap the related rows of includes per row, see IncludeLimitsProvider
+	IncludeLimits map[string]int
+	// HistogramFields are bucketed into pagination.histograms, see Histograms
+	HistogramFields []HistogramField
+	// BoundsFields are reported with their range in pagination.bounds, see Bounds
+	BoundsFields []string
+	// SortPresets map sort parameter values to orderings, see SortPresetProvider
+	SortPresets map[string]string
+	// SearchMode compares the search term with the search fields, see SearchMode
+	SearchMode SearchMode
 }
 
 func (s *SimpleQueryBuilder) ApplyFilters(query *gorm.DB) *gorm.DB {
@@ -313,6 +912,93 @@ func (s *SimpleQueryBuilder) WithSearchFields(fields ...string) *SimpleQueryBuil
 	return s
 }
 
+// WithSearchMode sets how the search term is compared with the search fields
+func (s *SimpleQueryBuilder) WithSearchMode(mode SearchMode) *SimpleQueryBuilder {
+	s.SearchMode = mode
+	return s
+}
+
+// GetSearchMode returns the search mode of the query builder, empty for the default
+func (s *SimpleQueryBuilder) GetSearchMode() SearchMode {
+	return s.SearchMode
+}
+
+// WithSearchClauses adds search alternatives OR'd with the search fields
+func (s *SimpleQueryBuilder) WithSearchClauses(clauses ...SearchClause) *SimpleQueryBuilder {
+	s.SearchClauses = append(s.SearchClauses, clauses...)
+	return s
+}
+
+// GetSearchClauses returns the additional search alternatives of the query builder
+func (s *SimpleQueryBuilder) GetSearchClauses() []SearchClause {
+	return s.SearchClauses
+}
+
+// WithIncludeLoader resolves an include with a custom loader instead of GORM Preload
+func (s *SimpleQueryBuilder) WithIncludeLoader(include string, loader IncludeLoader) *SimpleQueryBuilder {
+	if s.IncludeLoaders == nil {
+		s.IncludeLoaders = make(map[string]IncludeLoader)
+	}
+	s.IncludeLoaders[include] = loader
+	return s
+}
+
+// GetIncludeLoaders returns the custom include loaders of the query builder
+func (s *SimpleQueryBuilder) GetIncludeLoaders() map[string]IncludeLoader {
+	return s.IncludeLoaders
+}
+
+// WithIncludeLimit keeps at most limit related rows of include per row
+func (s *SimpleQueryBuilder) WithIncludeLimit(include string, limit int) *SimpleQueryBuilder {
+	if s.IncludeLimits == nil {
+		s.IncludeLimits = make(map[string]int)
+	}
+	s.IncludeLimits[strings.ToLower(include)] = limit
+	return s
+}
+
+// GetIncludeLimits returns the include limits of the query builder
+func (s *SimpleQueryBuilder) GetIncludeLimits() map[string]int {
+	return s.IncludeLimits
+}
+
+// WithHistogram declares a numeric column counted in buckets of width
+func (s *SimpleQueryBuilder) WithHistogram(column string, width float64) *SimpleQueryBuilder {
+	s.HistogramFields = append(s.HistogramFields, HistogramField{Column: column, Width: width})
+	return s
+}
+
+// GetHistogramFields returns the declared histogram fields
+func (s *SimpleQueryBuilder) GetHistogramFields() []HistogramField {
+	return s.HistogramFields
+}
+
+// WithBounds declares numeric or date columns reported with their minimum and maximum
+func (s *SimpleQueryBuilder) WithBounds(columns ...string) *SimpleQueryBuilder {
+	s.BoundsFields = append(s.BoundsFields, columns...)
+	return s
+}
+
+// GetBoundsFields returns the declared bounds columns
+func (s *SimpleQueryBuilder) GetBoundsFields() []string {
+	return s.BoundsFields
+}
+
+// WithSortPreset registers a named ordering selected with ?sort=name, e.g.
+// WithSortPreset("popular", "likes desc, created_at desc")
+func (s *SimpleQueryBuilder) WithSortPreset(name, ordering string) *SimpleQueryBuilder {
+	if s.SortPresets == nil {
+		s.SortPresets = make(map[string]string)
+	}
+	s.SortPresets[name] = ordering
+	return s
+}
+
+// GetSortPresets returns the named orderings of the query builder
+func (s *SimpleQueryBuilder) GetSortPresets() map[string]string {
+	return s.SortPresets
+}
+
 // WithDefaultSort sets the default sort for the query builder
 func (s *SimpleQueryBuilder) WithDefaultSort(sort string) *SimpleQueryBuilder {
 	s.DefaultSort = sort
@@ -331,6 +1017,23 @@ func (s *SimpleQueryBuilder) WithFilters(filterFunc func(*gorm.DB) *gorm.DB) *Si
 	return s
 }
 
+// WithIndexHint adds an index hint applied to the data and count queries
+func (s *SimpleQueryBuilder) WithIndexHint(hintType IndexHintType, indexes ...string) *SimpleQueryBuilder {
+	s.Hints.IndexHints = append(s.Hints.IndexHints, IndexHint{Type: hintType, Indexes: indexes})
+	return s
+}
+
+// WithQueryHints sets all optimizer hints for the query builder
+func (s *SimpleQueryBuilder) WithQueryHints(hints QueryHints) *SimpleQueryBuilder {
+	s.Hints = hints
+	return s
+}
+
+// GetQueryHints returns the optimizer hints of the query builder
+func (s *SimpleQueryBuilder) GetQueryHints() QueryHints {
+	return s.Hints
+}
+
 // GetSearchOperator returns the search operator based on the current dialect
 func (s *SimpleQueryBuilder) GetSearchOperator() string {
 	return getSearchOperator(s.Dialect)
@@ -0,0 +1,176 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// RedisZMember is a sorted set member with its score
+type RedisZMember struct {
+	Member string  `json:"member"`
+	Score  float64 `json:"score"`
+}
+
+// RedisSortedSet reads a Redis sorted set by score, e.g. a leaderboard
+type RedisSortedSet struct {
+	// Range returns up to count members from score start (inclusive) on, skipping the
+	// first offset: ZRANGE key start +inf BYSCORE LIMIT offset count, or with Reverse
+	// ZRANGE key start -inf BYSCORE REV LIMIT offset count
+	Range func(ctx context.Context, start string, offset, count int) ([]RedisZMember, error)
+	// Reverse pages from the highest score down
+	Reverse bool
+	// Count, when set, returns the total, e.g. with ZCARD
+	Count func(ctx context.Context) (int64, error)
+	// Scope binds cursors to the sorted set, see CursorScope
+	Scope string
+}
+
+// redisScoreCursor resumes after the last member of a page: at its score, skipping
+// the members with that score already returned
+type redisScoreCursor struct {
+	Score string `json:"s"`
+	Skip  int    `json:"k"`
+	Page  int    `json:"p"`
+}
+
+// redisScore renders a score as a ZRANGE bound
+func redisScore(score float64) string {
+	switch {
+	case math.IsInf(score, 1):
+		return "+inf"
+	case math.IsInf(score, -1):
+		return "-inf"
+	}
+	return strconv.FormatFloat(score, 'g', -1, 64)
+}
+
+// PaginateRedisSortedSet returns the page of set following cursor. Cursors hold a score
+// bound instead of a rank, so members added or removed above it do not shift later
+// pages; members sharing the boundary score are skipped by count.
+func PaginateRedisSortedSet(ctx context.Context, set RedisSortedSet, cursor string, pageSize int) ([]RedisZMember, PaginationResponse, error) {
+	if pageSize <= 0 {
+		pageSize = CurrentConfig().DefaultPageSize
+	}
+
+	position := redisScoreCursor{Score: "-inf"}
+	if set.Reverse {
+		position.Score = "+inf"
+	}
+	if cursor != "" {
+		if err := decodeScopedToken(cursor, set.Scope, &position); err != nil {
+			return nil, PaginationResponse{}, err
+		}
+	}
+
+	members, err := set.Range(ctx, position.Score, position.Skip, pageSize+1)
+	if err != nil {
+		return nil, PaginationResponse{}, fmt.Errorf("failed to read sorted set: %w", err)
+	}
+	hasMore := len(members) > pageSize
+	if hasMore {
+		members = members[:pageSize]
+	}
+	if members == nil {
+		members = []RedisZMember{}
+	}
+
+	request := PaginationRequest{Page: position.Page + 1, PerPage: pageSize}
+	meta := PaginationResponse{Page: request.Page, PerPage: pageSize, TotalStatus: TotalStatusUnknown}
+	if set.Count != nil {
+		total, err := set.Count(ctx)
+		if err != nil {
+			return nil, PaginationResponse{}, fmt.Errorf("failed to count sorted set: %w", err)
+		}
+		meta = CalculatePagination(request, total)
+	}
+
+	if hasMore {
+		last := members[len(members)-1]
+		next := redisScoreCursor{Score: redisScore(last.Score), Page: request.Page}
+		for i := len(members) - 1; i >= 0 && members[i].Score == last.Score; i-- {
+			next.Skip++
+		}
+		if next.Skip == len(members) && next.Score == position.Score {
+			next.Skip += position.Skip
+		}
+		if meta.NextCursor, err = encodeScopedToken(next, set.Scope); err != nil {
+			return nil, PaginationResponse{}, err
+		}
+	}
+	return members, meta, nil
+}
+
+// RedisScan iterates a Redis keyspace with SCAN, HSCAN, SSCAN or ZSCAN
+type RedisScan struct {
+	// Scan runs one call such as SCAN cursor MATCH pattern COUNT count and returns the
+	// keys with the cursor of the next call, 0 once the iteration is complete
+	Scan func(ctx context.Context, cursor uint64, count int) ([]string, uint64, error)
+	// MaxRequests caps the calls made to fill one page (default 10)
+	MaxRequests int
+	// Scope binds cursors to the keyspace and pattern, see CursorScope
+	Scope string
+}
+
+// redisScanCursor resumes a scan at a call, skipping the keys of it already returned
+type redisScanCursor struct {
+	Cursor uint64 `json:"c"`
+	Skip   int    `json:"k,omitempty"`
+}
+
+// PaginateRedisScan returns the page of keys following cursor. SCAN only promises to
+// return every key present for the whole iteration at least once, so pages may repeat
+// keys and have no total. A page is cut inside a SCAN call by repeating the call and
+// skipping what was returned, exact as long as the keyspace is not rehashed meanwhile.
+func PaginateRedisScan(ctx context.Context, scan RedisScan, cursor string, pageSize int) ([]string, PaginationResponse, error) {
+	if pageSize <= 0 {
+		pageSize = CurrentConfig().DefaultPageSize
+	}
+	if scan.MaxRequests <= 0 {
+		scan.MaxRequests = 10
+	}
+
+	var position redisScanCursor
+	if cursor != "" {
+		if err := decodeScopedToken(cursor, scan.Scope, &position); err != nil {
+			return nil, PaginationResponse{}, err
+		}
+	}
+
+	keys := []string{}
+	done := false
+	for requests := 0; requests < scan.MaxRequests && len(keys) < pageSize; requests++ {
+		batch, next, err := scan.Scan(ctx, position.Cursor, pageSize)
+		if err != nil {
+			return nil, PaginationResponse{}, fmt.Errorf("failed to scan keys: %w", err)
+		}
+		if position.Skip < len(batch) {
+			batch = batch[position.Skip:]
+		} else {
+			batch = nil
+		}
+
+		if room := pageSize - len(keys); len(batch) > room {
+			keys = append(keys, batch[:room]...)
+			position.Skip += room
+			break
+		}
+		keys = append(keys, batch...)
+		position = redisScanCursor{Cursor: next}
+		if next == 0 {
+			done = true
+			break
+		}
+	}
+
+	meta := PaginationResponse{PerPage: pageSize, TotalStatus: TotalStatusUnknown}
+	if !done {
+		next, err := encodeScopedToken(position, scan.Scope)
+		if err != nil {
+			return nil, PaginationResponse{}, err
+		}
+		meta.NextCursor = next
+	}
+	return keys, meta, nil
+}
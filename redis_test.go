@@ -0,0 +1,149 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeSortedSet implements ZRANGE ... BYSCORE [REV] LIMIT over members ordered like Redis
+func fakeSortedSet(members []RedisZMember, reverse bool) RedisSortedSet {
+	sorted := append([]RedisZMember(nil), members...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Score != sorted[j].Score {
+			return sorted[i].Score < sorted[j].Score != reverse
+		}
+		return sorted[i].Member < sorted[j].Member != reverse
+	})
+
+	return RedisSortedSet{
+		Reverse: reverse,
+		Range: func(ctx context.Context, start string, offset, count int) ([]RedisZMember, error) {
+			bound, err := strconv.ParseFloat(start, 64)
+			if err != nil {
+				return nil, err
+			}
+			var result []RedisZMember
+			for _, member := range sorted {
+				if (!reverse && member.Score < bound) || (reverse && member.Score > bound) {
+					continue
+				}
+				if offset > 0 {
+					offset--
+					continue
+				}
+				if len(result) == count {
+					break
+				}
+				result = append(result, member)
+			}
+			return result, nil
+		},
+	}
+}
+
+func zMembers(page []RedisZMember) []string {
+	names := make([]string, len(page))
+	for i, member := range page {
+		names[i] = member.Member
+	}
+	return names
+}
+
+func TestPaginateRedisSortedSet_Leaderboard(t *testing.T) {
+	members := []RedisZMember{
+		{"ann", 90}, {"bob", 75}, {"cat", 75}, {"dan", 75}, {"eve", 60}, {"fay", 40},
+	}
+	set := fakeSortedSet(members, true)
+	set.Count = func(context.Context) (int64, error) { return int64(len(members)), nil }
+
+	var pages [][]string
+	cursor := ""
+	for {
+		page, meta, err := PaginateRedisSortedSet(context.Background(), set, cursor, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, len(pages)+1, meta.Page)
+		assert.Equal(t, int64(6), meta.Total)
+		assert.Equal(t, int64(3), meta.MaxPage)
+		pages = append(pages, zMembers(page))
+		if meta.NextCursor == "" {
+			break
+		}
+		cursor = meta.NextCursor
+	}
+	assert.Equal(t, [][]string{{"ann", "dan"}, {"cat", "bob"}, {"eve", "fay"}}, pages)
+}
+
+func TestPaginateRedisSortedSet_TiesAcrossPages(t *testing.T) {
+	members := []RedisZMember{{"a", 1}, {"b", 5}, {"c", 5}, {"d", 5}, {"e", 5}, {"f", 7}}
+	set := fakeSortedSet(members, false)
+
+	var all []string
+	cursor := ""
+	for pages := 0; pages < 10; pages++ {
+		page, meta, err := PaginateRedisSortedSet(context.Background(), set, cursor, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, TotalStatusUnknown, meta.TotalStatus)
+		all = append(all, zMembers(page)...)
+		if meta.NextCursor == "" {
+			break
+		}
+		cursor = meta.NextCursor
+	}
+	assert.Equal(t, []string{"a", "b", "c", "d", "e", "f"}, all, "a run of equal scores longer than a page is not lost")
+}
+
+func TestPaginateRedisSortedSet_Errors(t *testing.T) {
+	set := RedisSortedSet{Range: func(context.Context, string, int, int) ([]RedisZMember, error) {
+		return nil, errors.New("connection refused")
+	}}
+	_, _, err := PaginateRedisSortedSet(context.Background(), set, "", 10)
+	assert.ErrorContains(t, err, "failed to read sorted set: connection refused")
+
+	_, _, err = PaginateRedisSortedSet(context.Background(), fakeSortedSet(nil, false), "bogus", 10)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	assert.Equal(t, "+inf", redisScore(math.Inf(1)))
+	assert.Equal(t, "2.5", redisScore(2.5))
+}
+
+// fakeKeyspace returns batches of keys like SCAN, with a cursor per batch
+func fakeKeyspace(batches [][]string, calls *int) RedisScan {
+	return RedisScan{Scan: func(ctx context.Context, cursor uint64, count int) ([]string, uint64, error) {
+		*calls++
+		next := cursor + 1
+		if int(next) == len(batches) {
+			next = 0
+		}
+		return batches[cursor], next, nil
+	}}
+}
+
+func TestPaginateRedisScan(t *testing.T) {
+	calls := 0
+	scan := fakeKeyspace([][]string{{"k1", "k2", "k3"}, {}, {"k4"}, {"k5", "k6"}}, &calls)
+
+	var pages [][]string
+	cursor := ""
+	for len(pages) < 10 {
+		keys, meta, err := PaginateRedisScan(context.Background(), scan, cursor, 2)
+		assert.NoError(t, err)
+		pages = append(pages, keys)
+		if meta.NextCursor == "" {
+			break
+		}
+		cursor = meta.NextCursor
+	}
+	assert.Equal(t, [][]string{{"k1", "k2"}, {"k3", "k4"}, {"k5", "k6"}}, pages)
+
+	scan.MaxRequests = 1
+	keys, meta, err := PaginateRedisScan(context.Background(), scan, "", 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"k1", "k2", "k3"}, keys)
+	assert.NotEmpty(t, meta.NextCursor, "a short page still continues")
+}
@@ -0,0 +1,128 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sync"
+)
+
+// TableConfig holds the pagination defaults of one table
+type TableConfig struct {
+	DefaultSort     string `json:"default_sort,omitempty"`
+	DefaultPageSize int    `json:"default_page_size,omitempty"`
+	// MaxPageSize caps per_page; it may exceed the global maximum
+	MaxPageSize     int      `json:"max_page_size,omitempty"`
+	AllowedIncludes []string `json:"allowed_includes,omitempty"`
+	// DefaultIncludes are preloaded on every page, whether requested or not
+	DefaultIncludes []string `json:"default_includes,omitempty"`
+	// MaxIncludes caps how many includes a client may request at once; requests with
+	// more are rejected as binding errors
+	MaxIncludes  int      `json:"max_includes,omitempty"`
+	SearchFields []string `json:"search_fields,omitempty"`
+	// SortDirections are the directions of sort fields requested without a sign or
+	// order, e.g. {"created_at": "desc"}
+	SortDirections map[string]string `json:"sort_directions,omitempty"`
+	// SortPresets are named orderings selected with the sort parameter, e.g.
+	// {"newest": "created_at desc, id desc"}
+	SortPresets map[string]string `json:"sort_presets,omitempty"`
+}
+
+var (
+	tableConfigsMu sync.RWMutex
+	tableConfigs   = make(map[string]TableConfig)
+)
+
+// RegisterTable registers the pagination defaults of a table, used by the helpers
+// taking a table name and by include validation of any builder of that table
+func RegisterTable(tableName string, config TableConfig) {
+	tableConfigsMu.Lock()
+	defer tableConfigsMu.Unlock()
+	tableConfigs[tableName] = config
+}
+
+// LookupTable returns the registered defaults of a table
+func LookupTable(tableName string) (TableConfig, bool) {
+	tableConfigsMu.RLock()
+	defer tableConfigsMu.RUnlock()
+	config, ok := tableConfigs[tableName]
+	return config, ok
+}
+
+// allowedIncludes returns the registered includes as a lookup set
+func (c TableConfig) allowedIncludes() map[string]bool {
+	allowed := make(map[string]bool, len(c.AllowedIncludes))
+	for _, include := range c.AllowedIncludes {
+		allowed[include] = true
+	}
+	return allowed
+}
+
+// checkIncludeLimit rejects filters requesting more includes than the MaxIncludes
+// registered for their table
+func checkIncludeLimit(filter interface{}) error {
+	includable, ok := filter.(interface {
+		GetTableName() string
+		GetIncludes() []string
+	})
+	if !ok {
+		return nil
+	}
+	config, ok := LookupTable(includable.GetTableName())
+	if !ok || config.MaxIncludes <= 0 {
+		return nil
+	}
+	if requested := len(includable.GetIncludes()); requested > config.MaxIncludes {
+		return fmt.Errorf("at most %d includes may be requested, got %d", config.MaxIncludes, requested)
+	}
+	return nil
+}
+
+// newTableQueryBuilder creates a SimpleQueryBuilder using the registered defaults of the
+// table; explicit search fields take precedence over registered ones
+func newTableQueryBuilder(tableName string, searchFields []string) *SimpleQueryBuilder {
+	builder := NewSimpleQueryBuilder(tableName)
+
+	config, ok := LookupTable(tableName)
+	if ok {
+		if config.DefaultSort != "" {
+			builder.WithDefaultSort(config.DefaultSort)
+		}
+		builder.WithSearchFields(config.SearchFields...)
+		for name, ordering := range config.SortPresets {
+			builder.WithSortPreset(name, ordering)
+		}
+	}
+	if len(searchFields) > 0 {
+		builder.WithSearchFields(searchFields...)
+	}
+
+	return builder
+}
+
+// bindTablePagination binds pagination parameters applying the page size and sort
+// direction defaults of the table
+func bindTablePagination(ctx context.Context, query url.Values, tableName string) (PaginationRequest, []PaginationWarning) {
+	config, _ := LookupTable(tableName)
+	pagination, warnings := bindPagination(ctx, query, tablePageSizeLimits(tableName))
+	applySortDirections(query, &pagination, config.SortDirections)
+	return pagination, warnings
+}
+
+// tablePageSizeLimits returns the page size limits of a table: its registered ones, or
+// the global ones
+func tablePageSizeLimits(tableName string) pageSizeLimits {
+	global := CurrentConfig()
+	limits := pageSizeLimits{Default: global.DefaultPageSize, Max: global.MaxPageSize}
+
+	if config, ok := LookupTable(tableName); ok {
+		if config.DefaultPageSize > 0 {
+			limits.Default = config.DefaultPageSize
+		}
+		// Table maximums may exceed the global one
+		if config.MaxPageSize > 0 {
+			limits.Max = config.MaxPageSize
+		}
+	}
+	return limits
+}
@@ -0,0 +1,110 @@
+package pagination
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func registerTestTable(t *testing.T, config TableConfig) {
+	RegisterTable("test_users", config)
+	t.Cleanup(func() {
+		tableConfigsMu.Lock()
+		delete(tableConfigs, "test_users")
+		tableConfigsMu.Unlock()
+	})
+}
+
+func newTestRequest(target string) *http.Request {
+	return httptest.NewRequest("GET", target, nil)
+}
+
+// bindTestPagination binds the pagination parameters of a request to target
+func bindTestPagination(target string) PaginationRequest {
+	pagination, _ := BindPaginationRequest(newTestRequest(target))
+	return pagination
+}
+
+func TestRegisterTable_QuickPaginate(t *testing.T) {
+	db := setupTestDB()
+	registerTestTable(t, TableConfig{
+		DefaultSort:     "age desc",
+		DefaultPageSize: 2,
+		MaxPageSize:     3,
+		SearchFields:    []string{"name"},
+	})
+
+	data, meta, err := Paginate[TestUser](db, newTestRequest("/users"), WithTable("test_users"))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, meta.PerPage)
+	assert.Len(t, data, 2)
+	assert.Equal(t, 35, data[0].Age)
+
+	_, meta, err = Paginate[TestUser](db, newTestRequest("/users?per_page=200"), WithTable("test_users"))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, meta.PerPage)
+
+	data, _, err = Paginate[TestUser](db, newTestRequest("/users?search=Jane"), WithTable("test_users"))
+	assert.NoError(t, err)
+	assert.Len(t, data, 1)
+}
+
+func TestRegisterTable_PageSizeAboveGlobalLimit(t *testing.T) {
+	perPage := func(target string) int {
+		pagination, _ := bindTablePagination(context.Background(), httptest.NewRequest("GET", target, nil).URL.Query(), "test_users")
+		return pagination.PerPage
+	}
+
+	registerTestTable(t, TableConfig{MaxPageSize: 500})
+	assert.Equal(t, 250, perPage("/users?per_page=250"))
+
+	registerTestTable(t, TableConfig{DefaultPageSize: 20})
+	assert.Equal(t, 100, perPage("/users?per_page=250"))
+	assert.Equal(t, 50, perPage("/users?per_page=50"))
+}
+
+func TestRegisterTable_AllowedIncludes(t *testing.T) {
+	registerTestTable(t, TableConfig{AllowedIncludes: []string{"Orders"}})
+
+	builder := NewSimpleQueryBuilder("test_users")
+	assert.Equal(t, []string{"Orders"}, validateIncludes(builder, []string{"Orders", "Secrets"}))
+}
+
+func TestRegisterTable_DefaultIncludes(t *testing.T) {
+	db := setupTeamsDB()
+	RegisterTable("test_teams", TableConfig{DefaultIncludes: []string{"Members"}})
+	t.Cleanup(func() {
+		tableConfigsMu.Lock()
+		delete(tableConfigs, "test_teams")
+		tableConfigsMu.Unlock()
+	})
+
+	teams, _, err := Paginate[TestTeam](db, newTestRequest("/teams"), WithTable("test_teams"))
+	assert.NoError(t, err)
+	if assert.Len(t, teams, 1) {
+		assert.Len(t, teams[0].Members, 2)
+	}
+
+	builder := NewSimpleQueryBuilder("test_teams")
+	assert.Equal(t, []string{"Members"}, validateIncludes(builder, []string{"Members"}), "requested defaults are not loaded twice")
+}
+
+func TestRegisterTable_MaxIncludes(t *testing.T) {
+	db := setupTestDB()
+	registerTestTable(t, TableConfig{MaxIncludes: 2})
+
+	filter := &testUserFilter{}
+	assert.NoError(t, BindFilterRequest(newTestRequest("/users?includes=Orders,Tags"), filter))
+
+	err := BindFilterRequest(newTestRequest("/users?includes=Orders,Tags,Profile"), &testUserFilter{})
+	var bindingErr *FilterBindingError
+	if assert.ErrorAs(t, err, &bindingErr) {
+		assert.Equal(t, "at most 2 includes may be requested, got 3", err.Error())
+	}
+
+	response := PaginateResponse[TestUser](db, newTestRequest("/users?includes=Orders,Tags,Profile"), "ok", WithCustomFilter(&testUserFilter{}))
+	assert.Equal(t, 400, response.Code)
+}
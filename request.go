@@ -0,0 +1,265 @@
+package pagination
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+	"gorm.io/gorm"
+)
+
+// The functions of this file read the query string of a *http.Request or url.Values, so
+// any router built on net/http can use them. The ginadapter and echoadapter packages
+// wrap them for Gin and Echo.
+
+// filterValidator checks the binding tags of filters, like the form binding of Gin
+var filterValidator = sync.OnceValue(func() *validator.Validate {
+	validate := validator.New()
+	validate.SetTagName("binding")
+	return validate
+})
+
+// requestBinder is implemented by filters embedding BaseFilter
+type requestBinder interface {
+	bindRequest(*http.Request)
+}
+
+// requestQuery returns the parsed query string of r, empty without a request
+func requestQuery(r *http.Request) url.Values {
+	if r == nil || r.URL == nil {
+		return url.Values{}
+	}
+	return r.URL.Query()
+}
+
+// requestContext returns the context of r, the background context without a request
+func requestContext(r *http.Request) context.Context {
+	if r == nil {
+		return context.Background()
+	}
+	return r.Context()
+}
+
+// BindPaginationValues binds pagination parameters from a parsed query string and
+// reports every parameter that was replaced by a default or clamped
+func BindPaginationValues(query url.Values) (PaginationRequest, []PaginationWarning) {
+	config := CurrentConfig()
+	return bindPagination(context.Background(), query, pageSizeLimits{Default: config.DefaultPageSize, Max: config.MaxPageSize})
+}
+
+// BindPaginationRequest is BindPaginationValues for the query string of r, applying the
+// page size reduction of AdaptivePageSize
+func BindPaginationRequest(r *http.Request) (PaginationRequest, []PaginationWarning) {
+	config := CurrentConfig()
+	return bindPagination(requestContext(r), requestQuery(r), pageSizeLimits{Default: config.DefaultPageSize, Max: config.MaxPageSize})
+}
+
+// ParsePaginationRequest binds pagination parameters like BindPaginationRequest; in
+// strict mode invalid values are reported instead of replaced by defaults
+func ParsePaginationRequest(r *http.Request) (PaginationRequest, error) {
+	pagination, warnings := BindPaginationRequest(r)
+	if !CurrentConfig().Strict || len(warnings) == 0 {
+		return pagination, nil
+	}
+
+	problems := make([]string, len(warnings))
+	for i, warning := range warnings {
+		problems[i] = warning.Message
+	}
+	return pagination, fmt.Errorf("%w: %s", ErrInvalidPagination, strings.Join(problems, "; "))
+}
+
+// BindFilterRequest binds custom filter fields (form tags), pagination and includes
+// from the query string of r, then checks the binding tags of the fields. Pagination is
+// bound last so its defaults and limits apply even though the embedded BaseFilter
+// fields are also reachable by the form binding.
+func BindFilterRequest(r *http.Request, filter interface{}) error {
+	query := requestQuery(r)
+	if err := CoerceFilterValues(query, filter); err != nil {
+		return &FilterBindingError{Err: err}
+	}
+	if err := checkBindingTags(filter); err != nil {
+		return &FilterBindingError{Err: err}
+	}
+
+	if binder, ok := filter.(requestBinder); ok {
+		binder.bindRequest(r)
+	}
+	if binder, ok := filter.(interface{ useTagFilters(interface{}) }); ok {
+		binder.useTagFilters(filter)
+	}
+	if provider, ok := filter.(FilterFieldsProvider); ok {
+		if binder, ok := filter.(interface {
+			bindFilterSet(url.Values, map[string]string) error
+		}); ok {
+			if err := binder.bindFilterSet(query, provider.GetFilterFields()); err != nil {
+				return &FilterBindingError{Err: err}
+			}
+		}
+	}
+	if provider, ok := filter.(AllowedIncludesProvider); ok {
+		if binder, ok := filter.(interface{ restrictIncludes(map[string]bool) }); ok {
+			binder.restrictIncludes(provider.GetAllowedIncludes())
+		}
+	}
+	if err := checkIncludeLimit(filter); err != nil {
+		return &FilterBindingError{Err: err}
+	}
+	if provider, ok := filter.(SortableFieldsProvider); ok {
+		if binder, ok := filter.(interface{ restrictSort([]string) }); ok {
+			binder.restrictSort(provider.GetSortableFields())
+		}
+	}
+	if binder, ok := filter.(interface {
+		bindSelectFields(url.Values, string, []string)
+	}); ok {
+		var resource string
+		if table, ok := filter.(interface{ GetTableName() string }); ok {
+			resource = table.GetTableName()
+		}
+		var allowed []string
+		if provider, ok := filter.(SelectableFieldsProvider); ok {
+			allowed = provider.GetSelectableFields()
+		}
+		binder.bindSelectFields(query, resource, allowed)
+	}
+	if provider, ok := filter.(SortDirectionProvider); ok {
+		if binder, ok := filter.(interface {
+			applySortDirections(url.Values, map[string]string)
+		}); ok {
+			binder.applySortDirections(query, provider.GetSortDirections())
+		}
+	}
+	return nil
+}
+
+// checkBindingTags checks the binding tags of a filter struct or pointer to one
+func checkBindingTags(filter interface{}) error {
+	value := reflect.ValueOf(filter)
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	return filterValidator().Struct(value.Interface())
+}
+
+// PaginateRequest binds filter from r and returns the page it selects, like Paginate
+// with WithCustomFilter
+func PaginateRequest[T any](db *gorm.DB, r *http.Request, filter Filterable) ([]T, PaginationResponse, error) {
+	db = withRequestContext(db, r)
+	if err := BindFilterRequest(r, filter); err != nil {
+		return nil, PaginationResponse{}, err
+	}
+	data, paginationResponse, err := paginateBoundFilter[T](db, requestQuery(r), filter)
+	return data, paginationResponse.WithPath(r), err
+}
+
+// PaginateTableRequest paginates a table with the pagination parameters of r, applying
+// the defaults registered for the table, like Paginate with WithTable
+func PaginateTableRequest[T any](
+	db *gorm.DB,
+	r *http.Request,
+	tableName string,
+	searchFields []string,
+	includes []string,
+	filters ...func(*gorm.DB) *gorm.DB,
+) ([]T, PaginationResponse, error) {
+	db = withRequestContext(db, r)
+	query := requestQuery(r)
+
+	pagination, warnings := bindTablePagination(requestContext(r), query, tableName)
+
+	builder := newTableQueryBuilder(tableName, searchFields)
+	if len(filters) > 0 {
+		builder.WithFilters(func(query *gorm.DB) *gorm.DB {
+			for _, filter := range filters {
+				if filter != nil {
+					query = filter(query)
+				}
+			}
+			return query
+		})
+	}
+	if includes == nil {
+		includes = []string{}
+	}
+
+	data, total, totalToken, mode, err := helperQuery[T](db, query, builder, pagination, includes)
+	if err != nil {
+		return nil, PaginationResponse{}, err
+	}
+
+	paginationResponse := helperPagination(requestContext(r), pagination, total, mode).WithPath(r)
+	paginationResponse.Warnings = warnings
+	paginationResponse.TotalToken = totalToken
+	return data, paginationResponse, nil
+}
+
+// PaginatedAPIResponseRequest creates a complete API response from PaginateRequest,
+// answering 400 when the query string does not bind to filter
+func PaginatedAPIResponseRequest[T any](db *gorm.DB, r *http.Request, filter Filterable, message string) PaginatedResponse {
+	data, paginationResponse, err := PaginateRequest[T](db, r, filter)
+	return filterResponse(data, paginationResponse, err, message).withRequestID(r)
+}
+
+// paginateBoundFilter runs the query of a bound filter and assembles its metadata
+func paginateBoundFilter[T any](db *gorm.DB, query url.Values, filter Filterable) ([]T, PaginationResponse, error) {
+	data, total, totalToken, mode, err := helperQuery[T](db, query, filter, filter.GetPagination(), filter.GetIncludes())
+	if err != nil {
+		return nil, PaginationResponse{}, err
+	}
+
+	paginationResponse := helperPagination(db.Statement.Context, filter.GetPagination(), total, mode)
+	paginationResponse.TotalToken = totalToken
+	if warner, ok := filter.(interface{ GetPaginationWarnings() []PaginationWarning }); ok {
+		paginationResponse.Warnings = warner.GetPaginationWarnings()
+	}
+
+	if paginationResponse.Histograms, err = Histograms(db, filter, filter.GetPagination()); err != nil {
+		return nil, PaginationResponse{}, err
+	}
+	if paginationResponse.Bounds, err = FilterBounds(db, filter, filter.GetPagination()); err != nil {
+		return nil, PaginationResponse{}, err
+	}
+	return data, paginationResponse, nil
+}
+
+// filterResponse wraps the result of a filtered page in the response envelope
+func filterResponse[T any](data []T, paginationResponse PaginationResponse, err error, message string) PaginatedResponse {
+	var coercionErr *CoercionError
+	if errors.As(err, &coercionErr) {
+		response := NewPaginatedResponse(422, "Invalid query parameters: "+err.Error(), nil, PaginationResponse{})
+		response.Errors = coercionErr.Params
+		return response
+	}
+	var bindingErr *FilterBindingError
+	if errors.As(err, &bindingErr) {
+		return NewPaginatedResponse(400, "Invalid query parameters: "+err.Error(), nil, PaginationResponse{})
+	}
+	if err != nil {
+		return NewPaginatedResponse(500, "Internal Server Error: "+err.Error(), nil, PaginationResponse{})
+	}
+
+	return NewPaginatedResponse(200, message, data, paginationResponse)
+}
+
+// writeJSON writes value to w as a JSON response with status
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	body, err := json.Marshal(value)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write(body)
+}
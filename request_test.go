@@ -0,0 +1,98 @@
+package pagination
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindPaginationValues(t *testing.T) {
+	pagination, warnings := BindPaginationValues(url.Values{"page": {"3"}, "per_page": {"500"}, "sort": {"-age"}})
+
+	assert.Equal(t, 3, pagination.Page)
+	assert.Equal(t, 100, pagination.PerPage)
+	assert.Equal(t, "age", pagination.Sort)
+	assert.Equal(t, "desc", pagination.Order)
+	assert.Len(t, warnings, 1)
+	assert.Equal(t, map[string][]string{"orders": {"id", "total"}}, ParseIncludeFieldsValues(url.Values{"fields[Orders]": {"id, total"}, "fields[]": {"x"}}))
+}
+
+func TestPaginateRequest(t *testing.T) {
+	db := setupTestDB()
+
+	users, meta, err := PaginateRequest[TestUser](db, httptest.NewRequest("GET", "/users?min_age=30&per_page=2&sort=age", nil), &testUserFilter{})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Jane Smith", "Charlie Wilson"}, userNames(users))
+	assert.Equal(t, int64(3), meta.Total)
+	assert.Equal(t, int64(2), meta.LastPage)
+	assert.Equal(t, "/users", meta.Path)
+
+	response := PaginatedAPIResponseRequest[TestUser](db, httptest.NewRequest("GET", "/users?min_age=old", nil), &testUserFilter{}, "ok")
+	assert.Equal(t, 422, response.Code)
+
+	users, meta, err = PaginateTableRequest[TestUser](db, httptest.NewRequest("GET", "/users?search=o&per_page=2", nil), "test_users", []string{"name"}, nil,
+		testUserFields.Age.Gt(30).Apply)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bob Johnson", "Charlie Wilson"}, userNames(users))
+	assert.Equal(t, int64(2), meta.Total)
+	assert.Equal(t, "/users", meta.Path)
+}
+
+func TestBindFilterRequest_BindingTags(t *testing.T) {
+	type ageFilter struct {
+		BaseFilter
+		MaxAge int `form:"max_age" binding:"omitempty,max=100"`
+	}
+
+	var bindingErr *FilterBindingError
+	assert.ErrorAs(t, BindFilterRequest(newTestRequest("/users?max_age=150"), &ageFilter{}), &bindingErr)
+	assert.NoError(t, BindFilterRequest(newTestRequest("/users?max_age=50"), &ageFilter{}))
+}
+
+func TestAdaptivePageSize_Handler(t *testing.T) {
+	sizer := &AdaptivePageSize{Threshold: 100 * time.Millisecond}
+	recordLatencies(sizer, "GET /users/{team}", time.Second)
+
+	var pagination PaginationRequest
+	mux := http.NewServeMux()
+	mux.Handle("GET /users/{team}", sizer.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pagination, _ = BindPaginationRequest(r)
+	})))
+	mux.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users/red?per_page=100", nil))
+
+	assert.Equal(t, 50, pagination.PerPage, "endpoints are told apart by their route pattern")
+}
+
+func TestCanonicalHandler(t *testing.T) {
+	var query string
+	handler := CanonicalHandler(CanonicalOptions{})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/users?sort=-age&utm=&page=2", nil))
+	assert.Equal(t, "order=desc&page=2&per_page=10&sort=age", query)
+
+	redirect := CanonicalHandler(CanonicalOptions{Redirect: true})(http.NotFoundHandler())
+	recorder := httptest.NewRecorder()
+	redirect.ServeHTTP(recorder, httptest.NewRequest("GET", "/users?page=2", nil))
+	assert.Equal(t, http.StatusMovedPermanently, recorder.Code)
+	assert.Equal(t, "/users?page=2&per_page=10", recorder.Header().Get("Location"))
+}
+
+func TestCheckNotModified(t *testing.T) {
+	lastModified := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	request := httptest.NewRequest("GET", "/users", nil)
+	request.Header.Set("If-Modified-Since", lastModified.Format(http.TimeFormat))
+
+	recorder := httptest.NewRecorder()
+	assert.True(t, CheckNotModified(recorder, request, lastModified))
+	assert.Equal(t, http.StatusNotModified, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	assert.False(t, CheckNotModified(recorder, request, lastModified.Add(time.Hour)))
+	assert.NotEmpty(t, recorder.Header().Get("Last-Modified"))
+}
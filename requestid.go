@@ -0,0 +1,75 @@
+package pagination
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// RequestIDHeader is the header carrying the request ID of a request and its response
+const RequestIDHeader = "X-Request-ID"
+
+type requestIDKey struct{}
+
+// WithRequestID returns a context carrying the request ID, pass it to the queries with
+// db.WithContext
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext returns the request ID carried by ctx, empty when none
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
+// RequestID returns the request ID of r, from its context, see RequestIDHandler, or
+// from the X-Request-ID header
+func RequestID(r *http.Request) string {
+	if r == nil {
+		return ""
+	}
+	if id := RequestIDFromContext(r.Context()); id != "" {
+		return id
+	}
+	return r.Header.Get(RequestIDHeader)
+}
+
+// RequestIDHandler propagates the X-Request-ID header of each request, generating an
+// ID when it is missing. The ID is put in the request context, where the request helpers
+// find it for error responses, debug metadata, observers and SQL comments, and echoed in
+// the response header.
+func RequestIDHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, withRequestIDHeader(w, r))
+	})
+}
+
+// withRequestIDHeader returns r carrying its request ID in its context, echoed to w
+func withRequestIDHeader(w http.ResponseWriter, r *http.Request) *http.Request {
+	id := RequestID(r)
+	if id == "" {
+		id = newRequestID()
+	}
+	w.Header().Set(RequestIDHeader, id)
+	return r.WithContext(WithRequestID(r.Context(), id))
+}
+
+// newRequestID returns a random 128-bit ID in hex
+func newRequestID() string {
+	var id [16]byte
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// withRequestID sets the request ID of r on error responses
+func (p PaginatedResponse) withRequestID(r *http.Request) PaginatedResponse {
+	if p.Code >= 400 {
+		p.RequestID = RequestID(r)
+	}
+	return p
+}
@@ -0,0 +1,73 @@
+package pagination
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIDHandler(t *testing.T) {
+	var seen string
+	router := RequestIDHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestIDFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	recorder := httptest.NewRecorder()
+	request := httptest.NewRequest("GET", "/users", nil)
+	request.Header.Set(RequestIDHeader, "req-42")
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, "req-42", seen)
+	assert.Equal(t, "req-42", recorder.Header().Get(RequestIDHeader))
+
+	recorder = httptest.NewRecorder()
+	RequestIDHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seen = RequestID(r)
+	})).ServeHTTP(recorder, httptest.NewRequest("GET", "/users", nil))
+	assert.Len(t, seen, 32, "a missing ID is generated")
+	assert.Equal(t, seen, recorder.Header().Get(RequestIDHeader))
+}
+
+func TestRequestID_ErrorResponse(t *testing.T) {
+	db := setupTestDB()
+
+	request := httptest.NewRequest("GET", "/users?min_age=old", nil)
+	request = request.WithContext(WithRequestID(request.Context(), "req-7"))
+	response := PaginatedAPIResponseRequest[TestUser](db, request, &testUserFilter{}, "ok")
+	assert.Equal(t, 422, response.Code)
+	assert.Equal(t, "req-7", response.RequestID)
+
+	request = newTestRequest("/users?min_age=30")
+	request.Header.Set(RequestIDHeader, "req-8")
+	response = PaginateResponse[TestUser](db, request, "ok", WithCustomFilter(&testUserFilter{}))
+	assert.Equal(t, 200, response.Code)
+	assert.Empty(t, response.RequestID, "successful responses carry the ID in the header only")
+
+	response = PaginateResponse[TestUser](db, request, "ok")
+	assert.Equal(t, 500, response.Code)
+	assert.Equal(t, "req-8", response.RequestID)
+}
+
+func TestRequestID_LogsAndComments(t *testing.T) {
+	db := setupTestDB()
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&logs, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	ctx := WithRequestID(context.Background(), "req-9")
+	_, _, err := PaginatedQueryWithOptions[TestUser](db.WithContext(ctx), NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 1, PerPage: 10}, nil, PaginatedQueryOptions{Dialect: SQLite, Observer: NewSlogObserver(logger)})
+	assert.NoError(t, err)
+	assert.Contains(t, logs.String(), "request_id=req-9")
+
+	request := httptest.NewRequest("GET", "/users", nil)
+	assert.Empty(t, RequestQueryTags(request, "/users")["request_id"])
+	assert.Equal(t, "req-9", RequestQueryTags(request.WithContext(ctx), "/users")["request_id"])
+
+	var debug *DebugMeta
+	assert.Nil(t, debug.WithRequest(request))
+	assert.Equal(t, "req-9", debug.WithRequest(request.WithContext(ctx)).RequestID)
+}
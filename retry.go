@@ -0,0 +1,101 @@
+package pagination
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// RetryPolicy retries the count and data queries of read-only listings on transient
+// errors such as deadlocks and connection resets
+type RetryPolicy struct {
+	// MaxAttempts includes the first attempt (default 3)
+	MaxAttempts int
+	// BaseDelay is doubled after every attempt (default 20ms) up to MaxDelay (default 1s);
+	// each wait is drawn at random below it so retrying requests spread out
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Retryable classifies errors, IsTransientError by default
+	Retryable func(error) bool
+}
+
+// transientErrorMessages are driver messages of errors worth retrying
+var transientErrorMessages = []string{
+	"deadlock",            // MySQL 1213, PostgreSQL 40P01, SQL Server 1205
+	"lock wait timeout",   // MySQL 1205
+	"could not serialize", // PostgreSQL 40001
+	"database is locked",  // SQLite
+	"connection reset",    // peers dropping idle connections
+	"broken pipe",         // writes to a closed connection
+	"bad connection",      // driver.ErrBadConn
+	"server closed the connection unexpectedly",
+	"invalid connection",
+}
+
+// IsTransientError reports whether err is a deadlock, serialization failure or dropped
+// connection that a retry of a read-only query can overcome; cancellations are not
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+
+	message := strings.ToLower(err.Error())
+	for _, transient := range transientErrorMessages {
+		if strings.Contains(message, transient) {
+			return true
+		}
+	}
+	return false
+}
+
+// run calls attempt until it succeeds, fails permanently, runs out of attempts or ctx
+// ends; retrying tells attempt to clear the error of the previous one
+func (p *RetryPolicy) run(ctx context.Context, attempt func(retrying bool) error) error {
+	if p == nil {
+		return attempt(false)
+	}
+
+	attempts := p.MaxAttempts
+	if attempts <= 0 {
+		attempts = 3
+	}
+	delay, maxDelay := p.BaseDelay, p.MaxDelay
+	if delay <= 0 {
+		delay = 20 * time.Millisecond
+	}
+	if maxDelay <= 0 {
+		maxDelay = time.Second
+	}
+	retryable := p.Retryable
+	if retryable == nil {
+		retryable = IsTransientError
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	for i := 1; ; i++ {
+		err := attempt(i > 1)
+		if err == nil || i >= attempts || !retryable(err) {
+			return err
+		}
+
+		timer := time.NewTimer(rand.N(delay) + 1)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+		delay = min(delay*2, maxDelay)
+	}
+}
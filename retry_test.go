@@ -0,0 +1,82 @@
+package pagination
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// failQueries makes the next n queries fail with err before they reach the database
+func failQueries(db *gorm.DB, n int, err error) *int {
+	attempts := 0
+	fail := func(tx *gorm.DB) {
+		attempts++
+		if attempts <= n {
+			tx.AddError(err)
+		}
+	}
+	db.Callback().Query().Before("gorm:query").Register("test:fail", fail)
+	return &attempts
+}
+
+func TestPaginatedQuery_RetriesTransientErrors(t *testing.T) {
+	db := setupTestDB()
+	attempts := failQueries(db, 2, errors.New("Error 1213: Deadlock found when trying to get lock"))
+
+	users, total, err := PaginatedQueryWithOptions[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 1, PerPage: 2}, nil, PaginatedQueryOptions{
+		Dialect: SQLite,
+		Retry:   &RetryPolicy{BaseDelay: time.Millisecond},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.Len(t, users, 2)
+	assert.Equal(t, 4, *attempts, "two failed counts, then the count and the data query")
+}
+
+func TestPaginatedQuery_RetryGivesUp(t *testing.T) {
+	db := setupTestDB()
+	attempts := failQueries(db, 10, driver.ErrBadConn)
+
+	_, _, err := PaginatedQueryWithOptions[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 1, PerPage: 2}, nil, PaginatedQueryOptions{
+		Dialect: SQLite,
+		Retry:   &RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond},
+	})
+	assert.ErrorIs(t, err, driver.ErrBadConn)
+	assert.Equal(t, 2, *attempts)
+
+	db = setupTestDB()
+	attempts = failQueries(db, 10, errors.New("no such column: nme"))
+	_, _, err = PaginatedQueryWithOptions[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 1, PerPage: 2}, nil, PaginatedQueryOptions{
+		Dialect: SQLite,
+		Retry:   &RetryPolicy{},
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, *attempts, "permanent errors are not retried")
+}
+
+func TestRetryPolicy_StopsWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	calls := 0
+	err := (&RetryPolicy{MaxAttempts: 5, BaseDelay: time.Hour}).run(ctx, func(bool) error {
+		calls++
+		cancel()
+		return errors.New("connection reset by peer")
+	})
+	assert.ErrorContains(t, err, "connection reset")
+	assert.Equal(t, 1, calls)
+}
+
+func TestIsTransientError(t *testing.T) {
+	assert.True(t, IsTransientError(fmt.Errorf("failed: %w", driver.ErrBadConn)))
+	assert.True(t, IsTransientError(errors.New("ERROR: could not serialize access due to concurrent update (SQLSTATE 40001)")))
+	assert.True(t, IsTransientError(errors.New("database is locked")))
+	assert.False(t, IsTransientError(context.DeadlineExceeded))
+	assert.False(t, IsTransientError(errors.New("syntax error at or near")))
+	assert.False(t, IsTransientError(nil))
+}
@@ -0,0 +1,264 @@
+package pagination
+
+import (
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// QueryRewrite reports one filter predicate replaced by an index friendly equivalent
+type QueryRewrite struct {
+	Rule   string `json:"rule"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// DebugMeta holds diagnostics of how a page was queried
+type DebugMeta struct {
+	Rewrites []QueryRewrite `json:"rewrites,omitempty"`
+	// RequestID is the ID of the request, set by WithRequest
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// WithRequest returns the diagnostics with the request ID of r, see RequestID, and
+// diagnostics holding only the ID when d is nil and r has one
+func (d *DebugMeta) WithRequest(r *http.Request) *DebugMeta {
+	id := RequestID(r)
+	if id == "" {
+		return d
+	}
+	if d == nil {
+		return &DebugMeta{RequestID: id}
+	}
+	withID := *d
+	withID.RequestID = id
+	return &withID
+}
+
+// RewriteRule replaces a filter predicate when it is safe to do so; ok is false to
+// leave the predicate untouched
+type RewriteRule struct {
+	Name    string
+	Rewrite func(expr clause.Expr) (rewritten clause.Expr, ok bool)
+}
+
+// QueryRewriter rewrites predicates added by ApplyFilters that defeat indexes, such as
+// YEAR(created_at) = ?, into equivalent range or prefix predicates. Only predicates passed
+// to Where as a whole are matched, and rules bail out unless the result is equivalent.
+// Like TotalReuse it records what it did, so use one per request.
+type QueryRewriter struct {
+	// Rules are tried in order on each predicate, DefaultRewriteRules when nil
+	Rules []RewriteRule
+
+	applied []QueryRewrite
+}
+
+var (
+	rewriteColumn = `([A-Za-z_][A-Za-z0-9_.]*)`
+
+	yearPredicate = regexp.MustCompile(`(?i)^\s*(?:YEAR\(\s*` + rewriteColumn + `\s*\)|EXTRACT\(\s*YEAR\s+FROM\s+` +
+		rewriteColumn + `\s*\)|STRFTIME\(\s*'%Y'\s*,\s*` + rewriteColumn + `\s*\))\s*=\s*\?\s*$`)
+	datePredicate = regexp.MustCompile(`(?i)^\s*(?:DATE\(\s*` + rewriteColumn + `\s*\)|CAST\(\s*` +
+		rewriteColumn + `\s+AS\s+DATE\s*\))\s*=\s*\?\s*$`)
+	likePredicate = regexp.MustCompile(`(?i)^\s*` + rewriteColumn + `\s+LIKE\s+\?\s*$`)
+)
+
+// YearRangeRule turns YEAR(col) = ?, EXTRACT(YEAR FROM col) = ? and strftime('%Y', col) = ?
+// into a range over the year in UTC, like InYear
+var YearRangeRule = RewriteRule{Name: "year_range", Rewrite: func(expr clause.Expr) (clause.Expr, bool) {
+	column := matchedColumn(yearPredicate, expr)
+	if column == "" {
+		return expr, false
+	}
+	year, ok := rewriteYear(expr.Vars[0])
+	if !ok {
+		return expr, false
+	}
+	start := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	return rangeExpr(column, start, start.AddDate(1, 0, 0)), true
+}}
+
+// DateRangeRule turns DATE(col) = ? and CAST(col AS DATE) = ? into a range over the day,
+// like OnDate; the date is a time.Time or a YYYY-MM-DD string read as UTC
+var DateRangeRule = RewriteRule{Name: "date_range", Rewrite: func(expr clause.Expr) (clause.Expr, bool) {
+	column := matchedColumn(datePredicate, expr)
+	if column == "" {
+		return expr, false
+	}
+
+	var day time.Time
+	switch value := expr.Vars[0].(type) {
+	case time.Time:
+		day = startOfDay(value)
+	case string:
+		parsed, err := time.Parse(time.DateOnly, value)
+		if err != nil {
+			return expr, false
+		}
+		day = parsed
+	default:
+		return expr, false
+	}
+	return rangeExpr(column, day, day.AddDate(0, 0, 1)), true
+}}
+
+// MatchAllLikeRule turns col LIKE '%', which scans every row, into col IS NOT NULL
+var MatchAllLikeRule = RewriteRule{Name: "match_all_like", Rewrite: func(expr clause.Expr) (clause.Expr, bool) {
+	column := matchedColumn(likePredicate, expr)
+	pattern, ok := likePattern(expr)
+	if column == "" || !ok || pattern == "" || strings.Trim(pattern, "%") != "" {
+		return expr, false
+	}
+	return clause.Expr{SQL: column + " IS NOT NULL"}, true
+}}
+
+// ReversedSuffixRule turns suffix searches such as email LIKE '%@example.com', which
+// cannot use an index on email, into a prefix search on a column storing the reversed
+// value, e.g. a generated column maintained by the database. columns maps each column
+// to its reversed copy.
+func ReversedSuffixRule(columns map[string]string) RewriteRule {
+	return RewriteRule{Name: "reversed_suffix", Rewrite: func(expr clause.Expr) (clause.Expr, bool) {
+		reversed, known := columns[matchedColumn(likePredicate, expr)]
+		pattern, ok := likePattern(expr)
+		if !known || !ok || !isValidSortField(reversed) || !strings.HasPrefix(pattern, "%") {
+			return expr, false
+		}
+		suffix := pattern[1:]
+		if suffix == "" || strings.ContainsAny(suffix, `%_\`) {
+			return expr, false
+		}
+		return clause.Expr{SQL: reversed + " LIKE ?", Vars: []interface{}{reverseString(suffix) + "%"}}, true
+	}}
+}
+
+// DefaultRewriteRules are the rules of a QueryRewriter without Rules; none of them
+// needs knowledge of the schema
+var DefaultRewriteRules = []RewriteRule{YearRangeRule, DateRangeRule, MatchAllLikeRule}
+
+// Rewrites returns the rewrites applied by the last query
+func (r *QueryRewriter) Rewrites() []QueryRewrite {
+	if r == nil {
+		return nil
+	}
+	return r.applied
+}
+
+// DebugMeta returns the rewrites for PaginationResponse.Debug, nil when nothing was rewritten
+func (r *QueryRewriter) DebugMeta() *DebugMeta {
+	if len(r.Rewrites()) == 0 {
+		return nil
+	}
+	return &DebugMeta{Rewrites: r.applied}
+}
+
+// rewrite replaces the matching WHERE predicates of query; record is false for the
+// second query of a page so each rewrite is reported once
+func (r *QueryRewriter) rewrite(query *gorm.DB, record bool) *gorm.DB {
+	if r == nil {
+		return query
+	}
+	if record {
+		r.applied = nil
+	}
+
+	whereClause, ok := query.Statement.Clauses["WHERE"]
+	if !ok {
+		return query
+	}
+	where, ok := whereClause.Expression.(clause.Where)
+	if !ok {
+		return query
+	}
+
+	rules := r.Rules
+	if rules == nil {
+		rules = DefaultRewriteRules
+	}
+
+	// The clause is shared with the statement query was cloned from, build a new one
+	exprs := make([]clause.Expression, len(where.Exprs))
+	changed := false
+	for i, expression := range where.Exprs {
+		exprs[i] = expression
+		expr, ok := expression.(clause.Expr)
+		if !ok {
+			continue
+		}
+		for _, rule := range rules {
+			rewritten, ok := rule.Rewrite(expr)
+			if !ok {
+				continue
+			}
+			if record {
+				r.applied = append(r.applied, QueryRewrite{Rule: rule.Name, Before: expr.SQL, After: rewritten.SQL})
+			}
+			exprs[i] = rewritten
+			changed = true
+			break
+		}
+	}
+
+	if changed {
+		whereClause.Expression = clause.Where{Exprs: exprs}
+		query.Statement.Clauses["WHERE"] = whereClause
+	}
+	return query
+}
+
+// matchedColumn returns the column of a single-placeholder predicate matching pattern
+func matchedColumn(pattern *regexp.Regexp, expr clause.Expr) string {
+	if len(expr.Vars) != 1 {
+		return ""
+	}
+	match := pattern.FindStringSubmatch(expr.SQL)
+	if match == nil {
+		return ""
+	}
+	for _, column := range match[1:] {
+		if column != "" {
+			return column
+		}
+	}
+	return ""
+}
+
+func likePattern(expr clause.Expr) (string, bool) {
+	if len(expr.Vars) != 1 {
+		return "", false
+	}
+	pattern, ok := expr.Vars[0].(string)
+	return pattern, ok
+}
+
+func rewriteYear(value interface{}) (int, bool) {
+	switch year := value.(type) {
+	case int:
+		return year, true
+	case int64:
+		return int(year), true
+	case int32:
+		return int(year), true
+	case string:
+		parsed, err := strconv.Atoi(year)
+		return parsed, err == nil && len(year) == 4
+	default:
+		return 0, false
+	}
+}
+
+func rangeExpr(column string, start, end time.Time) clause.Expr {
+	return clause.Expr{SQL: column + " >= ? AND " + column + " < ?", Vars: []interface{}{start, end}}
+}
+
+func reverseString(value string) string {
+	runes := []rune(value)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
@@ -0,0 +1,116 @@
+package pagination
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+func TestQueryRewriter_DateRange(t *testing.T) {
+	db := setupSignupsDB()
+	statements := captureSQL(db)
+	builder := NewSimpleQueryBuilder("test_signups").WithFilters(func(query *gorm.DB) *gorm.DB {
+		return query.Where("DATE(created_at) = ?", "2024-01-04").Where("plan = ?", "free")
+	})
+	pagination := PaginationRequest{Page: 1, PerPage: 10}
+
+	plain, plainTotal, err := PaginatedQueryWithOptions[TestSignup](db, builder, pagination, nil, PaginatedQueryOptions{Dialect: SQLite})
+	assert.NoError(t, err)
+
+	rewriter := &QueryRewriter{}
+	*statements = nil
+	rewritten, total, err := PaginatedQueryWithOptions[TestSignup](db, builder, pagination, nil, PaginatedQueryOptions{Dialect: SQLite, Rewriter: rewriter})
+	assert.NoError(t, err)
+
+	assert.Equal(t, int64(2), total)
+	assert.Equal(t, plainTotal, total)
+	assert.Equal(t, plain, rewritten, "the rewrite selects the same rows")
+	for _, statement := range *statements {
+		assert.NotContains(t, statement, "DATE(created_at)")
+		assert.Contains(t, statement, "created_at >= ")
+	}
+
+	assert.Equal(t, []QueryRewrite{{
+		Rule:   "date_range",
+		Before: "DATE(created_at) = ?",
+		After:  "created_at >= ? AND created_at < ?",
+	}}, rewriter.Rewrites(), "each rewrite is reported once per page")
+}
+
+func TestQueryRewriter_YearRange(t *testing.T) {
+	db := setupSignupsDB()
+	builder := NewSimpleQueryBuilder("test_signups").WithFilters(func(query *gorm.DB) *gorm.DB {
+		return query.Where("strftime('%Y', created_at) = ?", "2024")
+	})
+
+	rewriter := &QueryRewriter{}
+	_, total, err := PaginatedQueryWithOptions[TestSignup](db, builder, PaginationRequest{Page: 1, PerPage: 10}, nil, PaginatedQueryOptions{Dialect: SQLite, Rewriter: rewriter})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(7), total)
+	assert.Len(t, rewriter.Rewrites(), 1)
+	assert.Equal(t, "year_range", rewriter.Rewrites()[0].Rule)
+}
+
+func TestQueryRewriter_LeavesUnsafePredicates(t *testing.T) {
+	expressions := []clause.Expr{
+		{SQL: "YEAR(created_at) = ? OR id = 1", Vars: []interface{}{2024}},
+		{SQL: "YEAR(created_at) = ?", Vars: []interface{}{"24"}},
+		{SQL: "DATE(created_at) = ?", Vars: []interface{}{"yesterday"}},
+		{SQL: "name LIKE ?", Vars: []interface{}{"%ann%"}},
+		{SQL: "name LIKE ?", Vars: []interface{}{""}},
+	}
+
+	for _, expr := range expressions {
+		for _, rule := range DefaultRewriteRules {
+			_, ok := rule.Rewrite(expr)
+			assert.False(t, ok, "%s must not rewrite %s %v", rule.Name, expr.SQL, expr.Vars)
+		}
+	}
+
+	rewritten, ok := MatchAllLikeRule.Rewrite(clause.Expr{SQL: "name LIKE ?", Vars: []interface{}{"%%"}})
+	assert.True(t, ok)
+	assert.Equal(t, "name IS NOT NULL", rewritten.SQL)
+}
+
+func TestReversedSuffixRule(t *testing.T) {
+	rule := ReversedSuffixRule(map[string]string{"email": "email_reversed"})
+
+	rewritten, ok := rule.Rewrite(clause.Expr{SQL: "email LIKE ?", Vars: []interface{}{"%@example.com"}})
+	assert.True(t, ok)
+	assert.Equal(t, "email_reversed LIKE ?", rewritten.SQL)
+	assert.Equal(t, []interface{}{"moc.elpmaxe@%"}, rewritten.Vars)
+
+	for _, pattern := range []string{"%ann%", "%a_n", "ann%", "%"} {
+		_, ok := rule.Rewrite(clause.Expr{SQL: "email LIKE ?", Vars: []interface{}{pattern}})
+		assert.False(t, ok, pattern)
+	}
+	_, ok = rule.Rewrite(clause.Expr{SQL: "name LIKE ?", Vars: []interface{}{"%nn"}})
+	assert.False(t, ok, "columns without a reversed copy are left alone")
+}
+
+func TestQueryRewriter_DebugMeta(t *testing.T) {
+	var rewriter *QueryRewriter
+	assert.Nil(t, rewriter.DebugMeta())
+
+	db := setupSignupsDB()
+	builder := NewSimpleQueryBuilder("test_signups").WithFilters(func(query *gorm.DB) *gorm.DB {
+		return query.Where("DATE(created_at) = ?", time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC))
+	})
+	pagination := PaginationRequest{Page: 1, PerPage: 10}
+
+	rewriter = &QueryRewriter{}
+	_, total, err := PaginatedQueryWithOptions[TestSignup](db, builder, pagination, nil, PaginatedQueryOptions{Dialect: SQLite, Rewriter: rewriter})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), total)
+
+	response := CalculatePagination(pagination, total)
+	response.Debug = rewriter.DebugMeta()
+	encoded, err := json.Marshal(response)
+	assert.NoError(t, err)
+	assert.True(t, strings.Contains(string(encoded), `"debug":{"rewrites":[{"rule":"date_range"`), string(encoded))
+}
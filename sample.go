@@ -0,0 +1,99 @@
+package pagination
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// sampleOversampling compensates the variance of TABLESAMPLE so the limit is usually reached
+const sampleOversampling = 1.5
+
+// Sample returns about size random rows of the filtered set of builder. PostgreSQL,
+// SQL Server and Oracle read a sample of the table, which may return slightly fewer rows;
+// other dialects order by a random value. The total is the size of the filtered set.
+func Sample[T any](db *gorm.DB, builder QueryBuilder, pagination PaginationRequest, size int) ([]T, PaginationResponse, error) {
+	if size <= 0 {
+		return nil, PaginationResponse{}, fmt.Errorf("sample size must be positive")
+	}
+
+	var total int64
+	if err := filteredSet(db, builder, pagination).Count(&total).Error; err != nil {
+		return nil, PaginationResponse{}, fmt.Errorf("failed to count records: %w", err)
+	}
+
+	dialect := DetectDialect(db)
+	table := builder.GetTableName()
+	if total > int64(size) {
+		table += tableSampleClause(dialect, float64(size)/float64(total)*100*sampleOversampling)
+	}
+
+	query := builder.ApplyFilters(db.Table(table))
+	if pagination.Search != "" {
+		query = applySearch(query, builder, pagination, dialect)
+	}
+
+	var result []T
+	if err := query.Order(getRandomFunction(dialect)).Limit(size).Find(&result).Error; err != nil {
+		return nil, PaginationResponse{}, fmt.Errorf("failed to sample records: %w", err)
+	}
+
+	response := CalculatePagination(PaginationRequest{Page: 1, PerPage: size}, total)
+	response.Sampled = true
+	return result, response, nil
+}
+
+// tableSampleClause returns the TABLESAMPLE clause reading percent of the table,
+// empty for dialects without one
+func tableSampleClause(dialect DatabaseDialect, percent float64) string {
+	if percent >= 100 {
+		return ""
+	}
+	value := strconv.FormatFloat(percent, 'f', 4, 64)
+	switch dialect {
+	case PostgreSQL:
+		return " TABLESAMPLE BERNOULLI (" + value + ")"
+	case SQLServer:
+		return " TABLESAMPLE (" + value + " PERCENT)"
+	case Oracle:
+		return " SAMPLE (" + value + ")"
+	default:
+		return ""
+	}
+}
+
+// BindSampleValues reads ?sample=n; ok is false when no sample was requested.
+// n must be between 1 and the configured maximum page size.
+func BindSampleValues(query url.Values) (size int, ok bool, err error) {
+	value := query.Get("sample")
+	if value == "" {
+		return 0, false, nil
+	}
+
+	size, err = strconv.Atoi(value)
+	if maxSize := CurrentConfig().MaxPageSize; err != nil || size < 1 || size > maxSize {
+		return 0, false, &FilterBindingError{Err: fmt.Errorf("sample must be between 1 and %d", maxSize)}
+	}
+	return size, true, nil
+}
+
+// PaginateOrSample answers ?sample=n with a random sample of the filtered set and
+// paginates like PaginateRequest otherwise
+func PaginateOrSample[T any](db *gorm.DB, r *http.Request, filter Filterable) ([]T, PaginationResponse, error) {
+	size, ok, err := BindSampleValues(requestQuery(r))
+	if err != nil {
+		return nil, PaginationResponse{}, err
+	}
+	if !ok {
+		return PaginateRequest[T](db, r, filter)
+	}
+
+	db = withRequestContext(db, r)
+	if err := BindFilterRequest(r, filter); err != nil {
+		return nil, PaginationResponse{}, err
+	}
+	return Sample[T](db, filter, filter.GetPagination(), size)
+}
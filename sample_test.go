@@ -0,0 +1,57 @@
+package pagination
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestSample(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users").
+		WithFilters(func(query *gorm.DB) *gorm.DB { return query.Where("age >= ?", 28) })
+
+	users, meta, err := Sample[TestUser](db, builder, PaginationRequest{}, 2)
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.True(t, meta.Sampled)
+	assert.Equal(t, int64(4), meta.Total)
+	for _, user := range users {
+		assert.GreaterOrEqual(t, user.Age, 28)
+	}
+
+	users, _, err = Sample[TestUser](db, builder, PaginationRequest{}, 50)
+	assert.NoError(t, err)
+	assert.Len(t, users, 4)
+
+	_, _, err = Sample[TestUser](db, builder, PaginationRequest{}, 0)
+	assert.Error(t, err)
+}
+
+func TestTableSampleClause(t *testing.T) {
+	assert.Equal(t, " TABLESAMPLE BERNOULLI (1.5000)", tableSampleClause(PostgreSQL, 1.5))
+	assert.Equal(t, " TABLESAMPLE (1.5000 PERCENT)", tableSampleClause(SQLServer, 1.5))
+	assert.Equal(t, "", tableSampleClause(MySQL, 1.5))
+	assert.Equal(t, "", tableSampleClause(PostgreSQL, 150))
+}
+
+func TestPaginateOrSample(t *testing.T) {
+	db := setupTestDB()
+
+	users, meta, err := PaginateOrSample[TestUser](db, newTestRequest("/users?sample=3&min_age=30"), &testUserFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, users, 3)
+	assert.True(t, meta.Sampled)
+
+	users, meta, err = PaginateOrSample[TestUser](db, newTestRequest("/users?per_page=2"), &testUserFilter{})
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.False(t, meta.Sampled)
+	assert.Equal(t, int64(5), meta.Total)
+
+	_, _, err = PaginateOrSample[TestUser](db, newTestRequest("/users?sample=1000"), &testUserFilter{})
+	var bindingErr *FilterBindingError
+	assert.True(t, errors.As(err, &bindingErr))
+}
@@ -0,0 +1,133 @@
+package pagination
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// Scope is a filter condition that composes with And, Or and Not, built from typed
+// fields (see Field) or SQL fragments (see Expr). The zero Scope matches every row, so
+// optional filters can be combined without branches:
+//
+//	func (f *AthleteFilter) ApplyFilters(query *gorm.DB) *gorm.DB {
+//		return pagination.And(
+//			pagination.When(f.SportID > 0, AthleteFields.SportID.Eq(f.SportID)),
+//			pagination.Or(AthleteFields.Age.Lt(18), AthleteFields.Junior.Eq(true)),
+//		).Apply(query)
+//	}
+type Scope struct {
+	sql  string
+	vars []interface{}
+	err  error
+	// simple conditions need no parentheses inside a combination
+	simple bool
+}
+
+// Expr is a scope from a SQL fragment with ? placeholders
+func Expr(sql string, vars ...interface{}) Scope {
+	return Scope{sql: sql, vars: vars}
+}
+
+// When returns scope if condition holds and the zero Scope otherwise
+func When(condition bool, scope Scope) Scope {
+	if !condition {
+		return Scope{}
+	}
+	return scope
+}
+
+// IsZero reports whether the scope matches every row
+func (s Scope) IsZero() bool {
+	return s.sql == "" && s.err == nil
+}
+
+// And matches rows matching every scope; zero scopes are left out
+func And(scopes ...Scope) Scope {
+	return combine(" AND ", scopes, false)
+}
+
+// Or matches rows matching any scope; a zero scope makes it match every row
+func Or(scopes ...Scope) Scope {
+	return combine(" OR ", scopes, true)
+}
+
+// Not matches rows not matching scope; the negated zero Scope matches no row
+func Not(scope Scope) Scope {
+	switch {
+	case scope.err != nil:
+		return scope
+	case scope.sql == "":
+		return Scope{sql: "1 = 0", simple: true}
+	}
+	return Scope{sql: "NOT (" + scope.sql + ")", vars: scope.vars, simple: true}
+}
+
+// And is And(s, others...)
+func (s Scope) And(others ...Scope) Scope {
+	return And(append([]Scope{s}, others...)...)
+}
+
+// Or is Or(s, others...)
+func (s Scope) Or(others ...Scope) Scope {
+	return Or(append([]Scope{s}, others...)...)
+}
+
+// Not is Not(s)
+func (s Scope) Not() Scope {
+	return Not(s)
+}
+
+// combine joins scopes with separator, parenthesizing compound members; the first
+// error of a member is kept
+func combine(separator string, scopes []Scope, zeroMatchesAll bool) Scope {
+	parts := make([]Scope, 0, len(scopes))
+	for _, scope := range scopes {
+		if scope.err != nil {
+			return scope
+		}
+		if scope.sql == "" {
+			if zeroMatchesAll {
+				return Scope{}
+			}
+			continue
+		}
+		parts = append(parts, scope)
+	}
+
+	switch len(parts) {
+	case 0:
+		return Scope{}
+	case 1:
+		return parts[0]
+	}
+
+	sql := make([]string, len(parts))
+	var vars []interface{}
+	for i, part := range parts {
+		sql[i] = part.sql
+		if !part.simple {
+			sql[i] = "(" + part.sql + ")"
+		}
+		vars = append(vars, part.vars...)
+	}
+	return Scope{sql: strings.Join(sql, separator), vars: vars}
+}
+
+// Apply adds the scope to query; an invalid field is reported as a query error
+func (s Scope) Apply(query *gorm.DB) *gorm.DB {
+	switch {
+	case s.err != nil:
+		query.AddError(s.err)
+		return query
+	case s.sql == "":
+		return query
+	}
+	return query.Where(s.sql, s.vars...)
+}
+
+// Where returns a filter function applying every scope, for
+// SimpleQueryBuilder.WithFilters or query.Scopes
+func Where(scopes ...Scope) func(*gorm.DB) *gorm.DB {
+	return And(scopes...).Apply
+}
@@ -0,0 +1,42 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScope_Combinators(t *testing.T) {
+	age, name := testUserFields.Age, testUserFields.Name
+
+	scope := And(age.Gte(28), Or(name.HasPrefix("J"), Not(age.Lt(33))))
+	assert.Equal(t, "age >= ? AND (name LIKE ? ESCAPE '!' OR NOT (age < ?))", scope.sql)
+	assert.Equal(t, []interface{}{28, "J%", 33}, scope.vars)
+
+	assert.Equal(t, "(a = ? OR b = ?) AND (c = ?)", Expr("a = ? OR b = ?", 1, 2).And(Expr("c = ?", 3)).sql, "fragments are parenthesized")
+	assert.Equal(t, "age = ?", And(Scope{}, age.Eq(1), When(false, age.Eq(2))).sql)
+	assert.True(t, Or(age.Eq(1), Scope{}).IsZero(), "a zero alternative matches every row")
+	assert.True(t, And().IsZero())
+	assert.Equal(t, "1 = 0", Not(Scope{}).sql)
+	assert.Equal(t, "age = ?", age.Eq(1).Or().sql)
+}
+
+func TestScope_Apply(t *testing.T) {
+	db := setupTestDB()
+	age, name := testUserFields.Age, testUserFields.Name
+	names := func(scope Scope) []string {
+		var users []TestUser
+		assert.NoError(t, scope.Apply(db.Table("test_users")).Where("id <> ?", 0).Order("id").Find(&users).Error)
+		return userNames(users)
+	}
+
+	assert.Equal(t, []string{"Jane Smith", "Bob Johnson"}, names(And(age.Gte(28), Or(name.HasPrefix("J"), Not(age.Lt(33))))))
+	assert.Equal(t, []string{"John Doe", "Bob Johnson"}, names(Or(age.Eq(25), age.Eq(35))), "the OR stays grouped next to other conditions")
+	assert.Len(t, names(Scope{}), 5)
+	assert.Empty(t, names(Not(Scope{})))
+
+	invalid := NewField[int]("bad name")
+	var users []TestUser
+	err := And(age.Gt(1), Or(invalid.Eq(1), age.Eq(2))).Apply(db.Table("test_users")).Find(&users).Error
+	assert.ErrorContains(t, err, "invalid field name: bad name")
+}
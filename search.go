@@ -0,0 +1,193 @@
+package pagination
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SearchClause is one alternative of the search condition, OR'd with the search fields
+// of the builder and with the other clauses
+type SearchClause struct {
+	// Column is compared with the search pattern, or holds the key matched against
+	// the related table when RelatedTable is set
+	Column string
+
+	// RelatedTable matches rows whose Column is the RelatedKey of a related row
+	// with any RelatedColumns matching the pattern
+	RelatedTable   string
+	RelatedKey     string
+	RelatedColumns []string
+}
+
+// SearchClauseProvider is implemented by builders contributing search clauses
+type SearchClauseProvider interface {
+	GetSearchClauses() []SearchClause
+}
+
+// SearchColumn matches a column (or SQL expression) of the paginated table
+func SearchColumn(column string) SearchClause {
+	return SearchClause{Column: column}
+}
+
+// SearchRelated matches through a related table, e.g. athletes by province name:
+// SearchRelated("province_id", "provinces", "id", "name")
+func SearchRelated(column, table, key string, columns ...string) SearchClause {
+	return SearchClause{
+		Column:         column,
+		RelatedTable:   table,
+		RelatedKey:     key,
+		RelatedColumns: columns,
+	}
+}
+
+// SearchMode selects how the search term is compared with the search columns
+type SearchMode string
+
+const (
+	// SearchContains matches columns containing the term, the default
+	SearchContains SearchMode = "contains"
+	// SearchPrefix matches columns starting with the term
+	SearchPrefix SearchMode = "prefix"
+	// SearchExact matches columns equal to the term
+	SearchExact SearchMode = "exact"
+	// SearchFullText uses MATCH ... AGAINST on MySQL and tsvector matching on PostgreSQL,
+	// which need full-text indexes on the search columns; other dialects use SearchContains
+	SearchFullText SearchMode = "fulltext"
+)
+
+// SearchModeProvider is implemented by builders choosing their search mode
+type SearchModeProvider interface {
+	GetSearchMode() SearchMode
+}
+
+// validSearchMode reports whether mode is one of the SearchMode constants
+func validSearchMode(mode SearchMode) bool {
+	switch mode {
+	case SearchContains, SearchPrefix, SearchExact, SearchFullText:
+		return true
+	}
+	return false
+}
+
+// resolveSearchMode returns the search mode of a listing: the search_mode parameter,
+// else the mode of the builder, else Config.SearchMode. Full-text search needs indexes,
+// so the parameter only selects it where the builder or config already does.
+func resolveSearchMode(builder interface{}, pagination PaginationRequest) SearchMode {
+	configured := CurrentConfig().SearchMode
+	if provider, ok := builder.(SearchModeProvider); ok && provider.GetSearchMode() != "" {
+		configured = provider.GetSearchMode()
+	}
+	if configured == "" {
+		configured = SearchContains
+	}
+
+	if requested := pagination.SearchMode; requested != "" && (requested != SearchFullText || configured == SearchFullText) {
+		return requested
+	}
+	return configured
+}
+
+// searchMatcher returns the comparison of one column with the search term
+type searchMatcher func(column string) (string, []interface{})
+
+// newSearchMatcher compares columns with searchTerm in mode
+func newSearchMatcher(mode SearchMode, searchTerm string, dialect DatabaseDialect) searchMatcher {
+	operator := getSearchOperator(dialect)
+	compare := func(pattern string) searchMatcher {
+		return func(column string) (string, []interface{}) {
+			return column + " " + operator + " ?", []interface{}{pattern}
+		}
+	}
+
+	switch mode {
+	case SearchExact:
+		return func(column string) (string, []interface{}) {
+			return column + " = ?", []interface{}{searchTerm}
+		}
+	case SearchPrefix:
+		return compare(searchTerm + "%")
+	case SearchFullText:
+		switch dialect {
+		case MySQL:
+			return func(column string) (string, []interface{}) {
+				return "MATCH(" + column + ") AGAINST (? IN NATURAL LANGUAGE MODE)", []interface{}{searchTerm}
+			}
+		case PostgreSQL:
+			return func(column string) (string, []interface{}) {
+				return "to_tsvector(" + column + ") @@ plainto_tsquery(?)", []interface{}{searchTerm}
+			}
+		}
+	}
+	return compare("%" + searchTerm + "%")
+}
+
+// condition renders the clause with one placeholder per compared column
+func (c SearchClause) condition(operator string, pattern string) (string, []interface{}) {
+	return c.matchCondition(func(column string) (string, []interface{}) {
+		return column + " " + operator + " ?", []interface{}{pattern}
+	})
+}
+
+// matchCondition renders the clause comparing each column with match
+func (c SearchClause) matchCondition(match searchMatcher) (string, []interface{}) {
+	if c.RelatedTable == "" {
+		return match(c.Column)
+	}
+
+	conditions := make([]string, len(c.RelatedColumns))
+	var args []interface{}
+	for i, column := range c.RelatedColumns {
+		condition, columnArgs := match(column)
+		conditions[i] = condition
+		args = append(args, columnArgs...)
+	}
+	return c.Column + " IN (SELECT " + c.RelatedKey + " FROM " + c.RelatedTable + " WHERE " + strings.Join(conditions, " OR ") + ")", args
+}
+
+// applySearch applies the search fields and clauses of the builder as one OR group,
+// compared in the search mode of the listing
+func applySearch(query *gorm.DB, builder QueryBuilder, pagination PaginationRequest, dialect DatabaseDialect) *gorm.DB {
+	var clauses []SearchClause
+	for _, field := range builder.GetSearchFields() {
+		clauses = append(clauses, SearchColumn(field))
+	}
+	if provider, ok := builder.(SearchClauseProvider); ok {
+		clauses = append(clauses, provider.GetSearchClauses()...)
+	}
+
+	mode := resolveSearchMode(builder, pagination)
+	return applySearchClauses(query, pagination.Search, clauses, newSearchMatcher(mode, pagination.Search, dialect))
+}
+
+// filteredSet returns the rows of the builder's table matching its filters and the search term
+func filteredSet(db *gorm.DB, builder QueryBuilder, pagination PaginationRequest) *gorm.DB {
+	query := builder.ApplyFilters(db.Table(builder.GetTableName()))
+	if pagination.Search != "" {
+		query = applySearch(query, builder, pagination, DetectDialect(db))
+	}
+	return query
+}
+
+// applySearchClauses ORs the clauses matching the search term
+func applySearchClauses(query *gorm.DB, searchTerm string, clauses []SearchClause, match searchMatcher) *gorm.DB {
+	if len(clauses) == 0 || searchTerm == "" {
+		return query
+	}
+
+	var conditions []string
+	var args []interface{}
+	for _, clause := range clauses {
+		condition, clauseArgs := clause.matchCondition(match)
+		if len(clauseArgs) == 0 {
+			continue
+		}
+		conditions = append(conditions, condition)
+		args = append(args, clauseArgs...)
+	}
+	if len(conditions) == 0 {
+		return query
+	}
+
+	return query.Where("("+strings.Join(conditions, " OR ")+")", args...)
+}
@@ -0,0 +1,107 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type TestUserTag struct {
+	ID     uint
+	UserID uint
+	Tag    string
+}
+
+func TestSearchClause_Condition(t *testing.T) {
+	condition, args := SearchColumn("name").condition("LIKE", "%a%")
+	assert.Equal(t, "name LIKE ?", condition)
+	assert.Len(t, args, 1)
+
+	condition, args = SearchRelated("province_id", "provinces", "id", "name", "code").condition("ILIKE", "%a%")
+	assert.Equal(t, "province_id IN (SELECT id FROM provinces WHERE name ILIKE ? OR code ILIKE ?)", condition)
+	assert.Len(t, args, 2)
+}
+
+func TestPaginatedQuery_SearchClauses(t *testing.T) {
+	db := setupTestDB()
+	db.AutoMigrate(&TestUserTag{})
+	db.Create(&TestUserTag{UserID: 3, Tag: "vip"})
+
+	builder := NewSimpleQueryBuilder("test_users").
+		WithSearchFields("name").
+		WithSearchClauses(
+			SearchColumn("email"),
+			SearchRelated("id", "test_user_tags", "user_id", "tag"),
+		)
+
+	data, _, err := PaginatedQuery[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 10, Search: "vip"}, nil)
+	assert.NoError(t, err)
+	if assert.Len(t, data, 1) {
+		assert.Equal(t, "Bob Johnson", data[0].Name)
+	}
+
+	data, _, err = PaginatedQuery[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 10, Search: "alice@"}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, data, 1)
+
+	data, _, err = PaginatedQuery[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 10, Search: "Jane"}, nil)
+	assert.NoError(t, err)
+	assert.Len(t, data, 1)
+}
+
+func TestNewSearchMatcher(t *testing.T) {
+	condition, args := newSearchMatcher(SearchPrefix, "jo", PostgreSQL)("name")
+	assert.Equal(t, "name ILIKE ?", condition)
+	assert.Equal(t, []interface{}{"jo%"}, args)
+
+	condition, args = newSearchMatcher(SearchExact, "jo", MySQL)("name")
+	assert.Equal(t, "name = ?", condition)
+	assert.Equal(t, []interface{}{"jo"}, args)
+
+	condition, _ = newSearchMatcher(SearchFullText, "jo", MySQL)("name")
+	assert.Equal(t, "MATCH(name) AGAINST (? IN NATURAL LANGUAGE MODE)", condition)
+
+	condition, _ = newSearchMatcher(SearchFullText, "jo", PostgreSQL)("name")
+	assert.Equal(t, "to_tsvector(name) @@ plainto_tsquery(?)", condition)
+
+	condition, args = newSearchMatcher(SearchFullText, "jo", SQLite)("name")
+	assert.Equal(t, "name LIKE ?", condition)
+	assert.Equal(t, []interface{}{"%jo%"}, args, "dialects without full-text search contain the term")
+}
+
+func TestResolveSearchMode(t *testing.T) {
+	builder := NewSimpleQueryBuilder("test_users")
+	assert.Equal(t, SearchContains, resolveSearchMode(builder, PaginationRequest{}))
+	assert.Equal(t, SearchExact, resolveSearchMode(builder, PaginationRequest{SearchMode: SearchExact}))
+	assert.Equal(t, SearchContains, resolveSearchMode(builder, PaginationRequest{SearchMode: SearchFullText}), "no full-text index declared")
+
+	builder.WithSearchMode(SearchFullText)
+	assert.Equal(t, SearchFullText, resolveSearchMode(builder, PaginationRequest{}))
+	assert.Equal(t, SearchPrefix, resolveSearchMode(builder, PaginationRequest{SearchMode: SearchPrefix}))
+
+	useTestConfig(t, map[string]string{EnvSearchMode: "Prefix"})
+	assert.Equal(t, SearchPrefix, resolveSearchMode(NewSimpleQueryBuilder("test_users"), PaginationRequest{}))
+	assert.Error(t, LoadConfig(map[string]string{EnvSearchMode: "fuzzy"}))
+}
+
+func TestPaginatedQuery_SearchMode(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users").WithSearchFields("name").WithSearchMode(SearchPrefix)
+	options := PaginatedQueryOptions{Dialect: SQLite}
+
+	data, _, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 10, Search: "J"}, nil, options)
+	assert.NoError(t, err)
+	assert.Len(t, data, 2, "John Doe and Jane Smith, not Bob Johnson")
+
+	data, _, err = PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 10, Search: "Jane Smith", SearchMode: SearchExact}, nil, options)
+	assert.NoError(t, err)
+	assert.Len(t, data, 1)
+
+	pagination, warnings := BindPaginationValues(map[string][]string{"search": {"jo"}, "search_mode": {"EXACT"}})
+	assert.Equal(t, SearchExact, pagination.SearchMode)
+	assert.Empty(t, warnings)
+
+	pagination, warnings = BindPaginationValues(map[string][]string{"search_mode": {"fuzzy"}})
+	assert.Empty(t, pagination.SearchMode)
+	assert.Len(t, warnings, 1)
+}
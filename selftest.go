@@ -0,0 +1,204 @@
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// ValidationProblem is a misconfiguration of a filter found by Validate
+type ValidationProblem struct {
+	Table string `json:"table"`
+	// Check is the probe that failed: "table", "sort", "search", "filter", "select" or
+	// "explain"
+	Check   string `json:"check"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidationReport is the result of Validate
+type ValidationReport struct {
+	// Tables are the tables probed, in the order of the filters
+	Tables   []string            `json:"tables"`
+	Problems []ValidationProblem `json:"problems"`
+}
+
+// OK reports whether no probe failed
+func (r ValidationReport) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// Err returns the problems as one error, nil when there are none
+func (r ValidationReport) Err() error {
+	errs := make([]error, len(r.Problems))
+	for i, problem := range r.Problems {
+		errs[i] = errors.New(problem.String())
+	}
+	return errors.Join(errs...)
+}
+
+func (p ValidationProblem) String() string {
+	if p.Field != "" {
+		return fmt.Sprintf("%s: %s %s: %s", p.Table, p.Check, p.Field, p.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", p.Table, p.Check, p.Message)
+}
+
+// Validate probes the setup of filters against db, typically at startup, so misconfigured
+// filters fail before traffic hits them. It checks that the table of each filter exists
+// and has the columns of its default sort, sortable fields, sort presets, search fields,
+// filter tags naming a column, operator filters and selectable fields, then runs EXPLAIN
+// on its data and search queries where the database supports it. Nothing is written.
+//
+//	if report := pagination.Validate(db, &UserFilter{}, &OrderFilter{}); !report.OK() {
+//		log.Fatal(report.Err())
+//	}
+func Validate(db *gorm.DB, filters ...QueryBuilder) ValidationReport {
+	report := ValidationReport{Tables: []string{}, Problems: []ValidationProblem{}}
+	for _, filter := range filters {
+		table := filter.GetTableName()
+		report.Tables = append(report.Tables, table)
+		report.Problems = append(report.Problems, validateFilter(db, filter)...)
+	}
+	return report
+}
+
+// validateFilter runs the probes of one filter
+func validateFilter(db *gorm.DB, filter QueryBuilder) []ValidationProblem {
+	table := filter.GetTableName()
+	name := strings.Fields(table)
+	if len(name) == 0 {
+		return []ValidationProblem{{Table: table, Check: "table", Message: "filter has no table"}}
+	}
+	if !db.Migrator().HasTable(name[0]) {
+		return []ValidationProblem{{Table: table, Check: "table", Message: "table does not exist"}}
+	}
+
+	columnTypes, err := db.Migrator().ColumnTypes(name[0])
+	if err != nil {
+		return []ValidationProblem{{Table: table, Check: "table", Message: err.Error()}}
+	}
+	columns := make(map[string]bool, len(columnTypes))
+	for _, column := range columnTypes {
+		columns[strings.ToLower(column.Name())] = true
+	}
+
+	var problems []ValidationProblem
+	check := func(kind string, fields ...string) {
+		for _, field := range fields {
+			column, ok := ownColumn(field, tableQualifier(table))
+			if ok && !columns[strings.ToLower(column)] {
+				problems = append(problems, ValidationProblem{Table: table, Check: kind, Field: field, Message: "column does not exist"})
+			}
+		}
+	}
+
+	check("sort", orderColumns(filter.GetDefaultSort())...)
+	if provider, ok := filter.(SortableFieldsProvider); ok {
+		check("sort", provider.GetSortableFields()...)
+	}
+	if provider, ok := filter.(SortPresetProvider); ok {
+		for _, preset := range sortedKeys(provider.GetSortPresets()) {
+			check("sort", orderColumns(provider.GetSortPresets()[preset])...)
+		}
+	}
+	check("search", filter.GetSearchFields()...)
+	for _, param := range filterParams(reflect.ValueOf(filter), nil) {
+		if param.tagged {
+			check("filter", param.field)
+		}
+	}
+	if provider, ok := filter.(FilterFieldsProvider); ok {
+		fields := provider.GetFilterFields()
+		for _, param := range sortedKeys(fields) {
+			check("filter", fields[param])
+		}
+	}
+	if provider, ok := filter.(SelectableFieldsProvider); ok {
+		check("select", provider.GetSelectableFields()...)
+	}
+
+	if len(problems) == 0 {
+		problems = append(problems, explainFilter(db, filter)...)
+	}
+	return problems
+}
+
+// explainFilter runs EXPLAIN on the first page of filter and on a search of it
+func explainFilter(db *gorm.DB, filter QueryBuilder) []ValidationProblem {
+	dialect := DetectDialect(db)
+	var prefix string
+	switch dialect {
+	case SQLite:
+		prefix = "EXPLAIN QUERY PLAN "
+	case MySQL, PostgreSQL, ClickHouse:
+		prefix = "EXPLAIN "
+	default:
+		return nil
+	}
+
+	page := func(tx *gorm.DB) *gorm.DB {
+		query := filter.ApplyFilters(tx.Table(filter.GetTableName()))
+		return query.Order(filter.GetDefaultSort()).Limit(1)
+	}
+	queries := map[string]func(tx *gorm.DB) *gorm.DB{"page": page}
+	if len(filter.GetSearchFields()) > 0 {
+		queries["search"] = func(tx *gorm.DB) *gorm.DB {
+			return applySearch(page(tx), filter, PaginationRequest{Search: "probe"}, dialect)
+		}
+	}
+
+	// Failures are reported, not logged
+	probe := db.Session(&gorm.Session{NewDB: true, Logger: db.Logger.LogMode(logger.Silent)})
+	var problems []ValidationProblem
+	for _, kind := range sortedKeys(queries) {
+		stmt := queries[kind](probe.Session(&gorm.Session{DryRun: true})).Find(&[]map[string]interface{}{}).Statement
+		if stmt.Error != nil {
+			problems = append(problems, ValidationProblem{Table: filter.GetTableName(), Check: "explain", Field: kind, Message: stmt.Error.Error()})
+			continue
+		}
+		rows, err := probe.Raw(prefix+stmt.SQL.String(), stmt.Vars...).Rows()
+		if err != nil {
+			problems = append(problems, ValidationProblem{Table: filter.GetTableName(), Check: "explain", Field: kind, Message: err.Error()})
+			continue
+		}
+		rows.Close()
+	}
+	return problems
+}
+
+// ownColumn returns the column of field unless it names another table, as in
+// "provinces.name" on a cities filter; ok is false for fields that are not plain columns
+func ownColumn(field, table string) (string, bool) {
+	field = strings.Trim(strings.TrimSpace(field), "`\"")
+	if field == "" || strings.ContainsAny(field, "() ") {
+		return "", false
+	}
+	qualifier, column, qualified := strings.Cut(field, ".")
+	if !qualified {
+		return field, true
+	}
+	if !strings.EqualFold(strings.Trim(qualifier, "`\""), table) {
+		return "", false
+	}
+	return strings.Trim(column, "`\""), true
+}
+
+// orderColumns returns the fields of an ORDER BY clause such as "name asc, id desc";
+// clauses with expressions are left to EXPLAIN
+func orderColumns(order string) []string {
+	if strings.ContainsAny(order, "('") {
+		return nil
+	}
+	var fields []string
+	for _, entry := range strings.Split(order, ",") {
+		if parts := strings.Fields(entry); len(parts) > 0 {
+			fields = append(fields, parts[0])
+		}
+	}
+	return fields
+}
@@ -0,0 +1,57 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+type misconfiguredFilter struct {
+	BaseFilter
+	Level int `form:"level" filter:"column=rank,op=gte"`
+}
+
+func (f *misconfiguredFilter) GetTableName() string   { return "test_users" }
+func (f *misconfiguredFilter) GetDefaultSort() string { return "created_at desc, id desc" }
+func (f *misconfiguredFilter) GetSearchFields() []string {
+	return []string{"name", "nickname", "teams.name"}
+}
+func (f *misconfiguredFilter) GetSortableFields() []string   { return []string{"age"} }
+func (f *misconfiguredFilter) GetSelectableFields() []string { return []string{"id", "password"} }
+
+type brokenFilterQuery struct {
+	*SimpleQueryBuilder
+}
+
+func (b brokenFilterQuery) ApplyFilters(query *gorm.DB) *gorm.DB {
+	return query.Where("missing_column = ?", 1)
+}
+
+func TestValidate(t *testing.T) {
+	db := setupTestDB()
+
+	report := Validate(db, &testUserFilter{}, NewSimpleQueryBuilder("test_users").WithSearchFields("name"))
+	assert.True(t, report.OK(), report.Err())
+	assert.NoError(t, report.Err())
+	assert.Equal(t, []string{"test_users", "test_users"}, report.Tables)
+
+	report = Validate(db, &misconfiguredFilter{}, NewSimpleQueryBuilder("missing_table"))
+	assert.False(t, report.OK())
+	assert.Equal(t, []ValidationProblem{
+		{Table: "test_users", Check: "sort", Field: "created_at", Message: "column does not exist"},
+		{Table: "test_users", Check: "search", Field: "nickname", Message: "column does not exist"},
+		{Table: "test_users", Check: "filter", Field: "rank", Message: "column does not exist"},
+		{Table: "test_users", Check: "select", Field: "password", Message: "column does not exist"},
+		{Table: "missing_table", Check: "table", Message: "table does not exist"},
+	}, report.Problems)
+	assert.ErrorContains(t, report.Err(), "test_users: filter rank: column does not exist")
+
+	report = Validate(db, brokenFilterQuery{NewSimpleQueryBuilder("test_users").WithSearchFields("name")})
+	if assert.Len(t, report.Problems, 2) {
+		assert.Equal(t, "explain", report.Problems[0].Check)
+		assert.Equal(t, "page", report.Problems[0].Field)
+		assert.Contains(t, report.Problems[0].Message, "missing_column")
+		assert.Equal(t, "search", report.Problems[1].Field)
+	}
+}
@@ -0,0 +1,79 @@
+package pagination
+
+import "iter"
+
+// PaginateSeq slices an arbitrary sequence into the requested page
+// The sequence is consumed to the end so the total can be reported, therefore
+// it must be finite. Use PaginateSeqWithoutTotal for unbounded sequences.
+func PaginateSeq[T any](seq iter.Seq[T], pagination PaginationRequest) ([]T, PaginationResponse) {
+	pagination.Validate()
+
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+
+	result := make([]T, 0, limit)
+	var total int64
+
+	for item := range seq {
+		if pagination.IsDisabled || (total >= int64(offset) && len(result) < limit) {
+			result = append(result, item)
+		}
+		total++
+	}
+
+	return result, CalculatePagination(pagination, total)
+}
+
+// PaginateSeqWithoutTotal slices a possibly unbounded sequence into the requested page
+// It stops reading as soon as the page is filled and reports whether more items exist.
+func PaginateSeqWithoutTotal[T any](seq iter.Seq[T], pagination PaginationRequest) ([]T, bool) {
+	pagination.Validate()
+
+	offset := pagination.GetOffset()
+	limit := pagination.GetLimit()
+
+	result := make([]T, 0, limit)
+	hasMore := false
+	index := 0
+
+	for item := range seq {
+		if index >= offset {
+			if len(result) == limit {
+				hasMore = true
+				break
+			}
+			result = append(result, item)
+		}
+		index++
+	}
+
+	return result, hasMore
+}
+
+// PaginateChannel slices the items received from a channel into the requested page
+// The channel is drained until it is closed.
+func PaginateChannel[T any](ch <-chan T, pagination PaginationRequest) ([]T, PaginationResponse) {
+	return PaginateSeq(ChannelSeq(ch), pagination)
+}
+
+// ChannelSeq adapts a channel into an iter.Seq
+func ChannelSeq[T any](ch <-chan T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for item := range ch {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
+
+// SliceSeq adapts a slice into an iter.Seq
+func SliceSeq[T any](items []T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, item := range items {
+			if !yield(item) {
+				return
+			}
+		}
+	}
+}
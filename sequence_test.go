@@ -0,0 +1,45 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginateSeq(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5, 6, 7}
+
+	page, meta := PaginateSeq(SliceSeq(items), PaginationRequest{Page: 2, PerPage: 3})
+
+	assert.Equal(t, []int{4, 5, 6}, page)
+	assert.Equal(t, int64(7), meta.Total)
+	assert.Equal(t, int64(3), meta.MaxPage)
+}
+
+func TestPaginateSeqWithoutTotal(t *testing.T) {
+	naturals := func(yield func(int) bool) {
+		for i := 1; ; i++ {
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	page, hasMore := PaginateSeqWithoutTotal(naturals, PaginationRequest{Page: 3, PerPage: 2})
+
+	assert.Equal(t, []int{5, 6}, page)
+	assert.True(t, hasMore)
+}
+
+func TestPaginateChannel(t *testing.T) {
+	ch := make(chan string, 3)
+	ch <- "a"
+	ch <- "b"
+	ch <- "c"
+	close(ch)
+
+	page, meta := PaginateChannel(ch, PaginationRequest{Page: 2, PerPage: 2})
+
+	assert.Equal(t, []string{"c"}, page)
+	assert.Equal(t, int64(3), meta.Total)
+}
@@ -0,0 +1,276 @@
+package pagination
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MarshalHook writes a value of the type it is registered for in the data of a response;
+// the result is encoded as JSON in place of the value
+type MarshalHook func(value interface{}) interface{}
+
+// ResponseSerializer maps Go types to marshal hooks, so how the times and decimals of the
+// models are written can be chosen per response without changing the models. Unlike
+// OutputFormatter it matches values by type, wherever they appear in the data.
+type ResponseSerializer struct {
+	Hooks map[reflect.Type]MarshalHook
+}
+
+var (
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// NewResponseSerializer creates an empty ResponseSerializer
+func NewResponseSerializer() *ResponseSerializer {
+	return &ResponseSerializer{Hooks: make(map[reflect.Type]MarshalHook)}
+}
+
+// Hook registers a marshal hook for the type of sample, e.g. decimal.Decimal{}; pointers
+// to the type are written with the hook too, nil pointers as null
+func (s *ResponseSerializer) Hook(sample interface{}, hook MarshalHook) *ResponseSerializer {
+	if s.Hooks == nil {
+		s.Hooks = make(map[reflect.Type]MarshalHook)
+	}
+	s.Hooks[reflect.TypeOf(sample)] = hook
+	return s
+}
+
+// Time registers a marshal hook for time.Time
+func (s *ResponseSerializer) Time(hook MarshalHook) *ResponseSerializer {
+	return s.Hook(time.Time{}, hook)
+}
+
+// Apply returns a copy of data with the hooked values replaced, represented with generic
+// JSON values. Field names, omitempty and embedded structs follow encoding/json, except
+// that fields promoted from embedded structs of unexported types are left out.
+func (s *ResponseSerializer) Apply(data interface{}) interface{} {
+	if s == nil || len(s.Hooks) == 0 || data == nil {
+		return data
+	}
+	return s.serialize(reflect.ValueOf(data))
+}
+
+// SerializePaginatedResponse applies the serializer to the data of a response
+func SerializePaginatedResponse(response PaginatedResponse, serializer *ResponseSerializer) PaginatedResponse {
+	response.Data = serializer.Apply(response.Data)
+	return response
+}
+
+// serialize converts value to a generic JSON value, applying the hooks
+func (s *ResponseSerializer) serialize(value reflect.Value) interface{} {
+	if !value.IsValid() {
+		return nil
+	}
+	if hook, ok := s.Hooks[value.Type()]; ok {
+		return hook(value.Interface())
+	}
+
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return nil
+		}
+		return s.serialize(value.Elem())
+	case reflect.Pointer:
+		if value.IsNil() {
+			return nil
+		}
+		// Pointers with their own MarshalJSON are left to it unless their target is hooked
+		if _, ok := s.Hooks[value.Type().Elem()]; ok || !marshalsItself(value.Type()) {
+			return s.serialize(value.Elem())
+		}
+	}
+	if marshalsItself(value.Type()) {
+		return value.Interface()
+	}
+
+	switch value.Kind() {
+	case reflect.Struct:
+		object := make(map[string]interface{})
+		s.serializeFields(value, object, make(map[string]int), 0)
+		return object
+	case reflect.Map:
+		if value.IsNil() {
+			return nil
+		}
+		object := make(map[string]interface{}, value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			key, ok := jsonMapKey(iter.Key())
+			if !ok {
+				return value.Interface()
+			}
+			object[key] = s.serialize(iter.Value())
+		}
+		return object
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return nil
+		}
+		// Byte slices are written as base64 by encoding/json
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			return value.Interface()
+		}
+		items := make([]interface{}, value.Len())
+		for i := range items {
+			items[i] = s.serialize(value.Index(i))
+		}
+		return items
+	}
+	return value.Interface()
+}
+
+// serializeFields writes the JSON fields of a struct to object; depths keeps the depth
+// each name was written at, so fields of embedded structs never replace shallower ones
+func (s *ResponseSerializer) serializeFields(value reflect.Value, object map[string]interface{}, depths map[string]int, depth int) {
+	typ := value.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		fieldValue := value.Field(i)
+
+		if field.Anonymous && name == "" {
+			embedded := fieldValue
+			if embedded.Kind() == reflect.Pointer {
+				if embedded.IsNil() {
+					continue
+				}
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				s.serializeFields(embedded, object, depths, depth+1)
+				continue
+			}
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+		if strings.Contains(","+options+",", ",omitempty,") && emptyJSONValue(fieldValue) {
+			continue
+		}
+		if written, ok := depths[name]; ok && written <= depth {
+			continue
+		}
+		depths[name] = depth
+		object[name] = s.serialize(fieldValue)
+	}
+}
+
+// marshalsItself reports whether encoding/json writes typ with its own method
+func marshalsItself(typ reflect.Type) bool {
+	return typ.Implements(jsonMarshalerType) || typ.Implements(textMarshalerType)
+}
+
+// jsonMapKey writes a map key the way encoding/json does for string and integer keys
+func jsonMapKey(key reflect.Value) (string, bool) {
+	switch key.Kind() {
+	case reflect.String:
+		return key.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(key.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(key.Uint(), 10), true
+	}
+	return "", false
+}
+
+// emptyJSONValue reports whether omitempty drops value
+func emptyJSONValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.Interface, reflect.Pointer:
+		return value.IsZero()
+	}
+	return false
+}
+
+// TimeAsLayout writes times with layout, e.g. time.RFC3339 to drop fractional seconds
+func TimeAsLayout(layout string) MarshalHook {
+	return func(value interface{}) interface{} {
+		t, ok := value.(time.Time)
+		if !ok {
+			return value
+		}
+		return t.Format(layout)
+	}
+}
+
+// TimeAsRFC3339 writes times as RFC 3339 timestamps with second precision
+func TimeAsRFC3339() MarshalHook {
+	return TimeAsLayout(time.RFC3339)
+}
+
+// TimeAsUnix writes times as seconds since the Unix epoch, zero times as null
+func TimeAsUnix() MarshalHook {
+	return func(value interface{}) interface{} {
+		t, ok := value.(time.Time)
+		if !ok || t.IsZero() {
+			return nil
+		}
+		return t.Unix()
+	}
+}
+
+// TimeAsUnixMilli writes times as milliseconds since the Unix epoch, zero times as null
+func TimeAsUnixMilli() MarshalHook {
+	return func(value interface{}) interface{} {
+		t, ok := value.(time.Time)
+		if !ok || t.IsZero() {
+			return nil
+		}
+		return t.UnixMilli()
+	}
+}
+
+// DecimalAsString writes decimals as JSON strings, so clients parsing numbers as floats
+// keep every digit
+func DecimalAsString() MarshalHook {
+	return func(value interface{}) interface{} {
+		return decimalText(value)
+	}
+}
+
+// DecimalAsNumber writes decimals as JSON numbers with every digit of their text, e.g.
+// for decimal types whose MarshalJSON quotes them; values that are not numbers are
+// written as strings
+func DecimalAsNumber() MarshalHook {
+	return func(value interface{}) interface{} {
+		text := decimalText(value)
+		if _, err := strconv.ParseFloat(text, 64); err != nil {
+			return text
+		}
+		return json.Number(text)
+	}
+}
+
+// decimalText returns the text of a decimal value, from its String method for decimal
+// types
+func decimalText(value interface{}) string {
+	switch typed := value.(type) {
+	case string:
+		return typed
+	case json.Number:
+		return typed.String()
+	case float64:
+		return strconv.FormatFloat(typed, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(typed), 'f', -1, 32)
+	case fmt.Stringer:
+		return typed.String()
+	}
+	return fmt.Sprint(value)
+}
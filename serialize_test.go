@@ -0,0 +1,111 @@
+package pagination
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testDecimal stands in for decimal types quoting themselves in JSON
+type testDecimal struct {
+	text string
+}
+
+func (d testDecimal) String() string { return d.text }
+
+func (d testDecimal) MarshalJSON() ([]byte, error) { return json.Marshal(d.text) }
+
+type TestAudit struct {
+	CreatedAt time.Time  `json:"created_at"`
+	DeletedAt *time.Time `json:"deleted_at"`
+}
+
+type testInvoice struct {
+	TestAudit
+	ID       uint             `json:"id"`
+	Total    testDecimal      `json:"total"`
+	Discount *testDecimal     `json:"discount,omitempty"`
+	Note     string           `json:"note,omitempty"`
+	Secret   string           `json:"-"`
+	Lines    []testDecimal    `json:"lines"`
+	Raw      []byte           `json:"raw"`
+	Extra    map[string]int64 `json:"extra"`
+}
+
+func TestResponseSerializer_Apply(t *testing.T) {
+	created := time.Date(2024, 3, 9, 21, 30, 15, 500000000, time.UTC)
+	invoices := []testInvoice{{
+		TestAudit: TestAudit{CreatedAt: created},
+		ID:        7,
+		Total:     testDecimal{"1500000.50"},
+		Secret:    "hidden",
+		Lines:     []testDecimal{{"0.10"}, {"n/a"}},
+		Raw:       []byte("hi"),
+		Extra:     map[string]int64{"points": 3},
+	}}
+
+	serializer := NewResponseSerializer().
+		Time(TimeAsUnix()).
+		Hook(testDecimal{}, DecimalAsNumber())
+
+	encoded, err := json.Marshal(serializer.Apply(invoices))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[{
+		"created_at": 1710019815,
+		"deleted_at": null,
+		"id": 7,
+		"total": 1500000.50,
+		"lines": [0.10, "n/a"],
+		"raw": "aGk=",
+		"extra": {"points": 3}
+	}]`, string(encoded))
+
+	// Without hooks the data is left as it is
+	assert.Equal(t, invoices, NewResponseSerializer().Apply(invoices))
+}
+
+func TestResponseSerializer_Pointers(t *testing.T) {
+	deleted := time.Date(2024, 3, 9, 21, 30, 15, 500000000, time.UTC)
+	discount := testDecimal{"5"}
+	invoice := &testInvoice{
+		TestAudit: TestAudit{DeletedAt: &deleted},
+		Discount:  &discount,
+	}
+
+	serializer := NewResponseSerializer().
+		Time(TimeAsRFC3339()).
+		Hook(testDecimal{}, DecimalAsString())
+
+	object := serializer.Apply(invoice).(map[string]interface{})
+	assert.Equal(t, "2024-03-09T21:30:15Z", object["deleted_at"])
+	assert.Equal(t, "0001-01-01T00:00:00Z", object["created_at"])
+	assert.Equal(t, "5", object["discount"])
+	assert.Nil(t, object["lines"])
+}
+
+func TestTimeAsUnixMilli(t *testing.T) {
+	assert.Equal(t, int64(1710019815500), TimeAsUnixMilli()(time.Date(2024, 3, 9, 21, 30, 15, 500000000, time.UTC)))
+	assert.Nil(t, TimeAsUnixMilli()(time.Time{}))
+	assert.Equal(t, "09/03/2024", TimeAsLayout("02/01/2006")(time.Date(2024, 3, 9, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestSerializePaginatedResponse(t *testing.T) {
+	db := setupTestDB()
+
+	filter := &testUserFilter{}
+	request := newTestRequest("/users?per_page=1")
+	response := PaginatedAPIResponseRequest[TestUser](db, request, filter, "ok")
+	assert.Equal(t, http.StatusOK, response.Code)
+
+	response = SerializePaginatedResponse(response, NewResponseSerializer().Hook(0, func(value interface{}) interface{} {
+		return strconv.Itoa(value.(int))
+	}))
+	first := response.Data.([]interface{})[0].(map[string]interface{})
+	assert.Equal(t, "25", first["age"])
+	assert.Equal(t, "John Doe", first["name"])
+	assert.Equal(t, 1, response.Pagination.PerPage)
+}
@@ -0,0 +1,147 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultFlightTimeout bounds a shared query when SingleFlightTimeout is not set
+const defaultFlightTimeout = 30 * time.Second
+
+// flightGroup deduplicates concurrent calls sharing a key; callers arriving while a call
+// is in flight wait for it and receive its result
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+type flightCall struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// queryFlights is shared by every query using PaginatedQueryOptions.SingleFlight
+var queryFlights flightGroup
+
+// Do runs fn once for all concurrent callers of key and reports whether the result was shared
+// fn runs on its own goroutine, so every caller, the first one included, stops waiting
+// with the error of ctx once ctx ends while the call carries on for the others.
+func (g *flightGroup) Do(ctx context.Context, key string, fn func() (interface{}, error)) (interface{}, error, bool) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, shared := g.calls[key]
+	if !shared {
+		call = &flightCall{done: make(chan struct{})}
+		g.calls[key] = call
+		go func() {
+			defer func() {
+				g.mu.Lock()
+				delete(g.calls, key)
+				g.mu.Unlock()
+				close(call.done)
+			}()
+			call.value, call.err = fn()
+		}()
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err, shared
+	case <-ctx.Done():
+		return nil, ctx.Err(), shared
+	}
+}
+
+// flightScope keys shared queries to the connection pool of query and the SingleFlightKey
+// of options, so handles on different databases never share results; ok is false inside
+// transactions, whose uncommitted rows must not reach other callers
+func flightScope(query *gorm.DB, options *PaginatedQueryOptions) (string, bool) {
+	if _, ok := query.Statement.ConnPool.(gorm.TxCommitter); ok {
+		return "", false
+	}
+	return fmt.Sprintf("%p:%s:", query.Statement.ConnPool, options.SingleFlightKey), true
+}
+
+// runShared runs fn through queryFlights under key on a session detached from the
+// context of the caller starting it and bounded by SingleFlightTimeout, so one caller
+// going away does not cancel the query of the others
+func runShared(query *gorm.DB, key string, options *PaginatedQueryOptions, fn func(*gorm.DB) (interface{}, error)) (interface{}, error) {
+	ctx := query.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	value, err, _ := queryFlights.Do(ctx, key, func() (interface{}, error) {
+		timeout := options.SingleFlightTimeout
+		if timeout <= 0 {
+			timeout = defaultFlightTimeout
+		}
+		detached, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+		defer cancel()
+		return fn(sessionWithContext(query, detached))
+	})
+	return value, err
+}
+
+// sessionWithContext returns a session of query running on ctx. Sessions drop the build
+// clauses, e.g. of prepared page windows, so they are carried over.
+func sessionWithContext(query *gorm.DB, ctx context.Context) *gorm.DB {
+	buildClauses := query.Statement.BuildClauses
+	session := query.WithContext(ctx).Scopes()
+	session.Statement.BuildClauses = buildClauses
+	return session
+}
+
+// sharedCount runs count through queryFlights, keyed by the connection and the count SQL
+func sharedCount(query *gorm.DB, options *PaginatedQueryOptions, count func(*gorm.DB) (int64, error)) (int64, error) {
+	scope, ok := flightScope(query, options)
+	if !ok {
+		return count(query)
+	}
+
+	value, err := runShared(query, scope+"count:"+totalCacheKey(query), options, func(query *gorm.DB) (interface{}, error) {
+		return count(query)
+	})
+	if err != nil {
+		return 0, err
+	}
+	return value.(int64), nil
+}
+
+// findKey identifies a data query by its result type, SQL and arguments, leaving out
+// query comments. Sessions drop the build clauses, e.g. of prepared page windows, so
+// they are carried over to the rendered query.
+func findKey[T any](query *gorm.DB) string {
+	buildClauses := query.Statement.BuildClauses
+	return fmt.Sprintf("find:%T:", *new(T)) + query.ToSQL(func(tx *gorm.DB) *gorm.DB {
+		tx = tx.Set(sqlCommentSetting, "")
+		tx.Statement.BuildClauses = buildClauses
+		var result []T
+		return tx.Find(&result)
+	})
+}
+
+// sharedFind runs find through queryFlights, keyed by the connection, result type and SQL
+// Every caller receives its own slice; the elements themselves are shared.
+func sharedFind[T any](query *gorm.DB, options *PaginatedQueryOptions, find func(*gorm.DB) ([]T, error)) ([]T, error) {
+	scope, ok := flightScope(query, options)
+	if !ok {
+		return find(query)
+	}
+
+	value, err := runShared(query, scope+findKey[T](query), options, func(query *gorm.DB) (interface{}, error) {
+		return find(query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return append([]T(nil), value.([]T)...), nil
+}
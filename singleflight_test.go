@@ -0,0 +1,138 @@
+package pagination
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestFlightGroup_SharesConcurrentCalls(t *testing.T) {
+	var group flightGroup
+	var calls int32
+	release := make(chan struct{})
+
+	var wg sync.WaitGroup
+	results := make([]interface{}, 10)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _, _ = group.Do(context.Background(), "key", func() (interface{}, error) {
+				atomic.AddInt32(&calls, 1)
+				<-release
+				return 42, nil
+			})
+		}(i)
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	for _, result := range results {
+		assert.Equal(t, 42, result)
+	}
+
+	// Calls after completion run again
+	group.Do(context.Background(), "key", func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, nil
+	})
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestPaginatedQuery_SingleFlight(t *testing.T) {
+	db := setupTestDB()
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+
+	builder := NewSimpleQueryBuilder("test_users").WithDefaultSort("age asc")
+	options := PaginatedQueryOptions{Dialect: SQLite, SingleFlight: true}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			data, total, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil, options)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(5), total)
+			if assert.Len(t, data, 2) {
+				assert.Equal(t, 25, data[0].Age)
+				data[0].Age = 0 // callers own their slice
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestFlightGroup_CallerGivesUpAlone(t *testing.T) {
+	var group flightGroup
+	release := make(chan struct{})
+	fn := func() (interface{}, error) {
+		<-release
+		return 42, nil
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error, 1)
+	go func() {
+		_, err, _ := group.Do(ctx, "key", fn)
+		first <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	second := make(chan interface{}, 1)
+	go func() {
+		value, _, _ := group.Do(context.Background(), "key", fn)
+		second <- value
+	}()
+	time.Sleep(20 * time.Millisecond)
+
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled)
+
+	close(release)
+	assert.Equal(t, 42, <-second, "the call keeps running for the remaining callers")
+}
+
+func TestFlightScope(t *testing.T) {
+	first, second := setupTestDB(), setupTestDB()
+	options := &PaginatedQueryOptions{}
+
+	firstKey, ok := flightScope(first.Table("test_users"), options)
+	assert.True(t, ok)
+	secondKey, _ := flightScope(second.Table("test_users"), options)
+	assert.NotEqual(t, firstKey, secondKey, "different databases never share")
+
+	tenantKey, _ := flightScope(first.Table("test_users"), &PaginatedQueryOptions{SingleFlightKey: "tenant-a"})
+	assert.NotEqual(t, firstKey, tenantKey)
+
+	first.Transaction(func(tx *gorm.DB) error {
+		_, ok := flightScope(tx.Table("test_users"), options)
+		assert.False(t, ok, "transactions are never shared")
+		return nil
+	})
+}
+
+func TestPaginatedQuery_SingleFlightRetries(t *testing.T) {
+	db := setupTestDB()
+	attempts := failQueries(db, 2, errors.New("Error 1213: Deadlock found when trying to get lock"))
+
+	users, _, err := PaginatedQueryWithOptions[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 1, PerPage: 2}, nil, PaginatedQueryOptions{
+		Dialect:      SQLite,
+		SingleFlight: true,
+		CountMode:    CountSkip,
+		Retry:        &RetryPolicy{BaseDelay: time.Millisecond},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, 3, *attempts, "two failed data queries, then the shared one succeeds")
+}
@@ -0,0 +1,87 @@
+package pagination
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+)
+
+// ErrResponseTooLarge is returned when a page exceeds the size guard
+var ErrResponseTooLarge = errors.New("response exceeds the maximum allowed size")
+
+// SizeGuardOptions configures the maximum serialized size of a page
+// An oversized page is rejected rather than cut short: dropping its trailing rows would
+// skip them for good, since the next page starts after the full page.
+type SizeGuardOptions struct {
+	MaxBytes int
+	// SampleSize is the number of rows serialized to estimate the average row size;
+	// zero serializes every row, which is exact but slower
+	SampleSize int
+}
+
+// ApplySizeGuard estimates the serialized size of the page and rejects it with
+// ErrResponseTooLarge when it exceeds MaxBytes
+func ApplySizeGuard[T any](data []T, options SizeGuardOptions) error {
+	return checkResponseSize(reflect.ValueOf(data), options)
+}
+
+// GuardPaginatedResponse applies the size guard to a response built by the helpers
+// An oversized page turns into a 413 response asking for a smaller page.
+func GuardPaginatedResponse(response PaginatedResponse, options SizeGuardOptions) PaginatedResponse {
+	value := reflect.ValueOf(response.Data)
+	if value.Kind() != reflect.Slice {
+		return response
+	}
+
+	if err := checkResponseSize(value, options); err != nil {
+		var rejected PaginatedResponse
+		if errors.Is(err, ErrResponseTooLarge) {
+			rejected = NewPaginatedResponse(http.StatusRequestEntityTooLarge, err.Error()+", reduce per_page or includes", nil, PaginationResponse{})
+		} else {
+			rejected = NewPaginatedResponse(http.StatusInternalServerError, "Internal Server Error: "+err.Error(), nil, PaginationResponse{})
+		}
+		rejected.RequestID = response.RequestID
+		return rejected
+	}
+	return response
+}
+
+// checkResponseSize estimates whether the rows of the slice fit within MaxBytes
+func checkResponseSize(rows reflect.Value, options SizeGuardOptions) error {
+	if options.MaxBytes <= 0 || rows.Len() == 0 {
+		return nil
+	}
+
+	sampleSize := options.SampleSize
+	if sampleSize <= 0 || sampleSize > rows.Len() {
+		sampleSize = rows.Len()
+	}
+
+	// Stream through the sample, each row costs its encoding plus a separator
+	total := 2
+	for i := 0; i < sampleSize; i++ {
+		encoded, err := json.Marshal(rows.Index(i).Interface())
+		if err != nil {
+			return fmt.Errorf("failed to estimate response size: %w", err)
+		}
+		total += len(encoded) + 1
+		if total > options.MaxBytes {
+			return ErrResponseTooLarge
+		}
+	}
+
+	if sampleSize == rows.Len() {
+		return nil
+	}
+
+	average := (total - 2) / sampleSize
+	if average < 1 {
+		average = 1
+	}
+	if sampleSize+(options.MaxBytes-total)/average < rows.Len() {
+		return ErrResponseTooLarge
+	}
+	return nil
+}
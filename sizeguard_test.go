@@ -0,0 +1,34 @@
+package pagination
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplySizeGuard(t *testing.T) {
+	users := []TestUser{
+		{ID: 1, Name: "John Doe"},
+		{ID: 2, Name: "Jane Smith"},
+		{ID: 3, Name: "Bob Johnson"},
+	}
+
+	assert.NoError(t, ApplySizeGuard(users, SizeGuardOptions{MaxBytes: 10000}))
+	assert.ErrorIs(t, ApplySizeGuard(users, SizeGuardOptions{MaxBytes: 120}), ErrResponseTooLarge)
+	assert.ErrorIs(t, ApplySizeGuard(users, SizeGuardOptions{MaxBytes: 120, SampleSize: 1}), ErrResponseTooLarge)
+}
+
+func TestGuardPaginatedResponse(t *testing.T) {
+	users := []TestUser{{ID: 1, Name: "John Doe"}, {ID: 2, Name: "Jane Smith"}}
+	response := NewPaginatedResponse(200, "ok", users, PaginationResponse{Page: 1, PerPage: 2, Total: 2})
+
+	guarded := GuardPaginatedResponse(response, SizeGuardOptions{MaxBytes: 10000})
+	assert.Equal(t, 200, guarded.Code)
+	assert.Len(t, guarded.Data, 2)
+
+	rejected := GuardPaginatedResponse(response, SizeGuardOptions{MaxBytes: 70, SampleSize: 1})
+	assert.Equal(t, http.StatusRequestEntityTooLarge, rejected.Code)
+	assert.Contains(t, rejected.Message, "reduce per_page")
+	assert.Nil(t, rejected.Data)
+}
@@ -0,0 +1,144 @@
+package pagination
+
+import (
+	"strings"
+)
+
+// SortField is one field of a sort parameter with its direction
+type SortField struct {
+	Field string `json:"field"`
+	Order string `json:"order"`
+}
+
+// ParseSort splits a sort parameter such as "-created_at,name" into its fields in
+// order: a leading - sorts a field descending, a + ascending, and fields without a sign
+// use defaultOrder. Empty entries are skipped.
+func ParseSort(sort, defaultOrder string) []SortField {
+	if defaultOrder != "desc" {
+		defaultOrder = "asc"
+	}
+
+	var fields []SortField
+	for _, entry := range strings.Split(sort, ",") {
+		field, order := parseSortSign(entry)
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		if order == "" {
+			order = defaultOrder
+		}
+		fields = append(fields, SortField{Field: field, Order: order})
+	}
+	return fields
+}
+
+// SortFields returns the fields the request sorts by; fields of Sort without a sign
+// use Order
+func (p PaginationRequest) SortFields() []SortField {
+	return ParseSort(p.Sort, p.Order)
+}
+
+// isValidSort reports whether every field of a sort parameter is a column name
+func isValidSort(sort string) bool {
+	fields := ParseSort(sort, "")
+	for _, field := range fields {
+		if !isValidSortField(field.Field) {
+			return false
+		}
+	}
+	return len(fields) > 0
+}
+
+// sortFieldsClause renders fields as an ORDER BY clause
+func sortFieldsClause(fields []SortField) string {
+	parts := make([]string, len(fields))
+	for i, field := range fields {
+		parts[i] = field.Field + " " + field.Order
+	}
+	return strings.Join(parts, ", ")
+}
+
+// allowedSortFields keeps the fields present in allowed
+func allowedSortFields(fields []SortField, allowed []string) []SortField {
+	allowedSet := make(map[string]bool, len(allowed))
+	for _, field := range allowed {
+		allowedSet[field] = true
+	}
+
+	var kept []SortField
+	for _, field := range fields {
+		if allowedSet[field.Field] {
+			kept = append(kept, field)
+		}
+	}
+	return kept
+}
+
+// bindSort binds a sort parameter listing several fields, keeping the sign of each
+// valid field so the order parameter only applies to fields without one; invalid
+// fields are dropped with a warning. A direction after a field, as in sort=name,desc,
+// applies to that field.
+func bindSort(sort string, warn func(param, value string, applied interface{}, message string)) string {
+	var kept []string
+	for _, entry := range strings.Split(sort, ",") {
+		field, order := parseSortSign(entry)
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		if direction := strings.ToLower(field); (direction == "asc" || direction == "desc") && order == "" {
+			if len(kept) > 0 {
+				sign := "-"
+				if direction == "asc" {
+					sign = "+"
+				}
+				last, _ := parseSortSign(kept[len(kept)-1])
+				kept[len(kept)-1] = sign + last
+			}
+			continue
+		}
+		if !isValidSortField(field) {
+			warn("sort", field, "ignored", "sort fields must be column names")
+			continue
+		}
+		switch order {
+		case "desc":
+			field = "-" + field
+		case "asc":
+			field = "+" + field
+		}
+		kept = append(kept, field)
+	}
+	return strings.Join(kept, ",")
+}
+
+// restrictSort drops the sort fields missing from allowed, warning about each
+func restrictSort(pagination *PaginationRequest, allowed []string) []PaginationWarning {
+	if pagination.Sort == "" {
+		return nil
+	}
+
+	var warnings []PaginationWarning
+	entries := strings.Split(pagination.Sort, ",")
+	kept := entries[:0]
+	for _, entry := range entries {
+		field, _ := parseSortSign(entry)
+		if len(allowedSortFields([]SortField{{Field: field}}, allowed)) > 0 {
+			kept = append(kept, entry)
+			continue
+		}
+		warnings = append(warnings, PaginationWarning{
+			Param:   "sort",
+			Value:   field,
+			Applied: "ignored",
+			Message: "sort field must be one of " + strings.Join(allowed, ", "),
+		})
+	}
+	pagination.Sort = strings.Join(kept, ",")
+	if len(kept) == 1 {
+		// A single field keeps its direction in Order, as when bound alone
+		if field, signed := parseSortSign(pagination.Sort); signed != "" {
+			pagination.Sort, pagination.Order = field, signed
+		}
+	}
+	return warnings
+}
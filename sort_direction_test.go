@@ -0,0 +1,60 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type directedUserFilter struct {
+	testUserFilter
+}
+
+func (f *directedUserFilter) GetSortDirections() map[string]string {
+	return map[string]string{"created_at": "desc", "name": "asc"}
+}
+
+func TestBindPagination_SortSign(t *testing.T) {
+	pagination := bindTestPagination("/users?sort=-age")
+	assert.Equal(t, "age", pagination.Sort)
+	assert.Equal(t, "desc", pagination.Order)
+
+	pagination = bindTestPagination("/users?sort=%2Bage&order=desc")
+	assert.Equal(t, "age", pagination.Sort)
+	assert.Equal(t, "asc", pagination.Order, "the sign wins over order")
+
+	pagination = bindTestPagination("/users?sort=+age")
+	assert.Equal(t, "age", pagination.Sort, "an unescaped + arrives as a space")
+	assert.Equal(t, "asc", pagination.Order)
+}
+
+func TestBindFilter_SortDirections(t *testing.T) {
+	cases := map[string]string{
+		"/users?sort=created_at":            "desc",
+		"/users?sort=name":                  "asc",
+		"/users?sort=created_at&order=asc":  "asc",
+		"/users?sort=%2Bcreated_at":         "asc",
+		"/users?sort=age":                   "asc",
+		"/users?sort=-name":                 "desc",
+		"/users?sort=created_at&order=desc": "desc",
+	}
+	for target, order := range cases {
+		filter := &directedUserFilter{}
+		assert.NoError(t, BindFilterRequest(newTestRequest(target), filter))
+		assert.Equal(t, order, filter.GetPagination().Order, target)
+	}
+}
+
+func TestRegisterTable_SortDirections(t *testing.T) {
+	db := setupTestDB()
+	registerTestTable(t, TableConfig{SortDirections: map[string]string{"age": "desc"}})
+
+	users, meta, err := Paginate[TestUser](db, newTestRequest("/users?sort=age&per_page=2"), WithTable("test_users"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bob Johnson", "Charlie Wilson"}, userNames(users))
+	assert.Equal(t, int64(5), meta.Total)
+
+	users, _, err = Paginate[TestUser](db, newTestRequest("/users?sort=age&order=asc&per_page=2"), WithTable("test_users"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"John Doe", "Alice Brown"}, userNames(users))
+}
@@ -0,0 +1,49 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaginatedQuery_SortPreset(t *testing.T) {
+	db := setupTestDB()
+	statements := captureSQL(db)
+	builder := NewSimpleQueryBuilder("test_users").WithSortPreset("oldest", "age desc, name asc")
+
+	users, _, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2, Sort: "oldest", Order: "asc"}, nil, PaginatedQueryOptions{Dialect: SQLite})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bob Johnson", "Charlie Wilson"}, userNames(users))
+	assert.Contains(t, (*statements)[0], "ORDER BY age desc, name asc")
+
+	users, _, err = PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2, Sort: "age", Order: "asc"}, nil, PaginatedQueryOptions{Dialect: SQLite})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"John Doe", "Alice Brown"}, userNames(users), "columns still sort as requested")
+}
+
+func TestRegisterTable_SortPresets(t *testing.T) {
+	db := setupTestDB()
+	registerTestTable(t, TableConfig{SortPresets: map[string]string{"youngest": "age asc"}})
+
+	users, _, err := Paginate[TestUser](db, newTestRequest("/users?sort=youngest&per_page=2"), WithTable("test_users"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"John Doe", "Alice Brown"}, userNames(users))
+}
+
+func TestNeighbors_SortPreset(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users").WithSortPreset("oldest", "age desc")
+
+	filter := &presetFilter{SimpleQueryBuilder: builder, pagination: PaginationRequest{Sort: "oldest"}}
+	neighborhood, err := Neighbors[TestUser](db, filter, 5, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bob Johnson", neighborhood.Previous[0].Name)
+	assert.Equal(t, "Jane Smith", neighborhood.Next[0].Name)
+}
+
+type presetFilter struct {
+	*SimpleQueryBuilder
+	pagination PaginationRequest
+}
+
+func (f *presetFilter) GetPagination() PaginationRequest { return f.pagination }
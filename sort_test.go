@@ -0,0 +1,71 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sortableUserFilter struct {
+	testUserFilter
+}
+
+func (f *sortableUserFilter) GetSortableFields() []string { return []string{"age", "name"} }
+
+func TestParseSort(t *testing.T) {
+	assert.Equal(t, []SortField{{"created_at", "desc"}, {"name", "asc"}, {"id", "asc"}}, ParseSort("-created_at, name,,+id", "asc"))
+	assert.Equal(t, []SortField{{"name", "desc"}, {"id", "asc"}}, ParseSort("name,+id", "desc"))
+	assert.Nil(t, ParseSort("", "asc"))
+}
+
+func TestBindPagination_SortList(t *testing.T) {
+	pagination, warnings := BindPaginationRequest(newTestRequest("/users?sort=-age,name,bad*drop"))
+	assert.Equal(t, "-age,name", pagination.Sort)
+	assert.Equal(t, []SortField{{"age", "desc"}, {"name", "asc"}}, pagination.SortFields())
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, "bad*drop", warnings[0].Value)
+	}
+
+	pagination = bindTestPagination("/users?sort=-age,name&order=desc")
+	assert.Equal(t, []SortField{{"age", "desc"}, {"name", "desc"}}, pagination.SortFields(), "order applies to fields without a sign")
+
+	pagination = bindTestPagination("/users?sort=-age,bad*drop")
+	assert.Equal(t, "age", pagination.Sort)
+	assert.Equal(t, "desc", pagination.Order)
+}
+
+func TestBindFilter_SortListDirections(t *testing.T) {
+	filter := &directedUserFilter{}
+	assert.NoError(t, BindFilterRequest(newTestRequest("/users?sort=created_at,name"), filter))
+	assert.Equal(t, []SortField{{"created_at", "desc"}, {"name", "asc"}}, filter.GetPagination().SortFields())
+}
+
+func TestSortableFields(t *testing.T) {
+	db := setupTestDB()
+
+	filter := &sortableUserFilter{}
+	request := newTestRequest("/users?sort=-age,id&per_page=2")
+	users, _, err := PaginateRequest[TestUser](db, request, filter)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Bob Johnson", "Charlie Wilson"}, userNames(users))
+	assert.Equal(t, "age", filter.Pagination.Sort)
+	assert.Equal(t, "desc", filter.Pagination.Order)
+	if warnings := filter.GetPaginationWarnings(); assert.Len(t, warnings, 1) {
+		assert.Equal(t, "id", warnings[0].Value)
+		assert.Equal(t, "sort field must be one of age, name", warnings[0].Message)
+	}
+
+	// Builders drop undeclared fields even when the request was not bound by a filter
+	clause := sortClause(&sortableUserFilter{}, PaginationRequest{Sort: "id,-name", Order: "asc"})
+	assert.Equal(t, "name desc", clause)
+	assert.Equal(t, "id asc", sortClause(&sortableUserFilter{}, PaginationRequest{Sort: "id", Order: "desc"}))
+}
+
+func TestBindPagination_SortListDirectionEntries(t *testing.T) {
+	pagination := bindTestPagination("/users?sort=age,desc,name,asc&order=desc")
+	assert.Equal(t, []SortField{{"age", "desc"}, {"name", "asc"}}, pagination.SortFields())
+
+	pagination = bindTestPagination("/users?sort=name,desc")
+	assert.Equal(t, "name", pagination.Sort)
+	assert.Equal(t, "desc", pagination.Order)
+}
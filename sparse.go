@@ -0,0 +1,196 @@
+package pagination
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// SelectableFieldsProvider is implemented by filters declaring the fields clients may
+// select with ?fields=, so columns such as password hashes cannot be requested. Without
+// it any column of the model may be selected.
+type SelectableFieldsProvider interface {
+	GetSelectableFields() []string
+}
+
+// SelectFieldsProvider is implemented by builders loading only some columns of their
+// model; BaseFilter binds them from ?fields=
+type SelectFieldsProvider interface {
+	GetSelectFields() []string
+}
+
+// ParseFields reads the sparse fieldset of a resource, fields=id,name or in the JSON:API
+// style fields[provinces]=id,name where resource is "provinces"
+func ParseFields(query url.Values, resource string) []string {
+	values := query["fields"]
+	if resource != "" {
+		values = append(values[:len(values):len(values)], query["fields["+resource+"]"]...)
+	}
+	return splitMultiValue(values)
+}
+
+// restrictFields drops the fields not in allowed, matched case insensitively, with a
+// warning for each; nil allows every field
+func restrictFields(fields []string, allowed []string) ([]string, []PaginationWarning) {
+	if allowed == nil {
+		return fields, nil
+	}
+
+	var kept []string
+	var warnings []PaginationWarning
+	for _, field := range fields {
+		found := false
+		for _, name := range allowed {
+			if strings.EqualFold(field, name) {
+				found = true
+				break
+			}
+		}
+		if found {
+			kept = append(kept, field)
+			continue
+		}
+		warnings = append(warnings, PaginationWarning{
+			Param:   "fields",
+			Value:   field,
+			Applied: "ignored",
+			Message: "field must be one of " + strings.Join(allowed, ", "),
+		})
+	}
+	return kept, warnings
+}
+
+// GetSelectFields returns the fields selected with ?fields=, see SelectFieldsProvider
+func (f *BaseFilter) GetSelectFields() []string {
+	return f.selectFields
+}
+
+// bindSelectFields binds the sparse fieldset of resource, restricted to allowed
+func (f *BaseFilter) bindSelectFields(query url.Values, resource string, allowed []string) {
+	var warnings []PaginationWarning
+	f.selectFields, warnings = restrictFields(ParseFields(query, resource), allowed)
+	f.warnings = append(f.warnings, warnings...)
+}
+
+// selectColumns resolves the columns selected by a builder implementing
+// SelectFieldsProvider, keeping the primary key and the keys includes are attached by;
+// unknown fields are ignored and nil selects every column, as do result types GORM
+// cannot parse
+func selectColumns[T any](db *gorm.DB, builder interface{}, includes []string) []string {
+	provider, ok := builder.(SelectFieldsProvider)
+	if !ok || len(provider.GetSelectFields()) == 0 {
+		return nil
+	}
+
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil
+	}
+	current := stmt.Schema
+
+	var columns []string
+	seen := make(map[string]bool)
+	add := func(column string) {
+		if column != "" && !seen[column] {
+			seen[column] = true
+			columns = append(columns, column)
+		}
+	}
+
+	for _, field := range provider.GetSelectFields() {
+		if schemaField := current.LookUpField(field); schemaField != nil && schemaField.DBName != "" {
+			add(schemaField.DBName)
+		}
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	for _, field := range current.PrimaryFields {
+		add(field.DBName)
+	}
+	for _, include := range includes {
+		name, _, _ := strings.Cut(include, ".")
+		relationship, ok := current.Relationships.Relations[name]
+		if !ok {
+			continue
+		}
+		for _, reference := range relationship.References {
+			if reference.ForeignKey != nil && reference.ForeignKey.Schema == current {
+				add(reference.ForeignKey.DBName)
+			}
+			if reference.PrimaryKey != nil && reference.PrimaryKey.Schema == current {
+				add(reference.PrimaryKey.DBName)
+			}
+		}
+	}
+	return columns
+}
+
+// TrimFields returns a copy of data keeping only the listed top-level JSON keys of each
+// item, for responses that should not show the zero values of unselected columns.
+// Relations are kept only when listed too.
+func TrimFields(data interface{}, fields []string) (interface{}, error) {
+	if len(fields) == 0 || data == nil {
+		return data, nil
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to trim data: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(encoded))
+	decoder.UseNumber()
+	var generic interface{}
+	if err := decoder.Decode(&generic); err != nil {
+		return nil, fmt.Errorf("failed to trim data: %w", err)
+	}
+
+	keep := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		keep[strings.ToLower(field)] = true
+	}
+	trim := func(item interface{}) {
+		if object, ok := item.(map[string]interface{}); ok {
+			for key := range object {
+				if !keep[strings.ToLower(key)] {
+					delete(object, key)
+				}
+			}
+		}
+	}
+	if items, ok := generic.([]interface{}); ok {
+		for _, item := range items {
+			trim(item)
+		}
+	} else {
+		trim(generic)
+	}
+	return generic, nil
+}
+
+// TrimPaginatedResponse applies TrimFields to the data of a response
+func TrimPaginatedResponse(response PaginatedResponse, fields []string) PaginatedResponse {
+	trimmed, err := TrimFields(response.Data, fields)
+	if err != nil {
+		failed := NewPaginatedResponse(http.StatusInternalServerError, "Internal Server Error: "+err.Error(), nil, PaginationResponse{})
+		failed.RequestID = response.RequestID
+		return failed
+	}
+	response.Data = trimmed
+	return response
+}
+
+// qualifiedColumns qualifies columns with their table, so joins cannot make them ambiguous
+func qualifiedColumns(table string, columns []string) []string {
+	qualified := make([]string, len(columns))
+	for i, column := range columns {
+		qualified[i] = tableQualifier(table) + "." + column
+	}
+	return qualified
+}
@@ -0,0 +1,98 @@
+package pagination
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type selectUserFilter struct {
+	BaseFilter
+}
+
+func (f *selectUserFilter) GetTableName() string          { return "test_users" }
+func (f *selectUserFilter) GetDefaultSort() string        { return "id asc" }
+func (f *selectUserFilter) GetSearchFields() []string     { return []string{"name"} }
+func (f *selectUserFilter) GetSelectableFields() []string { return []string{"id", "name", "age"} }
+
+type selectMemberBuilder struct {
+	*SimpleQueryBuilder
+	fields []string
+}
+
+func (b selectMemberBuilder) GetSelectFields() []string {
+	return b.fields
+}
+
+func TestParseFields(t *testing.T) {
+	query, _ := url.ParseQuery("fields=id,%20name&fields[provinces]=code&fields[cities]=zip")
+	assert.Equal(t, []string{"id", "name", "code"}, ParseFields(query, "provinces"))
+	assert.Equal(t, []string{"id", "name"}, ParseFields(query, ""))
+}
+
+func TestPaginateRequest_SelectFields(t *testing.T) {
+	db := setupTestDB()
+	statements := captureSQL(db)
+
+	filter := &selectUserFilter{}
+	users, meta, err := PaginateRequest[TestUser](db, httptest.NewRequest("GET", "/users?fields=name,email&per_page=2", nil), filter)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"name"}, filter.GetSelectFields())
+	assert.Contains(t, (*statements)[0], "SELECT test_users.name,test_users.id FROM `test_users`")
+	if assert.Len(t, users, 2) {
+		assert.Equal(t, "John Doe", users[0].Name)
+		assert.NotZero(t, users[0].ID, "the primary key is always selected")
+		assert.Zero(t, users[0].Age)
+	}
+	if assert.Len(t, meta.Warnings, 1) {
+		assert.Equal(t, "fields", meta.Warnings[0].Param)
+		assert.Equal(t, "email", meta.Warnings[0].Value)
+	}
+
+	*statements = nil
+	_, _, err = PaginateRequest[TestUser](db, httptest.NewRequest("GET", "/users?fields[test_users]=age&per_page=2", nil), &selectUserFilter{})
+	assert.NoError(t, err)
+	assert.Contains(t, (*statements)[0], "SELECT test_users.age,test_users.id FROM `test_users`")
+}
+
+func TestSelectColumns_IncludeKeys(t *testing.T) {
+	db := setupTeamsDB()
+	statements := captureSQL(db)
+
+	builder := selectMemberBuilder{NewSimpleQueryBuilder("test_members"), []string{"name"}}
+	members, _, err := PaginatedQuery[TestMember](db, builder, PaginationRequest{Page: 1, PerPage: 10}, []string{"Team"})
+	assert.NoError(t, err)
+	if assert.Len(t, members, 2) && assert.NotNil(t, members[0].Team, "the foreign key of an include is selected") {
+		assert.Equal(t, "Red", members[0].Team.Name)
+		assert.Empty(t, members[0].Bio)
+	}
+	assert.Contains(t, strings.Join(*statements, "\n"), "SELECT test_members.name,test_members.id,test_members.team_id FROM")
+}
+
+func TestTrimPaginatedResponse(t *testing.T) {
+	response := NewPaginatedResponse(200, "ok", []TestUser{{ID: 1, Name: "John Doe"}}, PaginationResponse{})
+	trimmed := TrimPaginatedResponse(response, []string{"id", "Name"})
+	assert.Equal(t, []interface{}{map[string]interface{}{"id": "1", "name": "John Doe"}}, stringifyNumbers(trimmed.Data))
+
+	assert.Equal(t, response.Data, TrimPaginatedResponse(response, nil).Data)
+}
+
+// stringifyNumbers renders the json.Number values of generic JSON data as strings
+func stringifyNumbers(data interface{}) interface{} {
+	switch typed := data.(type) {
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = stringifyNumbers(item)
+		}
+	case map[string]interface{}:
+		for key, value := range typed {
+			typed[key] = stringifyNumbers(value)
+		}
+	case interface{ String() string }:
+		return typed.String()
+	}
+	return data
+}
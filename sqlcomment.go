@@ -0,0 +1,102 @@
+package pagination
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/callbacks"
+)
+
+const sqlCommentSetting = "pagination:sql_comment"
+
+// QueryCommenter is a GORM plugin appending sqlcommenter-style comments to paginated queries
+// Register it once with db.Use(pagination.QueryCommenter{}) and set PaginatedQueryOptions.QueryTags.
+type QueryCommenter struct{}
+
+// Name implements gorm.Plugin
+func (QueryCommenter) Name() string {
+	return "pagination:query_commenter"
+}
+
+// Initialize implements gorm.Plugin
+func (QueryCommenter) Initialize(db *gorm.DB) error {
+	if err := db.Callback().Query().Before("gorm:query").Register("pagination:sql_comment", appendSQLComment); err != nil {
+		return err
+	}
+	return db.Callback().Row().Before("gorm:row").Register("pagination:sql_comment", appendSQLComment)
+}
+
+// appendSQLComment builds the statement ahead of GORM and appends the comment;
+// GORM skips building when the SQL is already present
+func appendSQLComment(db *gorm.DB) {
+	comment, ok := db.Get(sqlCommentSetting)
+	if !ok || comment == "" || db.Error != nil || db.Statement.SQL.Len() > 0 {
+		return
+	}
+
+	callbacks.BuildQuerySQL(db)
+	if db.Statement.SQL.Len() > 0 {
+		db.Statement.SQL.WriteString(" " + comment.(string))
+	}
+}
+
+// annotateQuery attaches the comment produced by the tag callback to the query
+func annotateQuery(query *gorm.DB, tags func(ctx context.Context) map[string]string) *gorm.DB {
+	if tags == nil {
+		return query
+	}
+
+	ctx := query.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	comment := formatSQLComment(tags(ctx))
+	if comment == "" {
+		return query
+	}
+	return query.Set(sqlCommentSetting, comment)
+}
+
+// formatSQLComment renders tags following the sqlcommenter specification:
+// sorted keys, URL-encoded keys and values, values in single quotes
+func formatSQLComment(tags map[string]string) string {
+	keys := make([]string, 0, len(tags))
+	for key, value := range tags {
+		if key != "" && value != "" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, len(keys))
+	for i, key := range keys {
+		pairs[i] = sqlCommentEscape(key) + "='" + sqlCommentEscape(tags[key]) + "'"
+	}
+	return "/*" + strings.Join(pairs, ",") + "*/"
+}
+
+func sqlCommentEscape(value string) string {
+	return strings.ReplaceAll(url.QueryEscape(value), "+", "%20")
+}
+
+// RequestQueryTags returns the route, method and request ID of a request as query tags;
+// route is the pattern the request matched, r.Pattern when empty
+func RequestQueryTags(r *http.Request, route string) map[string]string {
+	tags := map[string]string{"route": route}
+	if r != nil {
+		if route == "" {
+			tags["route"] = r.Pattern
+		}
+		tags["method"] = r.Method
+		tags["request_id"] = RequestID(r)
+	}
+	return tags
+}
@@ -0,0 +1,41 @@
+package pagination
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatSQLComment(t *testing.T) {
+	comment := formatSQLComment(map[string]string{
+		"route":      "/athletes/:id",
+		"controller": "main.listAthletes",
+		"request_id": "it's 1",
+		"empty":      "",
+	})
+
+	assert.Equal(t, "/*controller='main.listAthletes',request_id='it%27s%201',route='%2Fathletes%2F%3Aid'*/", comment)
+	assert.Equal(t, "", formatSQLComment(nil))
+}
+
+func TestPaginatedQuery_QueryTags(t *testing.T) {
+	db := setupTestDB()
+	assert.NoError(t, db.Use(QueryCommenter{}))
+	statements := captureSQL(db)
+
+	builder := NewSimpleQueryBuilder("test_users")
+	_, total, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil, PaginatedQueryOptions{
+		Dialect: SQLite,
+		QueryTags: func(ctx context.Context) map[string]string {
+			return map[string]string{"route": "/users"}
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.Len(t, *statements, 2)
+	for _, statement := range *statements {
+		assert.Contains(t, statement, " /*route='%2Fusers'*/")
+	}
+}
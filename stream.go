@@ -0,0 +1,87 @@
+package pagination
+
+import (
+	"fmt"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+// StreamOptions configures StreamPaginatedResponse
+type StreamOptions struct {
+	// Message is the message of the envelope
+	Message string
+	// MinPageSize is the page size from which pages are streamed; smaller pages are
+	// rendered in one piece, which compresses better (default 500)
+	MinPageSize int
+	// FlushEvery flushes the response after this many rows (default 100)
+	FlushEvery int
+}
+
+func (o *StreamOptions) validate() {
+	if o.MinPageSize <= 0 {
+		o.MinPageSize = 500
+	}
+	if o.FlushEvery <= 0 {
+		o.FlushEvery = 100
+	}
+}
+
+// StreamPaginatedResponse writes the standard envelope of a paginated query to w.
+// Large pages are encoded row by row as they are scanned and flushed as chunks, so the
+// response starts flowing before the last row is read; the pagination metadata is written
+// first. Once streaming started errors can no longer change the status, the response is
+// then cut short and the error returned for logging. Includes are not loaded.
+func StreamPaginatedResponse[T any](
+	w http.ResponseWriter,
+	r *http.Request,
+	db *gorm.DB,
+	builder QueryBuilder,
+	pagination PaginationRequest,
+	options PaginatedQueryOptions,
+	stream StreamOptions,
+) error {
+	stream.validate()
+	db = withRequestContext(db, r)
+
+	if !pagination.IsDisabled && pagination.GetLimit() < stream.MinPageSize {
+		data, total, err := PaginatedQueryWithOptions[T](db, builder, pagination, nil, options)
+		if err != nil {
+			return err
+		}
+		writeJSON(w, http.StatusOK, NewPaginatedResponse(http.StatusOK, stream.Message, data, CalculatePagination(pagination, total)))
+		return nil
+	}
+
+	options.stream = func(query *gorm.DB, total int64) error {
+		rows, err := query.Rows()
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		scanned := func(yield func(T, error) bool) {
+			for rows.Next() {
+				var item T
+				if err := query.ScanRows(rows, &item); err != nil {
+					yield(item, err)
+					return
+				}
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if err := rows.Err(); err != nil {
+				yield(*new(T), err)
+			}
+		}
+		meta := func() PaginationResponse { return CalculatePagination(pagination, total) }
+		return WritePaginatedRows(w, scanned, meta, WriteOptions{Message: stream.Message, FlushEvery: stream.FlushEvery})
+	}
+
+	_, _, err := PaginatedQueryWithOptions[T](db, builder, pagination, nil, options)
+	if err != nil {
+		return fmt.Errorf("failed to stream page: %w", err)
+	}
+	return nil
+}
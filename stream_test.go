@@ -0,0 +1,56 @@
+package pagination
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func streamRequest(t *testing.T, pagination PaginationRequest, stream StreamOptions) (*httptest.ResponseRecorder, error) {
+	db := setupTestDB()
+	recorder := httptest.NewRecorder()
+
+	err := StreamPaginatedResponse[TestUser](recorder, httptest.NewRequest("GET", "/users", nil), db, NewSimpleQueryBuilder("test_users"), pagination, PaginatedQueryOptions{Dialect: SQLite}, stream)
+	return recorder, err
+}
+
+func TestStreamPaginatedResponse(t *testing.T) {
+	recorder, err := streamRequest(t, PaginationRequest{Page: 1, PerPage: 4}, StreamOptions{Message: "Users", MinPageSize: 1, FlushEvery: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, "no", recorder.Header().Get("X-Accel-Buffering"))
+	assert.True(t, recorder.Flushed)
+
+	var response struct {
+		Code       int                `json:"code"`
+		Message    string             `json:"message"`
+		Data       []TestUser         `json:"data"`
+		Pagination PaginationResponse `json:"pagination"`
+	}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, 200, response.Code)
+	assert.Equal(t, "Users", response.Message)
+	assert.Equal(t, []string{"John Doe", "Jane Smith", "Bob Johnson", "Alice Brown"}, userNames(response.Data))
+	assert.Equal(t, int64(5), response.Pagination.Total)
+	assert.Equal(t, int64(2), response.Pagination.MaxPage)
+}
+
+func TestStreamPaginatedResponse_SmallPagesAreBuffered(t *testing.T) {
+	recorder, err := streamRequest(t, PaginationRequest{Page: 2, PerPage: 2}, StreamOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, recorder.Header().Get("X-Accel-Buffering"))
+
+	var response PaginatedResponse
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Len(t, response.Data, 2)
+}
+
+func TestStreamPaginatedResponse_EmptyPage(t *testing.T) {
+	recorder, err := streamRequest(t, PaginationRequest{Page: 9, PerPage: 2}, StreamOptions{MinPageSize: 1})
+	assert.NoError(t, err)
+
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, []interface{}{}, response["data"])
+}
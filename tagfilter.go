@@ -0,0 +1,99 @@
+package pagination
+
+import (
+	"fmt"
+	"reflect"
+
+	"gorm.io/gorm"
+)
+
+// tagFilterConditions are the conditions of the operators of filter tags
+var tagFilterConditions = map[string]string{
+	"eq":     "= ?",
+	"ne":     "<> ?",
+	"gt":     "> ?",
+	"gte":    ">= ?",
+	"lt":     "< ?",
+	"lte":    "<= ?",
+	"in":     "IN ?",
+	"not_in": "NOT IN ?",
+	"like":   "LIKE ?",
+	"prefix": "LIKE ?",
+}
+
+// ApplyTagFilters adds the WHERE clauses declared by the tags of filter, a pointer to a
+// struct, for every field holding a value. Fields are read like Capabilities describes
+// them: `form:"min_age" filter:"column=age,op=gte"` compares the age column with the
+// min_age parameter, and fields without a filter tag compare their own column with "eq",
+// or "in" for slices. Operators are eq, ne, gt, gte, lt, lte, in, not_in, like, prefix
+// and null, which selects NULL columns for true and others for false. String fields
+// compared with in or not_in hold comma-separated values. Zero values are
+// skipped unless the field is a pointer, so `*bool` fields can filter on false.
+// Unknown operators fail the query.
+func ApplyTagFilters(query *gorm.DB, filter interface{}) *gorm.DB {
+	for _, param := range filterParams(reflect.ValueOf(filter), nil) {
+		value, ok := tagFilterValue(param.value)
+		if !ok {
+			continue
+		}
+
+		switch param.operator {
+		case "null":
+			if null, _ := value.(bool); null {
+				query = query.Where(param.field + " IS NULL")
+			} else {
+				query = query.Where(param.field + " IS NOT NULL")
+			}
+			continue
+		case "in", "not_in":
+			if text, ok := value.(string); ok {
+				value = splitMultiValue([]string{text})
+			}
+		case "like":
+			value = "%" + fmt.Sprint(value) + "%"
+		case "prefix":
+			value = fmt.Sprint(value) + "%"
+		}
+
+		condition, ok := tagFilterConditions[param.operator]
+		if !ok {
+			query.AddError(fmt.Errorf("unsupported filter operator %q on %s", param.operator, param.name))
+			continue
+		}
+		query = query.Where(param.field+" "+condition, value)
+	}
+	return query
+}
+
+// tagFilterValue returns the value of a filter field, false when it holds none
+func tagFilterValue(field reflect.Value) (interface{}, bool) {
+	if !field.IsValid() {
+		return nil, false
+	}
+	if field.Kind() == reflect.Ptr {
+		if field.IsNil() {
+			return nil, false
+		}
+		return field.Elem().Interface(), true
+	}
+	if field.IsZero() || (field.Kind() == reflect.Slice && field.Len() == 0) {
+		return nil, false
+	}
+	return field.Interface(), true
+}
+
+// ApplyFilters applies the filter tags of the filter embedding BaseFilter and its
+// operator filters, so filters bound with BindFilterRequest need no
+// ApplyFilters of their own, see ApplyTagFilters and GetFilterSet. Filters defining
+// ApplyFilters replace it.
+func (f *BaseFilter) ApplyFilters(query *gorm.DB) *gorm.DB {
+	if f.self != nil {
+		query = ApplyTagFilters(query, f.self)
+	}
+	return f.filterSet.Apply(query)
+}
+
+// useTagFilters records the filter embedding BaseFilter, whose tags ApplyFilters reads
+func (f *BaseFilter) useTagFilters(filter interface{}) {
+	f.self = filter
+}
@@ -0,0 +1,90 @@
+package pagination
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// taggedUserFilter has no ApplyFilters of its own
+type taggedUserFilter struct {
+	BaseFilter
+	MinAge   int      `form:"min_age" filter:"column=age,op=gte"`
+	MaxAge   *int     `form:"max_age" filter:"column=age,op=lt"`
+	Domain   string   `form:"domain" filter:"column=email,op=like"`
+	Initial  string   `form:"initial" filter:"column=name,op=prefix"`
+	Names    []string `form:"name"`
+	Internal string   `form:"internal" filter:"-"`
+}
+
+func (f *taggedUserFilter) GetTableName() string      { return "test_users" }
+func (f *taggedUserFilter) GetDefaultSort() string    { return "id asc" }
+func (f *taggedUserFilter) GetSearchFields() []string { return []string{"name"} }
+
+func TestPaginateRequest_TagFilters(t *testing.T) {
+	db := setupTestDB()
+
+	tests := []struct {
+		query string
+		names []string
+	}{
+		{"min_age=30", []string{"Jane Smith", "Bob Johnson", "Charlie Wilson"}},
+		{"min_age=28&max_age=32", []string{"Jane Smith", "Alice Brown"}},
+		{"domain=example&initial=J", []string{"John Doe", "Jane Smith"}},
+		{"name=Bob+Johnson&name=Alice+Brown", []string{"Bob Johnson", "Alice Brown"}},
+		{"internal=nobody", []string{"John Doe", "Jane Smith", "Bob Johnson", "Alice Brown", "Charlie Wilson"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			filter := &taggedUserFilter{}
+			users, _, err := PaginateRequest[TestUser](db, httptest.NewRequest("GET", "/users?"+tt.query, nil), filter)
+			assert.NoError(t, err)
+
+			var names []string
+			for _, user := range users {
+				names = append(names, user.Name)
+			}
+			assert.Equal(t, tt.names, names)
+		})
+	}
+}
+
+func TestApplyTagFilters(t *testing.T) {
+	db := setupTestDB()
+	statements := captureSQL(db)
+
+	zero := 0
+	var users []TestUser
+	err := ApplyTagFilters(db.Model(&TestUser{}), &taggedUserFilter{MaxAge: &zero}).Find(&users).Error
+	assert.NoError(t, err)
+	assert.Empty(t, users, "pointers filter on zero values")
+	assert.Contains(t, (*statements)[0], "WHERE age < ?")
+
+	type nullFilter struct {
+		Unnamed *bool `form:"unnamed" filter:"column=name,op=null"`
+		Odd     int   `form:"odd" filter:"op=mod"`
+	}
+	unnamed := false
+	err = ApplyTagFilters(db.Model(&TestUser{}), &nullFilter{Unnamed: &unnamed}).Find(&users).Error
+	assert.NoError(t, err)
+	assert.Len(t, users, 5)
+
+	err = ApplyTagFilters(db.Model(&TestUser{}), &nullFilter{Odd: 1}).Find(&users).Error
+	assert.ErrorContains(t, err, `unsupported filter operator "mod" on odd`)
+}
+
+func TestBaseFilter_ApplyFiltersUnbound(t *testing.T) {
+	db := setupTestDB()
+	filter := &taggedUserFilter{MinAge: 30}
+
+	query := filter.ApplyFilters(db.Table("test_users"))
+	var count int64
+	assert.NoError(t, query.Count(&count).Error)
+	assert.Equal(t, int64(5), count, "filters built by hand call ApplyTagFilters themselves")
+
+	assert.NoError(t, ApplyTagFilters(db.Table("test_users"), filter).Count(&count).Error)
+	assert.Equal(t, int64(3), count)
+
+	var _ Filterable = filter
+}
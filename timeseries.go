@@ -0,0 +1,209 @@
+package pagination
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	// maxTimeSeriesBuckets bounds the buckets a time range may span
+	maxTimeSeriesBuckets = 100000
+	// timeSeriesChunk is the number of buckets counted per query
+	timeSeriesChunk = 200
+)
+
+// TimeBucket is the width of the buckets of a time series
+type TimeBucket string
+
+const (
+	BucketHour  TimeBucket = "hour"
+	BucketDay   TimeBucket = "day"
+	BucketWeek  TimeBucket = "week"
+	BucketMonth TimeBucket = "month"
+)
+
+// TimeSeriesOptions configures PaginateTimeSeries
+type TimeSeriesOptions struct {
+	// Column is the timestamp column the rows are bucketed by
+	Column string
+	Bucket TimeBucket
+	// From and To delimit the series, From inclusive and To exclusive
+	From time.Time
+	To   time.Time
+	// Location sets where buckets start, UTC by default; weeks start on Monday
+	Location *time.Location
+}
+
+// TimeSeriesPoint holds the number of rows in one bucket
+type TimeSeriesPoint struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	Count int64     `json:"count"`
+}
+
+func (o *TimeSeriesOptions) validate() error {
+	if o.Bucket == "" {
+		o.Bucket = BucketDay
+	}
+	if o.Location == nil {
+		o.Location = time.UTC
+	}
+	if !isValidSortField(o.Column) {
+		return fmt.Errorf("invalid time series column %q", o.Column)
+	}
+	switch o.Bucket {
+	case BucketHour, BucketDay, BucketWeek, BucketMonth:
+	default:
+		return fmt.Errorf("unsupported time bucket %q", o.Bucket)
+	}
+	if o.From.IsZero() || o.To.IsZero() || !o.From.Before(o.To) {
+		return fmt.Errorf("time series needs a range with From before To")
+	}
+	return nil
+}
+
+// truncate returns the start of the bucket containing t
+func (o TimeSeriesOptions) truncate(t time.Time) time.Time {
+	t = t.In(o.Location)
+	year, month, day := t.Date()
+	switch o.Bucket {
+	case BucketHour:
+		return time.Date(year, month, day, t.Hour(), 0, 0, 0, o.Location)
+	case BucketWeek:
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(year, month, day-offset, 0, 0, 0, 0, o.Location)
+	case BucketMonth:
+		return time.Date(year, month, 1, 0, 0, 0, 0, o.Location)
+	default:
+		return time.Date(year, month, day, 0, 0, 0, 0, o.Location)
+	}
+}
+
+// next returns the start of the bucket following start
+func (o TimeSeriesOptions) next(start time.Time) time.Time {
+	switch o.Bucket {
+	case BucketHour:
+		return start.Add(time.Hour)
+	case BucketWeek:
+		return start.AddDate(0, 0, 7)
+	case BucketMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+// buckets lists the bucket boundaries of the range; bucket i spans boundaries[i] to boundaries[i+1]
+func (o TimeSeriesOptions) buckets() ([]time.Time, error) {
+	boundaries := []time.Time{o.truncate(o.From)}
+	for boundaries[len(boundaries)-1].Before(o.To) {
+		if len(boundaries) > maxTimeSeriesBuckets {
+			return nil, fmt.Errorf("time series spans more than %d buckets", maxTimeSeriesBuckets)
+		}
+		boundaries = append(boundaries, o.next(boundaries[len(boundaries)-1]))
+	}
+	return boundaries, nil
+}
+
+// PaginateTimeSeries counts the filtered set of builder per time bucket and paginates the
+// buckets; empty buckets are returned with a zero count. Order "desc" lists the newest bucket first.
+// The first and last buckets are clipped to From and To.
+func PaginateTimeSeries(db *gorm.DB, builder QueryBuilder, pagination PaginationRequest, options TimeSeriesOptions) ([]TimeSeriesPoint, PaginationResponse, error) {
+	if err := options.validate(); err != nil {
+		return nil, PaginationResponse{}, err
+	}
+	pagination.Validate()
+
+	boundaries, err := options.buckets()
+	if err != nil {
+		return nil, PaginationResponse{}, err
+	}
+
+	points := make([]TimeSeriesPoint, len(boundaries)-1)
+	for i := range points {
+		start, end := boundaries[i], boundaries[i+1]
+		if start.Before(options.From) {
+			start = options.From
+		}
+		if end.After(options.To) {
+			end = options.To
+		}
+		points[i] = TimeSeriesPoint{Start: start, End: end}
+	}
+	if pagination.Order == "desc" {
+		for i, j := 0, len(points)-1; i < j; i, j = i+1, j-1 {
+			points[i], points[j] = points[j], points[i]
+		}
+	}
+
+	total := int64(len(points))
+	if !pagination.IsDisabled {
+		offset := pagination.GetOffset()
+		if offset > len(points) {
+			offset = len(points)
+		}
+		end := offset + pagination.GetLimit()
+		if end > len(points) {
+			end = len(points)
+		}
+		points = points[offset:end]
+	}
+
+	for start := 0; start < len(points); start += timeSeriesChunk {
+		end := start + timeSeriesChunk
+		if end > len(points) {
+			end = len(points)
+		}
+		if err := countTimeSeries(db, builder, pagination, options.Column, points[start:end]); err != nil {
+			return nil, PaginationResponse{}, err
+		}
+	}
+
+	return points, CalculatePagination(pagination, total), nil
+}
+
+// countTimeSeries fills the counts of the points with one conditional aggregate per bucket,
+// which needs no date functions and keeps bucket boundaries exact in any location
+func countTimeSeries(db *gorm.DB, builder QueryBuilder, pagination PaginationRequest, column string, points []TimeSeriesPoint) error {
+	if len(points) == 0 {
+		return nil
+	}
+
+	first, last := points[0].Start, points[0].End
+	sums := make([]string, len(points))
+	args := make([]interface{}, 0, len(points)*2)
+	for i, point := range points {
+		sums[i] = "SUM(CASE WHEN " + column + " >= ? AND " + column + " < ? THEN 1 ELSE 0 END) AS b" + strconv.Itoa(i)
+		args = append(args, point.Start.UTC(), point.End.UTC())
+		if point.Start.Before(first) {
+			first = point.Start
+		}
+		if point.End.After(last) {
+			last = point.End
+		}
+	}
+
+	counts := make([]*int64, len(points))
+	targets := make([]interface{}, len(points))
+	for i := range counts {
+		targets[i] = &counts[i]
+	}
+
+	query := filteredSet(db, builder, pagination).
+		Select(strings.Join(sums, ", "), args...).
+		Where(column+" >= ? AND "+column+" < ?", first.UTC(), last.UTC())
+	if err := query.Row().Scan(targets...); err != nil {
+		return fmt.Errorf("failed to count time series: %w", err)
+	}
+
+	for i, count := range counts {
+		if count != nil {
+			points[i].Count = *count
+		}
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type TestSignup struct {
+	ID        uint
+	Plan      string
+	CreatedAt time.Time
+}
+
+func setupSignupsDB() *gorm.DB {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	db.AutoMigrate(&TestSignup{})
+
+	day := func(d, hour int) time.Time { return time.Date(2024, 1, d, hour, 0, 0, 0, time.UTC) }
+	db.Create(&[]TestSignup{
+		{Plan: "free", CreatedAt: day(1, 8)},
+		{Plan: "pro", CreatedAt: day(1, 20)},
+		{Plan: "free", CreatedAt: day(3, 0)},
+		{Plan: "free", CreatedAt: day(4, 1)},
+		{Plan: "pro", CreatedAt: day(4, 12)},
+		{Plan: "free", CreatedAt: day(4, 23)},
+		{Plan: "free", CreatedAt: day(6, 9)},
+	})
+	return db
+}
+
+func seriesCounts(points []TimeSeriesPoint) []int64 {
+	counts := make([]int64, len(points))
+	for i, point := range points {
+		counts[i] = point.Count
+	}
+	return counts
+}
+
+func TestPaginateTimeSeries_GapFilling(t *testing.T) {
+	db := setupSignupsDB()
+	builder := NewSimpleQueryBuilder("test_signups")
+	options := TimeSeriesOptions{
+		Column: "created_at",
+		From:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+	}
+
+	points, meta, err := PaginateTimeSeries(db, builder, PaginationRequest{Page: 1, PerPage: 10}, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{2, 0, 1, 3}, seriesCounts(points))
+	assert.Equal(t, int64(4), meta.Total)
+	assert.Equal(t, time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), points[1].Start)
+	assert.Equal(t, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), points[1].End)
+
+	points, meta, err = PaginateTimeSeries(db, builder, PaginationRequest{Page: 2, PerPage: 2}, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 3}, seriesCounts(points))
+	assert.Equal(t, int64(2), meta.MaxPage)
+
+	points, _, err = PaginateTimeSeries(db, builder, PaginationRequest{Page: 1, PerPage: 2, Order: "desc"}, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{3, 1}, seriesCounts(points))
+	assert.Equal(t, 4, points[0].Start.Day())
+
+	filtered := NewSimpleQueryBuilder("test_signups").WithFilters(func(query *gorm.DB) *gorm.DB { return query.Where("plan = ?", "pro") })
+	points, _, err = PaginateTimeSeries(db, filtered, PaginationRequest{Page: 1, PerPage: 10}, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{1, 0, 0, 1}, seriesCounts(points))
+
+	points, _, err = PaginateTimeSeries(db, builder, PaginationRequest{Page: 9, PerPage: 10}, options)
+	assert.NoError(t, err)
+	assert.Empty(t, points)
+}
+
+func TestPaginateTimeSeries_BucketsAndLocation(t *testing.T) {
+	db := setupSignupsDB()
+	builder := NewSimpleQueryBuilder("test_signups")
+
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	points, _, err := PaginateTimeSeries(db, builder, PaginationRequest{Page: 1, PerPage: 10}, TimeSeriesOptions{
+		Column:   "created_at",
+		From:     time.Date(2024, 1, 1, 0, 0, 0, 0, jakarta),
+		To:       time.Date(2024, 1, 3, 0, 0, 0, 0, jakarta),
+		Location: jakarta,
+	})
+	assert.NoError(t, err)
+	// 20:00 UTC on Jan 1 is already Jan 2 in Jakarta
+	assert.Equal(t, []int64{1, 1}, seriesCounts(points))
+
+	points, _, err = PaginateTimeSeries(db, builder, PaginationRequest{Page: 1, PerPage: 10}, TimeSeriesOptions{
+		Column: "created_at",
+		Bucket: BucketWeek,
+		From:   time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		To:     time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC),
+	})
+	assert.NoError(t, err)
+	// Weeks start on Monday Jan 1 and Jan 8; the first bucket is clipped to From
+	assert.Equal(t, []int64{5, 0}, seriesCounts(points))
+	assert.Equal(t, time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC), points[0].Start)
+	assert.Equal(t, time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC), points[1].Start)
+}
+
+func TestTimeSeriesOptions_Validate(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	invalid := []TimeSeriesOptions{
+		{Column: "created_at; --", From: from, To: from.Add(time.Hour)},
+		{Column: "created_at", Bucket: "decade", From: from, To: from.Add(time.Hour)},
+		{Column: "created_at", From: from, To: from},
+	}
+	for _, options := range invalid {
+		assert.Error(t, options.validate())
+	}
+
+	options := TimeSeriesOptions{Column: "created_at", Bucket: BucketHour, From: from, To: from.AddDate(20, 0, 0)}
+	assert.NoError(t, options.validate())
+	_, err := options.buckets()
+	assert.Error(t, err)
+}
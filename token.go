@@ -0,0 +1,160 @@
+package pagination
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrInvalidToken is returned when an opaque token cannot be decoded
+var ErrInvalidToken = errors.New("invalid pagination token")
+
+var (
+	// ErrTokenExpired is returned for tokens used after their expiry
+	ErrTokenExpired = fmt.Errorf("%w: token expired", ErrInvalidToken)
+	// ErrTokenVersion is returned for tokens issued in an unsupported format
+	ErrTokenVersion = fmt.Errorf("%w: unsupported token version", ErrInvalidToken)
+	// ErrTokenScope is returned when a cursor is replayed with different filter or sort parameters
+	ErrTokenScope = fmt.Errorf("%w: cursor was issued for a different query, restart without a cursor", ErrInvalidToken)
+)
+
+// tokenVersion is the first byte of every token payload and must be bumped whenever
+// the envelope layout changes
+const tokenVersion byte = 1
+
+// TokenError describes why a token was rejected
+type TokenError struct {
+	Version   byte
+	ExpiredAt time.Time
+	err       error
+}
+
+func (e *TokenError) Error() string {
+	switch {
+	case !e.ExpiredAt.IsZero():
+		return fmt.Sprintf("%v at %s", e.err, e.ExpiredAt.Format(time.RFC3339))
+	case errors.Is(e.err, ErrTokenVersion):
+		return fmt.Sprintf("%v %d", e.err, e.Version)
+	default:
+		return e.err.Error()
+	}
+}
+
+func (e *TokenError) Unwrap() error {
+	return e.err
+}
+
+var (
+	tokenTTLMu sync.RWMutex
+	tokenTTL   time.Duration
+)
+
+// SetCursorTTL sets how long newly issued cursor tokens stay valid; zero disables expiry
+func SetCursorTTL(ttl time.Duration) {
+	tokenTTLMu.Lock()
+	defer tokenTTLMu.Unlock()
+	tokenTTL = ttl
+}
+
+func cursorTTL() time.Duration {
+	tokenTTLMu.RLock()
+	defer tokenTTLMu.RUnlock()
+	return tokenTTL
+}
+
+// CursorScope fingerprints the filter and sort parameters a cursor is issued for
+// Pass the same values when issuing and when accepting a cursor; values are hashed
+// through their JSON representation.
+func CursorScope(values ...interface{}) string {
+	encoded, err := json.Marshal(values)
+	if err != nil {
+		encoded = []byte(fmt.Sprint(values...))
+	}
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:8])
+}
+
+// encodeToken serializes a value into an opaque URL-safe token
+func encodeToken(value interface{}) (string, error) {
+	return encodeScopedToken(value, "")
+}
+
+// decodeToken deserializes an opaque token produced by encodeToken
+func decodeToken(token string, value interface{}) error {
+	return decodeScopedToken(token, "", value)
+}
+
+// encodeScopedToken serializes a value into a token bound to scope
+func encodeScopedToken(value interface{}, scope string) (string, error) {
+	return encodeExpiringToken(value, scope, cursorTTL())
+}
+
+// encodeExpiringToken serializes a value into a token bound to scope valid for ttl;
+// zero disables expiry
+func encodeExpiringToken(value interface{}, scope string, ttl time.Duration) (string, error) {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode token: %w", err)
+	}
+
+	token := CursorToken{Scope: scope, Payload: payload}
+	if ttl > 0 {
+		token.ExpiresAt = time.Now().Add(ttl)
+	}
+	return activeCursorCodec().Encode(token)
+}
+
+// decodeScopedToken deserializes a token, rejecting it when it was bound to another scope
+// or, when scope is set, to none; calls without a scope skip the check.
+func decodeScopedToken(token string, scope string, value interface{}) error {
+	decoded, err := activeCursorCodec().Decode(token)
+	if err != nil {
+		return err
+	}
+
+	// Expiry has second precision, a token stays valid through its last second
+	if !decoded.ExpiresAt.IsZero() && time.Now().Unix() > decoded.ExpiresAt.Unix() {
+		return &TokenError{Version: tokenVersion, ExpiredAt: decoded.ExpiresAt, err: ErrTokenExpired}
+	}
+
+	// A scoped endpoint only accepts tokens issued for the same scope
+	if scope != "" && scope != decoded.Scope {
+		return &TokenError{Version: tokenVersion, err: ErrTokenScope}
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(decoded.Payload))
+	decoder.UseNumber()
+	if err := decoder.Decode(value); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return nil
+}
+
+// normalizeTokenValue converts decoded JSON values into types suitable for query arguments
+func normalizeTokenValue(value interface{}) interface{} {
+	number, ok := value.(json.Number)
+	if !ok {
+		return value
+	}
+	if i, err := number.Int64(); err == nil {
+		return i
+	}
+	if f, err := number.Float64(); err == nil {
+		return f
+	}
+	return number.String()
+}
+
+// normalizeTokenValues applies normalizeTokenValue to every element
+func normalizeTokenValues(values []interface{}) []interface{} {
+	normalized := make([]interface{}, len(values))
+	for i, value := range values {
+		normalized[i] = normalizeTokenValue(value)
+	}
+	return normalized
+}
@@ -0,0 +1,64 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToken_RoundTrip(t *testing.T) {
+	token, err := encodeToken(map[string]interface{}{"id": 42, "name": "x"})
+	assert.NoError(t, err)
+
+	var decoded map[string]interface{}
+	assert.NoError(t, decodeToken(token, &decoded))
+	assert.Equal(t, int64(42), normalizeTokenValue(decoded["id"]))
+	assert.Equal(t, "x", decoded["name"])
+}
+
+func TestToken_Expiry(t *testing.T) {
+	SetCursorTTL(time.Minute)
+	defer SetCursorTTL(0)
+
+	token, err := encodeToken(map[string]int{"id": 1})
+	assert.NoError(t, err)
+	var decoded map[string]interface{}
+	assert.NoError(t, decodeToken(token, &decoded))
+
+	expired := base64.RawURLEncoding.EncodeToString(append([]byte{tokenVersion}, []byte(`{"x":1,"p":{"id":1}}`)...))
+	err = decodeToken(expired, &decoded)
+	assert.ErrorIs(t, err, ErrTokenExpired)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	var tokenErr *TokenError
+	assert.True(t, errors.As(err, &tokenErr))
+	assert.Equal(t, int64(1), tokenErr.ExpiredAt.Unix())
+}
+
+func TestToken_OldFormatRejected(t *testing.T) {
+	legacy := base64.RawURLEncoding.EncodeToString([]byte(`{"id":1}`))
+
+	var decoded map[string]interface{}
+	err := decodeToken(legacy, &decoded)
+	assert.ErrorIs(t, err, ErrTokenVersion)
+
+	var tokenErr *TokenError
+	assert.True(t, errors.As(err, &tokenErr))
+	assert.Equal(t, byte('{'), tokenErr.Version)
+}
+
+func TestCursorScope(t *testing.T) {
+	assert.Equal(t, CursorScope("name", 1), CursorScope("name", 1))
+	assert.NotEqual(t, CursorScope("name", 1), CursorScope("name", 2))
+
+	token, err := encodeScopedToken(map[string]int{"id": 1}, CursorScope("a"))
+	assert.NoError(t, err)
+
+	var decoded map[string]interface{}
+	assert.NoError(t, decodeScopedToken(token, CursorScope("a"), &decoded))
+	assert.NoError(t, decodeToken(token, &decoded))
+	assert.ErrorIs(t, decodeScopedToken(token, CursorScope("b"), &decoded), ErrTokenScope)
+}
@@ -0,0 +1,93 @@
+package pagination
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultTotalTokenTTL bounds how stale a reused total can get
+const defaultTotalTokenTTL = time.Minute
+
+// TotalReuse lets sequential page requests with unchanged filters reuse the total of an
+// earlier page: the client echoes the token of the previous response and the COUNT is skipped.
+// Tokens are bound to the count query, so changed filters count again; invalid or expired
+// tokens are ignored.
+type TotalReuse struct {
+	// Token is the total_token echoed by the client, empty on the first request
+	Token string
+	// TTL is the lifetime of issued tokens, one minute by default; reusing a token does not extend it
+	TTL time.Duration
+
+	issued string
+}
+
+type totalToken struct {
+	Total int64 `json:"t"`
+}
+
+// IssuedToken returns the token to send back to the client after the query ran
+func (r *TotalReuse) IssuedToken() string {
+	if r == nil {
+		return ""
+	}
+	return r.issued
+}
+
+// reuse returns the total carried by the token when it was issued for the same count query
+func (r *TotalReuse) reuse(scope string) (int64, bool) {
+	if r == nil || r.Token == "" {
+		return 0, false
+	}
+
+	var decoded totalToken
+	if err := decodeScopedToken(r.Token, scope, &decoded); err != nil || decoded.Total < 0 {
+		return 0, false
+	}
+	r.issued = r.Token
+	return decoded.Total, true
+}
+
+// issue creates the token for a freshly counted total
+func (r *TotalReuse) issue(scope string, total int64) {
+	if r == nil || total == TotalPending {
+		return
+	}
+
+	ttl := r.TTL
+	if ttl <= 0 {
+		ttl = defaultTotalTokenTTL
+	}
+	token, err := encodeExpiringToken(totalToken{Total: total}, scope, ttl)
+	if err == nil {
+		r.issued = token
+	}
+}
+
+// reusableTotal resolves the total through the TotalReuse of options before counting
+func reusableTotal(countQuery *gorm.DB, options PaginatedQueryOptions, count func() (int64, error)) (int64, error) {
+	if options.TotalReuse == nil {
+		return count()
+	}
+
+	scope := CursorScope(totalCacheKey(countQuery))
+	if total, ok := options.TotalReuse.reuse(scope); ok {
+		return total, nil
+	}
+
+	total, err := count()
+	if err != nil {
+		return 0, err
+	}
+	options.TotalReuse.issue(scope, total)
+	return total, nil
+}
+
+// bindTotalReuse enables total reuse for helper requests when Config.TotalTokenTTL is set
+func bindTotalReuse(token string) *TotalReuse {
+	ttl := CurrentConfig().TotalTokenTTL
+	if ttl <= 0 {
+		return nil
+	}
+	return &TotalReuse{Token: token, TTL: ttl}
+}
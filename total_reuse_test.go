@@ -0,0 +1,94 @@
+package pagination
+
+import (
+	"encoding/json"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+// captureCounts counts the COUNT queries actually sent to the database
+func captureCounts(db *gorm.DB) *int {
+	counted := new(int)
+	db.Callback().Query().After("gorm:query").Register("test:capture_counts", func(tx *gorm.DB) {
+		if !tx.DryRun && strings.Contains(tx.Statement.SQL.String(), "count(*)") {
+			*counted++
+		}
+	})
+	return counted
+}
+
+func TestTotalReuse_SkipsCount(t *testing.T) {
+	db := setupTestDB()
+	counted := captureCounts(db)
+	builder := NewSimpleQueryBuilder("test_users")
+
+	first := &TotalReuse{}
+	_, total, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil, PaginatedQueryOptions{Dialect: SQLite, TotalReuse: first})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.NotEmpty(t, first.IssuedToken())
+	assert.Equal(t, 1, *counted)
+
+	db.Create(&TestUser{Name: "Dave", Email: "dave@example.com", Age: 40})
+
+	second := &TotalReuse{Token: first.IssuedToken()}
+	_, total, err = PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 2, PerPage: 2}, nil, PaginatedQueryOptions{Dialect: SQLite, TotalReuse: second})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total, "total should come from the token")
+	assert.Equal(t, first.IssuedToken(), second.IssuedToken(), "reuse must not extend the token")
+	assert.Equal(t, 1, *counted)
+
+	// Other filters count again
+	filtered := NewSimpleQueryBuilder("test_users").WithFilters(func(query *gorm.DB) *gorm.DB { return query.Where("age > ?", 30) })
+	third := &TotalReuse{Token: first.IssuedToken()}
+	_, total, err = PaginatedQueryWithOptions[TestUser](db, filtered, PaginationRequest{Page: 1, PerPage: 2}, nil, PaginatedQueryOptions{Dialect: SQLite, TotalReuse: third})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	assert.NotEqual(t, first.IssuedToken(), third.IssuedToken())
+}
+
+func TestTotalReuse_InvalidTokenCounts(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users")
+
+	reuse := &TotalReuse{Token: "garbage"}
+	_, total, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil, PaginatedQueryOptions{Dialect: SQLite, TotalReuse: reuse})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.NotEqual(t, "garbage", reuse.IssuedToken())
+
+	expired, err := encodeExpiringToken(totalToken{Total: 99}, "", time.Nanosecond)
+	assert.NoError(t, err)
+	time.Sleep(1100 * time.Millisecond)
+	_, ok := (&TotalReuse{Token: expired}).reuse("")
+	assert.False(t, ok)
+}
+
+func TestQuickPaginate_TotalToken(t *testing.T) {
+	db := setupTestDB()
+
+	_, meta, err := Paginate[TestUser](db, newTestRequest("/users?per_page=2"), WithTable("test_users"))
+	assert.NoError(t, err)
+	assert.Empty(t, meta.TotalToken, "total reuse is disabled by default")
+
+	useTestConfig(t, map[string]string{EnvTotalTokenTTL: "30s"})
+
+	_, meta, err = Paginate[TestUser](db, newTestRequest("/users?per_page=2"), WithTable("test_users"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, meta.TotalToken)
+
+	encoded, _ := json.Marshal(meta)
+	assert.Contains(t, string(encoded), `"total_token"`)
+
+	db.Create(&TestUser{Name: "Dave", Email: "dave@example.com", Age: 40})
+
+	_, next, err := Paginate[TestUser](db, newTestRequest("/users?per_page=2&page=2&total_token="+url.QueryEscape(meta.TotalToken)), WithTable("test_users"))
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), next.Total)
+	assert.Equal(t, meta.TotalToken, next.TotalToken)
+}
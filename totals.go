@@ -0,0 +1,235 @@
+package pagination
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TotalPending is reported as the total while an asynchronous count is still running
+const TotalPending int64 = -1
+
+// TotalStatusPending marks a response whose total is being computed in the background
+const TotalStatusPending = "pending"
+
+// TotalStatusUnknown marks a response from a source that cannot count, e.g. DynamoDB
+const TotalStatusUnknown = "unknown"
+
+// Total relations of counts stopped at a limit, as reported by Elasticsearch
+const (
+	TotalRelationEQ  = "eq"
+	TotalRelationGTE = "gte"
+)
+
+// cappedCountQuery counts at most limit rows of query by counting a limited subquery
+func cappedCountQuery(query *gorm.DB, distinctKey string, limit int64) *gorm.DB {
+	rows := query.Session(&gorm.Session{}).Select("1")
+	if distinctKey != "" {
+		rows = query.Session(&gorm.Session{}).Distinct(distinctKey)
+	}
+	return query.Session(&gorm.Session{NewDB: true}).Table("(?) capped", rows.Limit(int(limit)))
+}
+
+// CalculateCappedPagination is CalculatePagination for totals counted with
+// PaginatedQueryOptions.CountLimit: a total that reached countLimit is reported with
+// total_relation "gte", any other with "eq". Zero countLimit reports no relation.
+func CalculateCappedPagination(pagination PaginationRequest, totalCount, countLimit int64) PaginationResponse {
+	response := CalculatePagination(pagination, totalCount)
+	if countLimit <= 0 || totalCount == TotalPending {
+		return response
+	}
+	response.TotalRelation = TotalRelationEQ
+	if totalCount >= countLimit {
+		response.TotalRelation = TotalRelationGTE
+	}
+	return response
+}
+
+// TotalCache caches COUNT results per query so expensive totals are computed once
+type TotalCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]totalEntry
+	pending map[string]bool
+	hits    int64
+	misses  int64
+	// generation changes on every invalidation so counts started before it are not stored
+	generation uint64
+}
+
+// TotalCacheStats reports the usage of a TotalCache
+type TotalCacheStats struct {
+	Entries int   `json:"entries"`
+	Pending int   `json:"pending"`
+	Hits    int64 `json:"hits"`
+	Misses  int64 `json:"misses"`
+}
+
+type totalEntry struct {
+	total     int64
+	table     string
+	expiresAt time.Time
+}
+
+// NewTotalCache creates a TotalCache; a zero TTL keeps totals until they are invalidated
+func NewTotalCache(ttl time.Duration) *TotalCache {
+	return &TotalCache{
+		ttl:     ttl,
+		entries: make(map[string]totalEntry),
+		pending: make(map[string]bool),
+	}
+}
+
+// Get returns the cached total for key
+func (c *TotalCache) Get(key string) (int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if ok && !entry.expiresAt.IsZero() && time.Now().After(entry.expiresAt) {
+		delete(c.entries, key)
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		return 0, false
+	}
+	c.hits++
+	return entry.total, true
+}
+
+// Stats returns the current usage counters
+func (c *TotalCache) Stats() TotalCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return TotalCacheStats{
+		Entries: len(c.entries),
+		Pending: len(c.pending),
+		Hits:    c.hits,
+		Misses:  c.misses,
+	}
+}
+
+// Set stores the total for key; totals stored without a table are dropped by any InvalidateTable
+func (c *TotalCache) Set(key string, total int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.store(key, "", total)
+}
+
+// store records a total; the caller holds mu
+func (c *TotalCache) store(key, table string, total int64) {
+	entry := totalEntry{total: total, table: table}
+	if c.ttl > 0 {
+		entry.expiresAt = time.Now().Add(c.ttl)
+	}
+	c.entries[key] = entry
+}
+
+// currentGeneration returns the invalidation generation, taken before counting
+func (c *TotalCache) currentGeneration() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.generation
+}
+
+// storeCounted stores a total counted since generation unless the cache was invalidated meanwhile
+func (c *TotalCache) storeCounted(key, table string, total int64, generation uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.generation == generation {
+		c.store(key, table, total)
+	}
+}
+
+// Invalidate drops every cached total
+func (c *TotalCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]totalEntry)
+	c.generation++
+}
+
+// InvalidateTable drops the cached totals of table and those stored without a table
+func (c *TotalCache) InvalidateTable(table string) {
+	table = baseTableName(table)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if entry.table == "" || entry.table == table {
+			delete(c.entries, key)
+		}
+	}
+	c.generation++
+}
+
+// countInBackground runs count once per key and caches its result; failures are not cached
+func (c *TotalCache) countInBackground(key, table string, count func() (int64, error)) {
+	c.mu.Lock()
+	if c.pending[key] {
+		c.mu.Unlock()
+		return
+	}
+	c.pending[key] = true
+	generation := c.generation
+	c.mu.Unlock()
+
+	go func() {
+		total, err := count()
+		if err == nil {
+			c.storeCounted(key, table, total, generation)
+		}
+
+		c.mu.Lock()
+		delete(c.pending, key)
+		c.mu.Unlock()
+	}()
+}
+
+// totalCacheKey identifies a count query by its SQL and arguments, leaving out query comments
+func totalCacheKey(countQuery *gorm.DB) string {
+	return countQuery.Session(&gorm.Session{}).Set(sqlCommentSetting, "").ToSQL(func(tx *gorm.DB) *gorm.DB {
+		var total int64
+		return tx.Count(&total)
+	})
+}
+
+// cachedTotal resolves the total through the cache of options, counting in the background
+// when async is set and the total is not known yet
+func cachedTotal(countQuery *gorm.DB, table string, options PaginatedQueryOptions, async bool, count func(*gorm.DB) (int64, error)) (int64, error) {
+	cache := options.TotalCache
+	if cache == nil {
+		return count(countQuery)
+	}
+
+	table = baseTableName(table)
+	key := totalCacheKey(countQuery)
+	if total, ok := cache.Get(key); ok {
+		return total, nil
+	}
+
+	if !async {
+		generation := cache.currentGeneration()
+		total, err := count(countQuery)
+		if err != nil {
+			return 0, err
+		}
+		cache.storeCounted(key, table, total, generation)
+		return total, nil
+	}
+
+	// The request context ends with the response, the background count must outlive it
+	ctx := countQuery.Statement.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	background := countQuery.WithContext(context.WithoutCancel(ctx))
+	cache.countInBackground(key, table, func() (int64, error) {
+		return count(background)
+	})
+	return TotalPending, nil
+}
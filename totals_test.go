@@ -0,0 +1,126 @@
+package pagination
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTotalCache_ReusesCount(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users")
+	options := PaginatedQueryOptions{Dialect: SQLite, TotalCache: NewTotalCache(time.Minute)}
+	request := PaginationRequest{Page: 1, PerPage: 2}
+
+	_, total, err := PaginatedQueryWithOptions[TestUser](db, builder, request, nil, options)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+
+	db.Create(&TestUser{Name: "Dave", Email: "dave@example.com", Age: 40})
+
+	_, total, err = PaginatedQueryWithOptions[TestUser](db, builder, request, nil, options)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total, "total should come from the cache")
+
+	options.TotalCache.Invalidate()
+	_, total, err = PaginatedQueryWithOptions[TestUser](db, builder, request, nil, options)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), total)
+}
+
+func TestTotalCache_Async(t *testing.T) {
+	db := setupTestDB()
+	sqlDB, _ := db.DB()
+	sqlDB.SetMaxOpenConns(1)
+
+	builder := NewSimpleQueryBuilder("test_users")
+	options := PaginatedQueryOptions{Dialect: SQLite, TotalCache: NewTotalCache(0), AsyncTotal: true}
+	request := PaginationRequest{Page: 1, PerPage: 2}
+
+	data, total, err := PaginatedQueryWithOptions[TestUser](db, builder, request, nil, options)
+	assert.NoError(t, err)
+	assert.Len(t, data, 2)
+	assert.Equal(t, TotalPending, total)
+
+	meta := CalculatePagination(request, total)
+	assert.Equal(t, TotalStatusPending, meta.TotalStatus)
+	encoded, err := json.Marshal(meta)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"page":1,"per_page":2,"max_page":null,"total":null,"last_page":null,"total_status":"pending"}`, string(encoded))
+
+	assert.Eventually(t, func() bool {
+		_, total, err = PaginatedQueryWithOptions[TestUser](db, builder, request, nil, options)
+		return err == nil && total == 5
+	}, time.Second, 10*time.Millisecond)
+
+	encoded, err = json.Marshal(CalculatePagination(request, total))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"page":1,"per_page":2,"max_page":3,"total":5,"last_page":3}`, string(encoded))
+}
+
+func countStatements(statements []string) int {
+	counts := 0
+	for _, statement := range statements {
+		if strings.Contains(statement, "count(*)") {
+			counts++
+		}
+	}
+	return counts
+}
+
+func TestPaginatedQuery_ShortPageSkipsCount(t *testing.T) {
+	db := setupTestDB()
+	statements := captureSQL(db)
+	builder := NewSimpleQueryBuilder("test_users")
+
+	users, total, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 10}, nil, PaginatedQueryOptions{Dialect: SQLite})
+	assert.NoError(t, err)
+	assert.Len(t, users, 5)
+	assert.Equal(t, int64(5), total)
+	assert.Equal(t, 0, countStatements(*statements), "a short first page is the whole listing")
+
+	users, total, err = PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 2, PerPage: 3}, nil, PaginatedQueryOptions{Dialect: SQLite})
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, int64(5), total)
+	assert.Equal(t, 1, countStatements(*statements), "later pages depend on the rows before them")
+
+	_, total, err = PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 4, PerPage: 3}, nil, PaginatedQueryOptions{Dialect: SQLite})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total, "an empty page past the end still counts")
+	assert.Equal(t, 2, countStatements(*statements))
+
+	_, total, err = PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 5}, nil, PaginatedQueryOptions{Dialect: SQLite})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.Equal(t, 3, countStatements(*statements), "a full page may have more rows after it")
+}
+
+func TestPaginatedQuery_SearchTotal(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users").WithSearchFields("name")
+
+	// "o" matches John Doe, Bob Johnson, Alice Brown and Charlie Wilson
+	var totals []int64
+	for _, perPage := range []int{10, 4, 2} {
+		users, total, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: perPage, Search: "o"}, nil, PaginatedQueryOptions{Dialect: SQLite})
+		assert.NoError(t, err)
+		assert.Len(t, users, min(perPage, 4))
+		totals = append(totals, total)
+	}
+	assert.Equal(t, []int64{4, 4, 4}, totals, "short and full pages count the same rows")
+}
+
+func TestPaginatedQuery_AlwaysCount(t *testing.T) {
+	db := setupTestDB()
+	statements := captureSQL(db)
+
+	_, total, err := PaginatedQueryWithOptions[TestUser](db, NewSimpleQueryBuilder("test_users"), PaginationRequest{Page: 1, PerPage: 10}, nil, PaginatedQueryOptions{Dialect: SQLite, AlwaysCount: true})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), total)
+	assert.Equal(t, 1, countStatements(*statements))
+	assert.Contains(t, (*statements)[0], "count(*)", "the count runs first")
+}
@@ -0,0 +1,251 @@
+package pagination
+
+import (
+	"fmt"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// treePathWidth is the zero-padded width of each id in a tree path
+const treePathWidth = 10
+
+// TreeOptions configures pagination over a parent_id hierarchy
+// Ids must be non-negative integers; siblings are ordered by id.
+type TreeOptions struct {
+	TableName    string
+	IDColumn     string
+	ParentColumn string
+	// RootID starts the tree below one node; nil starts at the top-level nodes
+	RootID interface{}
+	// MaxDepth limits the depth below the roots; zero means unlimited
+	MaxDepth int
+	Limit    int
+}
+
+// TreeNode is one node of a flattened tree
+type TreeNode[T any] struct {
+	Node  T   `json:"node"`
+	Depth int `json:"depth"`
+}
+
+// TreePage holds one page of a flattened tree in depth-first order
+type TreePage[T any] struct {
+	Data       []TreeNode[T] `json:"data"`
+	NextCursor string        `json:"next_cursor,omitempty"`
+	HasMore    bool          `json:"has_more"`
+}
+
+// treeRow is the scan target of the recursive query
+type treeRow[T any] struct {
+	Node      T `gorm:"embedded"`
+	TreeDepth int
+	TreePath  string
+}
+
+type treeCursor struct {
+	Path string `json:"p"`
+}
+
+func (o *TreeOptions) validate() error {
+	if o.IDColumn == "" {
+		o.IDColumn = "id"
+	}
+	if o.ParentColumn == "" {
+		o.ParentColumn = "parent_id"
+	}
+	if o.Limit <= 0 {
+		o.Limit = 10
+	}
+	for _, identifier := range []string{o.TableName, o.IDColumn, o.ParentColumn} {
+		if !isValidSortField(identifier) {
+			return fmt.Errorf("invalid tree identifier %q", identifier)
+		}
+	}
+	return nil
+}
+
+// Children paginates the direct children of parentID; a nil parentID lists the top-level nodes
+func Children[T any](db *gorm.DB, parentID interface{}, pagination PaginationRequest, options TreeOptions) ([]T, PaginationResponse, error) {
+	if err := options.validate(); err != nil {
+		return nil, PaginationResponse{}, err
+	}
+
+	builder := NewSimpleQueryBuilder(options.TableName).
+		WithDefaultSort(options.IDColumn + " asc").
+		WithFilters(func(query *gorm.DB) *gorm.DB {
+			if parentID == nil {
+				return query.Where(options.ParentColumn + " IS NULL")
+			}
+			return query.Where(options.ParentColumn+" = ?", parentID)
+		})
+
+	data, total, err := PaginatedQueryWithOptions[T](db, builder, pagination, nil, PaginatedQueryOptions{Dialect: DetectDialect(db)})
+	if err != nil {
+		return nil, PaginationResponse{}, err
+	}
+	return data, CalculatePagination(pagination, total), nil
+}
+
+// Tree returns the hierarchy flattened in depth-first order with the depth of every node,
+// using a recursive CTE; the cursor continues after the last node of the previous page
+func Tree[T any](db *gorm.DB, cursor string, options TreeOptions) (TreePage[T], error) {
+	if err := options.validate(); err != nil {
+		return TreePage[T]{}, err
+	}
+
+	var after string
+	if cursor != "" {
+		var decoded treeCursor
+		if err := decodeToken(cursor, &decoded); err != nil {
+			return TreePage[T]{}, err
+		}
+		after = decoded.Path
+	}
+
+	query, args := buildTreeQuery(DetectDialect(db), options, after)
+
+	var rows []treeRow[T]
+	if err := db.Raw(query, args...).Scan(&rows).Error; err != nil {
+		return TreePage[T]{}, fmt.Errorf("failed to fetch tree: %w", err)
+	}
+
+	page := TreePage[T]{HasMore: len(rows) > options.Limit}
+	if page.HasMore {
+		rows = rows[:options.Limit]
+	}
+
+	page.Data = make([]TreeNode[T], len(rows))
+	for i, row := range rows {
+		page.Data[i] = TreeNode[T]{Node: row.Node, Depth: row.TreeDepth}
+	}
+
+	if page.HasMore {
+		token, err := encodeToken(treeCursor{Path: rows[len(rows)-1].TreePath})
+		if err != nil {
+			return TreePage[T]{}, err
+		}
+		page.NextCursor = token
+	}
+
+	return page, nil
+}
+
+// buildTreeQuery renders the recursive CTE; each node carries the path of zero-padded
+// ancestor ids so that ordering by path yields depth-first order
+func buildTreeQuery(dialect DatabaseDialect, options TreeOptions, after string) (string, []interface{}) {
+	if dialect == Oracle {
+		return buildConnectByQuery(options, after)
+	}
+
+	var args []interface{}
+
+	anchor := "t." + options.ParentColumn + " IS NULL"
+	if options.RootID != nil {
+		anchor = "t." + options.ParentColumn + " = ?"
+		args = append(args, options.RootID)
+	}
+
+	recursion := ""
+	if options.MaxDepth > 0 {
+		recursion = " WHERE tree.tree_depth < " + strconv.Itoa(options.MaxDepth)
+	}
+
+	with := "WITH RECURSIVE"
+	if dialect == SQLServer {
+		with = "WITH"
+	}
+
+	query := with + " tree AS (" +
+		"SELECT t.*, 0 AS tree_depth, " + treePathCast(dialect, treePad(dialect, "t."+options.IDColumn)) + " AS tree_path" +
+		" FROM " + options.TableName + " t WHERE " + anchor +
+		" UNION ALL " +
+		"SELECT c.*, tree.tree_depth + 1, " + treePathCast(dialect, treeConcat(dialect, "tree.tree_path", treePad(dialect, "c."+options.IDColumn))) +
+		" FROM " + options.TableName + " c JOIN tree ON c." + options.ParentColumn + " = tree." + options.IDColumn + recursion +
+		") SELECT * FROM tree"
+
+	if after != "" {
+		query += " WHERE tree_path > ?"
+		args = append(args, after)
+	}
+
+	// One extra row tells whether another page follows
+	limit := strconv.Itoa(options.Limit + 1)
+	if dialect == SQLServer {
+		query += " ORDER BY tree_path OFFSET 0 ROWS FETCH NEXT " + limit + " ROWS ONLY"
+	} else {
+		query += " ORDER BY tree_path LIMIT " + limit
+	}
+
+	return query, args
+}
+
+func treePad(dialect DatabaseDialect, column string) string {
+	width := strconv.Itoa(treePathWidth)
+	switch dialect {
+	case PostgreSQL:
+		return "LPAD(CAST(" + column + " AS TEXT), " + width + ", '0')"
+	case SQLServer:
+		return "RIGHT(REPLICATE('0', " + width + ") + CAST(" + column + " AS VARCHAR(" + width + ")), " + width + ")"
+	case SQLite:
+		return "printf('%0" + width + "d', " + column + ")"
+	default:
+		return "LPAD(" + column + ", " + width + ", '0')"
+	}
+}
+
+func treeConcat(dialect DatabaseDialect, parent, child string) string {
+	switch dialect {
+	case MySQL:
+		return "CONCAT(" + parent + ", '/', " + child + ")"
+	case SQLServer:
+		return parent + " + '/' + " + child
+	default:
+		return parent + " || '/' || " + child
+	}
+}
+
+// treePathCast gives both halves of the CTE the same string type, wide enough for deep paths
+func treePathCast(dialect DatabaseDialect, expression string) string {
+	switch dialect {
+	case PostgreSQL:
+		return "CAST(" + expression + " AS TEXT)"
+	case SQLServer:
+		return "CAST(" + expression + " AS VARCHAR(1024))"
+	case MySQL:
+		return "CAST(" + expression + " AS CHAR(1024))"
+	default:
+		return expression
+	}
+}
+
+// buildConnectByQuery renders the tree query with CONNECT BY, Oracle requires a column
+// list for recursive CTEs which t.* cannot provide. Paths start with a separator.
+func buildConnectByQuery(options TreeOptions, after string) (string, []interface{}) {
+	var args []interface{}
+
+	start := "t." + options.ParentColumn + " IS NULL"
+	if options.RootID != nil {
+		start = "t." + options.ParentColumn + " = ?"
+		args = append(args, options.RootID)
+	}
+
+	connect := "PRIOR t." + options.IDColumn + " = t." + options.ParentColumn
+	if options.MaxDepth > 0 {
+		connect += " AND LEVEL <= " + strconv.Itoa(options.MaxDepth+1)
+	}
+
+	query := "SELECT t.*, LEVEL - 1 AS tree_depth, " +
+		"SYS_CONNECT_BY_PATH(LPAD(TO_CHAR(t." + options.IDColumn + "), " + strconv.Itoa(treePathWidth) + ", '0'), '/') AS tree_path" +
+		" FROM " + options.TableName + " t START WITH " + start + " CONNECT BY " + connect
+
+	query = "SELECT * FROM (" + query + ") tree"
+	if after != "" {
+		query += " WHERE tree_path > ?"
+		args = append(args, after)
+	}
+
+	// ROWNUM applies before ORDER BY of the same query, and works before Oracle 12c
+	query = "SELECT * FROM (" + query + " ORDER BY tree_path) WHERE ROWNUM <= " + strconv.Itoa(options.Limit+1)
+	return query, args
+}
@@ -0,0 +1,101 @@
+package pagination
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+type TestRegion struct {
+	ID       uint
+	ParentID *uint
+	Name     string
+}
+
+func setupTreeDB() *gorm.DB {
+	db, _ := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	db.AutoMigrate(&TestRegion{})
+
+	parent := func(id uint) *uint { return &id }
+	db.Create(&[]TestRegion{
+		{ID: 1, Name: "Indonesia"},
+		{ID: 2, ParentID: parent(1), Name: "Java"},
+		{ID: 3, ParentID: parent(2), Name: "Jakarta"},
+		{ID: 4, ParentID: parent(1), Name: "Bali"},
+		{ID: 5, Name: "Malaysia"},
+		{ID: 6, ParentID: parent(2), Name: "Bandung"},
+		{ID: 12, ParentID: parent(5), Name: "Selangor"},
+	})
+	return db
+}
+
+func TestTree_DepthFirstCursor(t *testing.T) {
+	db := setupTreeDB()
+	options := TreeOptions{TableName: "test_regions", Limit: 3}
+
+	var names []string
+	var depths []int
+	cursor := ""
+	for pages := 0; pages < 5; pages++ {
+		page, err := Tree[TestRegion](db, cursor, options)
+		assert.NoError(t, err)
+		for _, node := range page.Data {
+			names = append(names, node.Node.Name)
+			depths = append(depths, node.Depth)
+		}
+		if !page.HasMore {
+			break
+		}
+		cursor = page.NextCursor
+	}
+
+	assert.Equal(t, []string{"Indonesia", "Java", "Jakarta", "Bandung", "Bali", "Malaysia", "Selangor"}, names)
+	assert.Equal(t, []int{0, 1, 2, 2, 1, 0, 1}, depths)
+}
+
+func TestTree_RootAndMaxDepth(t *testing.T) {
+	db := setupTreeDB()
+
+	page, err := Tree[TestRegion](db, "", TreeOptions{TableName: "test_regions", RootID: 1, MaxDepth: 1, Limit: 10})
+	assert.NoError(t, err)
+
+	var names []string
+	for _, node := range page.Data {
+		names = append(names, node.Node.Name)
+	}
+	assert.Equal(t, []string{"Java", "Jakarta", "Bandung", "Bali"}, names)
+	assert.False(t, page.HasMore)
+}
+
+func TestChildren(t *testing.T) {
+	db := setupTreeDB()
+	options := TreeOptions{TableName: "test_regions"}
+
+	roots, meta, err := Children[TestRegion](db, nil, PaginationRequest{Page: 1, PerPage: 10}, options)
+	assert.NoError(t, err)
+	assert.Len(t, roots, 2)
+	assert.Equal(t, int64(2), meta.Total)
+
+	children, meta, err := Children[TestRegion](db, 2, PaginationRequest{Page: 1, PerPage: 1}, options)
+	assert.NoError(t, err)
+	assert.Equal(t, "Jakarta", children[0].Name)
+	assert.Equal(t, int64(2), meta.MaxPage)
+
+	_, _, err = Children[TestRegion](db, 2, PaginationRequest{Page: 1, PerPage: 1}, TreeOptions{TableName: "regions; --"})
+	assert.Error(t, err)
+}
+
+func TestBuildTreeQuery_Dialects(t *testing.T) {
+	options := TreeOptions{TableName: "regions", IDColumn: "id", ParentColumn: "parent_id", Limit: 10}
+
+	query, _ := buildTreeQuery(MySQL, options, "")
+	assert.Contains(t, query, "WITH RECURSIVE tree AS (SELECT t.*, 0 AS tree_depth, CAST(LPAD(t.id, 10, '0') AS CHAR(1024))")
+	assert.Contains(t, query, "CONCAT(tree.tree_path, '/', LPAD(c.id, 10, '0'))")
+
+	query, args := buildTreeQuery(SQLServer, options, "0000000001")
+	assert.Contains(t, query, "WITH tree AS")
+	assert.Contains(t, query, "OFFSET 0 ROWS FETCH NEXT 11 ROWS ONLY")
+	assert.Equal(t, []interface{}{"0000000001"}, args)
+}
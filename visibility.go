@@ -0,0 +1,152 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// Visibility hides the rows a caller may not see, for rules that cannot be written in
+// SQL, such as permissions kept by another service. Its predicate runs on each fetched
+// row, and a page losing rows is refilled with the rows after it until it holds the
+// requested number of rows, the listing ends or MaxScan rows were read. Page n holds the
+// visible rows after those of the pages before it: with the token of a VisibilityReport
+// it starts where the previous page stopped reading, otherwise it reads the listing from
+// its start. Totals still count hidden rows. A Visibility holds no per-request state and
+// can be shared.
+type Visibility struct {
+	// MaxScan bounds the rows read for one page, hidden ones included; five pages by
+	// default. Pages read from the start of the listing may read MaxScan rows per page.
+	MaxScan int
+
+	rowType reflect.Type
+	visible func(ctx context.Context, item interface{}) bool
+}
+
+// VisibilityReport carries the position of a listing filtered by Visibility from one
+// page to the next and receives the rows hidden on the page; use one per request
+type VisibilityReport struct {
+	// Token is the visibility_token echoed by the client, empty on the first page
+	Token string
+
+	hidden int
+	issued string
+}
+
+type visibilityToken struct {
+	Page   int `json:"p"`
+	Offset int `json:"o"`
+}
+
+// VisibleIf creates a Visibility showing the rows predicate accepts; T must be the row
+// type of the query it is used with
+func VisibleIf[T any](predicate func(ctx context.Context, item T) bool) *Visibility {
+	return &Visibility{
+		rowType: reflect.TypeOf((*T)(nil)).Elem(),
+		visible: func(ctx context.Context, item interface{}) bool {
+			return predicate(ctx, item.(T))
+		},
+	}
+}
+
+// Hidden returns how many rows the predicate hid while reading the page
+func (r *VisibilityReport) Hidden() int {
+	if r == nil {
+		return 0
+	}
+	return r.hidden
+}
+
+// IssuedToken returns the token to send back to the client after the query ran
+func (r *VisibilityReport) IssuedToken() string {
+	if r == nil {
+		return ""
+	}
+	return r.issued
+}
+
+// checkRowType reports a predicate written for another row type than the query's
+func (v *Visibility) checkRowType(rowType reflect.Type) error {
+	if v == nil || v.rowType == rowType {
+		return nil
+	}
+	return fmt.Errorf("visibility predicate takes %s, the query returns %s", v.rowType, rowType)
+}
+
+// visibilityWindow is where a page filtered by a Visibility starts reading
+type visibilityWindow struct {
+	scope string
+	// start is the offset of the first row read, skip the visible rows before the page
+	start, skip int
+}
+
+// window places page, whose rows start at offset without hidden rows, using the token
+// of report when it was issued for the page before
+func (v *Visibility) window(report *VisibilityReport, scope string, page, offset int) visibilityWindow {
+	window := visibilityWindow{scope: scope, skip: offset}
+	if report == nil || report.Token == "" {
+		return window
+	}
+	var token visibilityToken
+	if err := decodeScopedToken(report.Token, scope, &token); err == nil && token.Page == page-1 && token.Offset >= 0 {
+		window.start, window.skip = token.Offset, 0
+	}
+	return window
+}
+
+// visibleRows keeps the rows of a page accepted by v, at most size of them after
+// skipping window.skip visible rows, reading further rows with next while fewer are
+// kept; size is negative for unlimited pages. The report receives the hidden rows and
+// the token of the next page.
+func visibleRows[T any](ctx context.Context, v *Visibility, report *VisibilityReport, window visibilityWindow, page int, rows []T, size int, next func(offset, limit int) ([]T, error)) ([]T, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	maxScan := v.MaxScan
+	if maxScan <= 0 {
+		maxScan = 5 * size
+	}
+	if size > 0 {
+		maxScan *= 1 + window.skip/size
+	}
+
+	kept := make([]T, 0, len(rows))
+	skip, hidden, read, requested := window.skip, 0, 0, size
+	for batch := rows; ; {
+		full := false
+		for _, row := range batch {
+			read++
+			if !v.visible(ctx, row) {
+				hidden++
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			if kept = append(kept, row); len(kept) == size {
+				full = true
+				break
+			}
+		}
+		if size < 0 || full || len(batch) < requested || read >= maxScan {
+			break
+		}
+
+		requested = min(size, maxScan-read)
+		var err error
+		if batch, err = next(window.start+read, requested); err != nil {
+			return nil, err
+		}
+	}
+
+	if report != nil {
+		report.hidden = hidden
+		issued, err := encodeScopedToken(visibilityToken{Page: page, Offset: window.start + read}, window.scope)
+		if err != nil {
+			return nil, err
+		}
+		report.issued = issued
+	}
+	return kept, nil
+}
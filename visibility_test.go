@@ -0,0 +1,103 @@
+package pagination
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// adults hides John Doe (25) and Alice Brown (28)
+var adults = VisibleIf(func(ctx context.Context, user TestUser) bool { return user.Age >= 30 })
+
+func TestVisibility_Refill(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users")
+
+	report := &VisibilityReport{}
+	users, total, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil, PaginatedQueryOptions{Dialect: SQLite, Visibility: adults, VisibilityReport: report})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Jane Smith", "Bob Johnson"}, userNames(users), "John is hidden and Bob refills the page")
+	assert.Equal(t, 1, report.Hidden())
+	assert.NotEmpty(t, report.IssuedToken())
+	assert.Equal(t, int64(5), total, "totals count hidden rows")
+
+	// The shared Visibility keeps no state, each request has its own report
+	other := &VisibilityReport{}
+	_, _, err = PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 10}, nil, PaginatedQueryOptions{Dialect: SQLite, Visibility: adults, VisibilityReport: other})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, other.Hidden())
+	assert.Equal(t, 1, report.Hidden())
+}
+
+func TestVisibility_ConsecutivePages(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users")
+	page := func(number int, report *VisibilityReport) []string {
+		users, _, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: number, PerPage: 2}, nil, PaginatedQueryOptions{Dialect: SQLite, Visibility: adults, VisibilityReport: report})
+		assert.NoError(t, err)
+		return userNames(users)
+	}
+
+	first := &VisibilityReport{}
+	assert.Equal(t, []string{"Jane Smith", "Bob Johnson"}, page(1, first))
+	second := &VisibilityReport{Token: first.IssuedToken()}
+	assert.Equal(t, []string{"Charlie Wilson"}, page(2, second), "the page starts after the rows read by the first one")
+	assert.Equal(t, 1, second.Hidden(), "Alice is hidden, Bob is not shown again")
+
+	// Without a token the page reads the listing from its start
+	assert.Equal(t, []string{"Charlie Wilson"}, page(2, nil))
+	assert.Equal(t, []string{"Charlie Wilson"}, page(2, &VisibilityReport{Token: second.IssuedToken()}), "tokens only apply to the following page")
+	assert.Empty(t, page(3, &VisibilityReport{Token: second.IssuedToken()}))
+}
+
+func TestVisibility_MaxScan(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users")
+
+	var checked []string
+	visibility := VisibleIf(func(ctx context.Context, user TestUser) bool {
+		checked = append(checked, user.Name)
+		return user.Name == "Charlie Wilson"
+	})
+	visibility.MaxScan = 3
+	report := &VisibilityReport{}
+	users, _, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 2}, nil, PaginatedQueryOptions{Dialect: SQLite, Visibility: visibility, VisibilityReport: report})
+	assert.NoError(t, err)
+	assert.Empty(t, users)
+	assert.Equal(t, []string{"John Doe", "Jane Smith", "Bob Johnson"}, checked)
+	assert.Equal(t, 3, report.Hidden())
+}
+
+func TestVisibility_RowType(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users")
+
+	called := false
+	visibility := VisibleIf(func(ctx context.Context, user *TestUser) bool {
+		called = true
+		return true
+	})
+	_, _, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 1}, nil, PaginatedQueryOptions{Dialect: SQLite, Visibility: visibility})
+	assert.EqualError(t, err, "visibility predicate takes *pagination.TestUser, the query returns pagination.TestUser")
+	assert.False(t, called)
+}
+
+func TestVisibility_Context(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users")
+
+	var requestIDs []string
+	visibility := VisibleIf(func(ctx context.Context, user TestUser) bool {
+		requestIDs = append(requestIDs, RequestIDFromContext(ctx))
+		return true
+	})
+	users, _, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: 1, PerPage: 1}, nil, PaginatedQueryOptions{
+		Dialect:    SQLite,
+		Context:    WithRequestID(context.Background(), "req-1"),
+		Visibility: visibility,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, []string{"req-1"}, requestIDs)
+}
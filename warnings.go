@@ -0,0 +1,206 @@
+package pagination
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// PaginationWarning describes a pagination parameter that was invalid or adjusted
+type PaginationWarning struct {
+	Param   string `json:"param"`
+	Value   string `json:"value"`
+	Applied string `json:"applied"`
+	Message string `json:"message"`
+}
+
+// pageSizeLimits configures how per_page is bound; oversized values are clamped at Max
+type pageSizeLimits struct {
+	Default int
+	Max     int
+}
+
+// bindPagination binds the pagination parameters of query; ctx is the request context,
+// which carries the page size reduction of AdaptivePageSize
+func bindPagination(ctx context.Context, query url.Values, limits pageSizeLimits) (PaginationRequest, []PaginationWarning) {
+	config := CurrentConfig()
+
+	// Endpoints may set their own maximum, see WithMaxPageSize
+	if max, ok := requestMaxPageSize(ctx); ok {
+		limits.Max = max
+		limits.Default = min(limits.Default, max)
+	}
+	// The policy of the caller replaces every other maximum, see PagePolicy
+	if policy, ok := PolicyFromContext(ctx); ok && policy.MaxPageSize > 0 {
+		limits.Max = policy.MaxPageSize
+		limits.Default = min(limits.Default, policy.MaxPageSize)
+	}
+
+	// Endpoints under load serve smaller pages, see AdaptivePageSize
+	defaultSize := limits.Default
+	reducedMax, reduced := adaptiveMaxPageSize(ctx, limits.Max)
+	if reduced {
+		limits.Max = reducedMax
+		if limits.Default > limits.Max {
+			limits.Default = limits.Max
+		}
+	}
+
+	pagination := PaginationRequest{
+		Page:       1,
+		PerPage:    limits.Default,
+		Search:     "",
+		Sort:       "",
+		Order:      "asc",
+		IsDisabled: false,
+	}
+
+	var warnings []PaginationWarning
+	warn := func(param, value string, applied interface{}, message string) {
+		warnings = append(warnings, PaginationWarning{
+			Param:   param,
+			Value:   value,
+			Applied: fmt.Sprint(applied),
+			Message: message,
+		})
+	}
+
+	pageParam, sizeParam := config.ParamStyle.pageParams()
+
+	if perPageStr := query.Get(sizeParam); perPageStr != "" {
+		perPage, err := strconv.Atoi(perPageStr)
+		switch {
+		case err == nil && perPage > 0 && perPage <= limits.Max:
+			pagination.PerPage = perPage
+		case err == nil && perPage > limits.Max:
+			pagination.PerPage = limits.Max
+			fallthrough
+		default:
+			warn(sizeParam, perPageStr, pagination.PerPage, pageSizeMessage(sizeParam, limits.Max, reduced && err == nil && perPage > 0))
+		}
+	} else if limits.Default < defaultSize {
+		warn(sizeParam, strconv.Itoa(defaultSize), pagination.PerPage, pageSizeMessage(sizeParam, limits.Max, reduced))
+	}
+
+	if config.ParamStyle == ParamStyleOffset {
+		// Offsets map onto the page containing them
+		if offsetStr := query.Get("offset"); offsetStr != "" {
+			if offset, err := strconv.Atoi(offsetStr); err == nil && offset >= 0 {
+				pagination.Page = offset/pagination.PerPage + 1
+				if offset%pagination.PerPage != 0 {
+					warn("offset", offsetStr, pagination.GetOffset(), "offset must be a multiple of "+sizeParam)
+				}
+			} else {
+				warn("offset", offsetStr, 0, "offset must be a non-negative integer")
+			}
+		}
+	} else if pageStr := query.Get(pageParam); pageStr != "" {
+		if page, err := strconv.Atoi(pageStr); err == nil && page > 0 {
+			pagination.Page = page
+		} else {
+			warn(pageParam, pageStr, pagination.Page, pageParam+" must be a positive integer")
+		}
+	}
+
+	pagination.Search = query.Get("search")
+	if mode := SearchMode(strings.ToLower(query.Get("search_mode"))); validSearchMode(mode) {
+		pagination.SearchMode = mode
+	} else if mode != "" {
+		warn("search_mode", query.Get("search_mode"), "default", "search_mode must be contains, prefix, exact or fulltext")
+	}
+
+	// A sign on the field, e.g. sort=-created_at, takes precedence over order; lists
+	// such as sort=-created_at,name keep the sign of each field
+	sort, signed := parseSortSign(query.Get("sort"))
+	if strings.Contains(sort, ",") {
+		sort, signed = bindSort(query.Get("sort"), warn), ""
+		if !strings.Contains(sort, ",") {
+			// A single valid field binds like sort=-created_at
+			sort, signed = parseSortSign(sort)
+		}
+	}
+	pagination.Sort = sort
+	if pagination.Sort != "" && !isValidSort(pagination.Sort) {
+		warn("sort", pagination.Sort, "default", "sort must be a column name")
+	}
+
+	if order := query.Get("order"); order == "desc" || order == "asc" {
+		pagination.Order = order
+	} else if order != "" {
+		warn("order", order, pagination.Order, "order must be asc or desc")
+	}
+	if signed != "" {
+		pagination.Order = signed
+	}
+
+	if isDisabled := query.Get("is_disabled"); isDisabled != "" {
+		switch strings.ToLower(isDisabled) {
+		case "1", "true", "yes", "y", "on":
+			pagination.IsDisabled = true
+		default:
+			pagination.IsDisabled = false
+		}
+	}
+
+	bindPolicy(ctx, &pagination, warn)
+	// Disabling pagination would bypass the maximum page size, so the page is capped instead
+	if pagination.IsDisabled && limits.Max > 0 {
+		pagination.ClampPageSize(limits.Max)
+		warn("is_disabled", query.Get("is_disabled"), false, fmt.Sprintf("is_disabled is not allowed, %s is capped at %d", sizeParam, limits.Max))
+	}
+	pagination.Validate()
+	return pagination, warnings
+}
+
+// parseSortSign splits a leading - or + off a sort field and returns the direction it
+// stands for; a + arrives as a space unless the client escaped it
+func parseSortSign(sort string) (string, string) {
+	switch {
+	case strings.HasPrefix(sort, "-"):
+		return strings.TrimSpace(sort[1:]), "desc"
+	case strings.HasPrefix(sort, "+"), strings.HasPrefix(sort, " "):
+		return strings.TrimSpace(sort[1:]), "asc"
+	}
+	return sort, ""
+}
+
+// applySortDirections sets the default direction declared for the sort field unless the
+// client chose one with a sign or the order parameter
+func applySortDirections(query url.Values, pagination *PaginationRequest, directions map[string]string) {
+	if query.Get("order") != "" {
+		return
+	}
+	entries := strings.Split(pagination.Sort, ",")
+	if len(entries) == 1 {
+		if _, signed := parseSortSign(query.Get("sort")); signed != "" {
+			return
+		}
+		if direction := strings.ToLower(directions[pagination.Sort]); direction == "asc" || direction == "desc" {
+			pagination.Order = direction
+		}
+		return
+	}
+
+	// Fields of a list without a sign get the sign of their declared direction
+	for i, entry := range entries {
+		if _, signed := parseSortSign(entry); signed != "" {
+			continue
+		}
+		switch strings.ToLower(directions[entry]) {
+		case "desc":
+			entries[i] = "-" + entry
+		case "asc":
+			entries[i] = "+" + entry
+		}
+	}
+	pagination.Sort = strings.Join(entries, ",")
+}
+
+func pageSizeMessage(sizeParam string, max int, reduced bool) string {
+	if reduced {
+		return fmt.Sprintf("%s is limited to %d while the endpoint is under load", sizeParam, max)
+	}
+	return fmt.Sprintf("%s must be between 1 and %d", sizeParam, max)
+}
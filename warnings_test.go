@@ -0,0 +1,54 @@
+package pagination
+
+import (
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBindPaginationWithWarnings(t *testing.T) {
+	pagination, warnings := BindPaginationRequest(newTestRequest("/users?page=two&per_page=500&order=up&sort=name%20desc"))
+
+	assert.Equal(t, 1, pagination.Page)
+	assert.Equal(t, 100, pagination.PerPage)
+	assert.Equal(t, "asc", pagination.Order)
+	assert.Equal(t, []PaginationWarning{
+		{Param: "per_page", Value: "500", Applied: "100", Message: "per_page must be between 1 and 100"},
+		{Param: "page", Value: "two", Applied: "1", Message: "page must be a positive integer"},
+		{Param: "sort", Value: "name desc", Applied: "default", Message: "sort must be a column name"},
+		{Param: "order", Value: "up", Applied: "asc", Message: "order must be asc or desc"},
+	}, warnings)
+
+	_, warnings = BindPaginationRequest(newTestRequest("/users?page=2&per_page=20&order=desc"))
+	assert.Empty(t, warnings)
+}
+
+func TestBindPaginationWithWarnings_Clamp(t *testing.T) {
+	registerTestTable(t, TableConfig{MaxPageSize: 50})
+
+	pagination, warnings := bindTablePagination(context.Background(), httptest.NewRequest("GET", "/users?per_page=80", nil).URL.Query(), "test_users")
+	assert.Equal(t, 50, pagination.PerPage)
+	if assert.Len(t, warnings, 1) {
+		assert.Equal(t, "50", warnings[0].Applied)
+	}
+}
+
+func TestHelpers_SurfaceWarnings(t *testing.T) {
+	db := setupTestDB()
+
+	response := PaginateResponse[TestUser](db, newTestRequest("/users?per_page=0"), "ok", WithTable("test_users"))
+	assert.Equal(t, 200, response.Code)
+	assert.Len(t, response.Pagination.Warnings, 1)
+
+	response = PaginateResponse[TestUser](db, newTestRequest("/users?page=-1"), "ok", WithCustomFilter(&testUserFilter{}))
+	assert.Equal(t, 200, response.Code)
+	if assert.Len(t, response.Pagination.Warnings, 1) {
+		assert.Equal(t, "page", response.Pagination.Warnings[0].Param)
+	}
+
+	encoded, _ := json.Marshal(CalculatePagination(PaginationRequest{Page: 1, PerPage: 10}, 5))
+	assert.NotContains(t, string(encoded), "warnings")
+}
@@ -0,0 +1,114 @@
+package pagination
+
+import (
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// defaultHighWaterMarkTTL bounds how long a listing stays pinned to its first page
+const defaultHighWaterMarkTTL = time.Hour
+
+// HighWaterMark keeps an offset listing stable while rows are written: the first page
+// reads the highest value of a write-ordered column, such as an auto-increment id or
+// updated_at, and issues a token holding it. Later pages, when the client echoes the
+// token, only see rows at or below that mark, so rows inserted meanwhile neither shift
+// the pages nor change the total. Rows updated past an updated_at mark leave the
+// listing. Invalid or expired tokens start a new mark.
+type HighWaterMark struct {
+	// Token is the as_of_token echoed by the client, empty on the first page
+	Token string
+	// Column only grows with writes; the primary key by default
+	Column string
+	// TTL is the lifetime of issued tokens, an hour by default
+	TTL time.Duration
+
+	issued string
+	mark   interface{}
+}
+
+type highWaterMarkToken struct {
+	Mark interface{} `json:"m"`
+	// Time marks a timestamp, decoded back into time.Time
+	Time bool `json:"t,omitempty"`
+}
+
+// IssuedToken returns the token to send back to the client after the query ran
+func (h *HighWaterMark) IssuedToken() string {
+	if h == nil {
+		return ""
+	}
+	return h.issued
+}
+
+// Mark returns the value the listing was bounded by, nil when it was not bounded
+func (h *HighWaterMark) Mark() interface{} {
+	if h == nil {
+		return nil
+	}
+	return h.mark
+}
+
+// apply bounds query by the mark of the echoed token, or the highest value of the
+// column in query when there is none; empty listings stay unbounded
+func (h *HighWaterMark) apply(query *gorm.DB, column string, scope string) (*gorm.DB, error) {
+	if !isValidSortField(column) {
+		return nil, fmt.Errorf("invalid high water mark column: %s", column)
+	}
+
+	if mark, ok := h.decode(scope); ok {
+		h.mark, h.issued = mark, h.Token
+		return query.Where(column+" <= ?", mark), nil
+	}
+	if query.DryRun {
+		return query, nil
+	}
+
+	var mark interface{}
+	if err := query.Session(&gorm.Session{}).Select("MAX(" + column + ")").Row().Scan(&mark); err != nil {
+		return nil, fmt.Errorf("failed to read high water mark: %w", err)
+	}
+	if bytes, ok := mark.([]byte); ok {
+		mark = string(bytes)
+	}
+	if mark == nil {
+		return query, nil
+	}
+
+	ttl := h.TTL
+	if ttl <= 0 {
+		ttl = defaultHighWaterMarkTTL
+	}
+	_, isTime := mark.(time.Time)
+	token, err := encodeExpiringToken(highWaterMarkToken{Mark: mark, Time: isTime}, scope, ttl)
+	if err != nil {
+		return nil, err
+	}
+	h.mark, h.issued = mark, token
+	return query.Where(column+" <= ?", mark), nil
+}
+
+// decode returns the mark of the echoed token if it is valid for scope
+func (h *HighWaterMark) decode(scope string) (interface{}, bool) {
+	if h.Token == "" {
+		return nil, false
+	}
+
+	var decoded highWaterMarkToken
+	if err := decodeScopedToken(h.Token, scope, &decoded); err != nil || decoded.Mark == nil {
+		return nil, false
+	}
+	if !decoded.Time {
+		return normalizeTokenValue(decoded.Mark), true
+	}
+	text, ok := decoded.Mark.(string)
+	if !ok {
+		return nil, false
+	}
+	mark, err := time.Parse(time.RFC3339Nano, text)
+	if err != nil {
+		return nil, false
+	}
+	return mark, true
+}
@@ -0,0 +1,74 @@
+package pagination
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighWaterMark(t *testing.T) {
+	db := setupTestDB()
+	builder := NewSimpleQueryBuilder("test_users").WithDefaultSort("id desc")
+	page := func(number int, asOf *HighWaterMark) ([]string, int64) {
+		users, total, err := PaginatedQueryWithOptions[TestUser](db, builder, PaginationRequest{Page: number, PerPage: 2}, nil, PaginatedQueryOptions{Dialect: SQLite, AsOf: asOf})
+		assert.NoError(t, err)
+		return userNames(users), total
+	}
+
+	first := &HighWaterMark{}
+	names, total := page(1, first)
+	assert.Equal(t, []string{"Charlie Wilson", "Alice Brown"}, names)
+	assert.Equal(t, int64(5), total)
+	assert.Equal(t, int64(5), first.Mark())
+	assert.NotEmpty(t, first.IssuedToken())
+
+	db.Create(&TestUser{Name: "Dave", Age: 40})
+
+	second := &HighWaterMark{Token: first.IssuedToken()}
+	names, total = page(2, second)
+	assert.Equal(t, []string{"Bob Johnson", "Jane Smith"}, names, "rows inserted after the first page do not shift later pages")
+	assert.Equal(t, int64(5), total)
+	assert.Equal(t, first.IssuedToken(), second.IssuedToken(), "the mark carries on through later pages")
+
+	fresh := &HighWaterMark{Token: "garbage"}
+	names, _ = page(1, fresh)
+	assert.Equal(t, []string{"Dave", "Charlie Wilson"}, names, "invalid tokens start a new mark")
+	assert.Equal(t, int64(6), fresh.Mark())
+
+	filtered := NewSimpleQueryBuilder("test_users").WithFilters(Where(testUserFields.Age.Gt(30)))
+	other := &HighWaterMark{Token: first.IssuedToken()}
+	_, _, err := PaginatedQueryWithOptions[TestUser](db, filtered, PaginationRequest{Page: 2, PerPage: 2}, nil, PaginatedQueryOptions{Dialect: SQLite, AsOf: other})
+	assert.NoError(t, err)
+	assert.NotEqual(t, first.IssuedToken(), other.IssuedToken(), "tokens are bound to the filters")
+	assert.Equal(t, int64(6), other.Mark())
+}
+
+func TestHighWaterMark_Timestamps(t *testing.T) {
+	db := setupChangesDB()
+	db.Where("1 = 1").Delete(&TestChange{})
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	for i, name := range []string{"a", "b", "c"} {
+		db.Create(&TestChange{Name: name, UpdatedAt: base.Add(time.Duration(i) * time.Hour)})
+	}
+	builder := NewSimpleQueryBuilder("test_changes").WithDefaultSort("updated_at desc")
+	names := func(asOf *HighWaterMark) []string {
+		changes, _, err := PaginatedQueryWithOptions[TestChange](db, builder, PaginationRequest{Page: 1, PerPage: 10}, nil, PaginatedQueryOptions{Dialect: SQLite, AsOf: asOf})
+		assert.NoError(t, err)
+		var names []string
+		for _, change := range changes {
+			names = append(names, change.Name)
+		}
+		return names
+	}
+
+	first := &HighWaterMark{Column: "updated_at"}
+	assert.Equal(t, []string{"c", "b", "a"}, names(first))
+
+	db.Create(&TestChange{Name: "d", UpdatedAt: base.Add(5 * time.Hour)})
+	db.Model(&TestChange{}).Where("name = ?", "a").Update("updated_at", base.Add(4*time.Hour))
+	assert.Equal(t, []string{"c", "b"}, names(&HighWaterMark{Column: "updated_at", Token: first.IssuedToken()}), "rows written past the mark leave the listing")
+
+	_, _, err := PaginatedQueryWithOptions[TestChange](db, builder, PaginationRequest{Page: 1, PerPage: 10}, nil, PaginatedQueryOptions{Dialect: SQLite, AsOf: &HighWaterMark{Column: "updated_at; --"}})
+	assert.ErrorContains(t, err, "invalid high water mark column")
+}
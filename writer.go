@@ -0,0 +1,125 @@
+package pagination
+
+import (
+	"encoding/json"
+	"iter"
+	"net/http"
+)
+
+// WriteOptions configures WritePaginated
+type WriteOptions struct {
+	// Code is the HTTP status and envelope code (default 200)
+	Code int
+	// Message is the message of the envelope
+	Message string
+	// MetaLast writes the pagination metadata after the data, e.g. when it is only known
+	// once every row was produced; by default it comes first so clients can read it early
+	MetaLast bool
+	// FlushEvery flushes the response after this many rows (default 100)
+	FlushEvery int
+}
+
+func (o *WriteOptions) validate() {
+	if o.Code == 0 {
+		o.Code = http.StatusOK
+	}
+	if o.FlushEvery <= 0 {
+		o.FlushEvery = 100
+	}
+}
+
+// WritePaginated writes the standard envelope of data to w, encoding the rows one by one
+// with a json.Encoder and flushing every FlushEvery rows instead of rendering the whole
+// response in memory first. The JSON is the same as that of PaginatedResponse.
+func WritePaginated[T any](w http.ResponseWriter, data []T, meta PaginationResponse, options WriteOptions) error {
+	rows := func(yield func(T, error) bool) {
+		for _, item := range data {
+			if !yield(item, nil) {
+				return
+			}
+		}
+	}
+	return WritePaginatedRows(w, rows, func() PaginationResponse { return meta }, options)
+}
+
+// WritePaginatedRows is WritePaginated for rows produced while the response is written,
+// e.g. scanned from a cursor. meta is called when the metadata is written, after the last
+// row with MetaLast. A row error cuts the response short, as the status was already sent,
+// and is returned for logging.
+func WritePaginatedRows[T any](w http.ResponseWriter, rows iter.Seq2[T, error], meta func() PaginationResponse, options WriteOptions) error {
+	options.validate()
+	envelope := NewPaginatedResponse(options.Code, options.Message, nil, PaginationResponse{})
+	head, err := json.Marshal(struct {
+		Code    int    `json:"code"`
+		Status  string `json:"status"`
+		Message string `json:"message"`
+	}{envelope.Code, envelope.Status, envelope.Message})
+	if err != nil {
+		return err
+	}
+
+	// Proxies such as nginx would otherwise buffer the whole response
+	w.Header().Set("X-Accel-Buffering", "no")
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	}
+	w.WriteHeader(options.Code)
+
+	flush := func() {}
+	if flusher, ok := w.(http.Flusher); ok {
+		flush = flusher.Flush
+	}
+	encoder := json.NewEncoder(w)
+	writeMeta := func() error {
+		if _, err := w.Write([]byte(`,"pagination":`)); err != nil {
+			return err
+		}
+		return encoder.Encode(meta())
+	}
+
+	// Reopen the envelope to append the metadata and data
+	if _, err := w.Write(head[:len(head)-1]); err != nil {
+		return err
+	}
+	if !options.MetaLast {
+		if err := writeMeta(); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write([]byte(`,"data":[`)); err != nil {
+		return err
+	}
+
+	count := 0
+	for item, err := range rows {
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			if _, err := w.Write([]byte{','}); err != nil {
+				return err
+			}
+		}
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+		count++
+		if count%options.FlushEvery == 0 {
+			flush()
+		}
+	}
+
+	if _, err := w.Write([]byte{']'}); err != nil {
+		return err
+	}
+	if options.MetaLast {
+		if err := writeMeta(); err != nil {
+			return err
+		}
+	}
+	if _, err := w.Write([]byte{'}'}); err != nil {
+		return err
+	}
+	flush()
+	return nil
+}
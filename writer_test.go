@@ -0,0 +1,78 @@
+package pagination
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWritePaginated(t *testing.T) {
+	users := []TestUser{{ID: 1, Name: "Ann"}, {ID: 2, Name: "Ben"}, {ID: 3, Name: "Cid"}}
+	meta := CalculatePagination(PaginationRequest{Page: 1, PerPage: 3}, 7)
+
+	recorder := httptest.NewRecorder()
+	assert.NoError(t, WritePaginated(recorder, users, meta, WriteOptions{Message: "Users", FlushEvery: 2}))
+	assert.Equal(t, 200, recorder.Code)
+	assert.True(t, recorder.Flushed)
+	assert.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+	expected, err := json.Marshal(NewPaginatedResponse(200, "Users", users, meta))
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(expected), recorder.Body.String())
+	body := recorder.Body.String()
+	assert.Less(t, strings.Index(body, `"pagination"`), strings.Index(body, `"data"`), "metadata comes first by default")
+
+	recorder = httptest.NewRecorder()
+	assert.NoError(t, WritePaginated(recorder, users, meta, WriteOptions{Message: "Users", MetaLast: true}))
+	assert.JSONEq(t, string(expected), recorder.Body.String())
+	body = recorder.Body.String()
+	assert.Greater(t, strings.Index(body, `"pagination"`), strings.Index(body, `"data"`))
+
+	recorder = httptest.NewRecorder()
+	assert.NoError(t, WritePaginated(recorder, []TestUser{}, meta, WriteOptions{Code: 404}))
+	var response map[string]interface{}
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, 404, recorder.Code)
+	assert.Equal(t, "error", response["status"])
+	assert.Equal(t, []interface{}{}, response["data"])
+}
+
+func TestWritePaginatedRows_MetaAfterRows(t *testing.T) {
+	produced := 0
+	rows := func(yield func(TestUser, error) bool) {
+		for _, name := range []string{"Ann", "Ben"} {
+			produced++
+			if !yield(TestUser{Name: name}, nil) {
+				return
+			}
+		}
+	}
+	meta := func() PaginationResponse {
+		return CalculatePagination(PaginationRequest{Page: 1, PerPage: 10}, int64(produced))
+	}
+
+	recorder := httptest.NewRecorder()
+	assert.NoError(t, WritePaginatedRows(recorder, rows, meta, WriteOptions{MetaLast: true}))
+
+	var response PaginatedResponse
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, int64(2), response.Pagination.Total, "metadata written last sees every row")
+}
+
+func TestWritePaginatedRows_RowError(t *testing.T) {
+	failure := errors.New("connection lost")
+	rows := func(yield func(TestUser, error) bool) {
+		if yield(TestUser{Name: "Ann"}, nil) {
+			yield(TestUser{}, failure)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	err := WritePaginatedRows(recorder, rows, func() PaginationResponse { return PaginationResponse{} }, WriteOptions{})
+	assert.ErrorIs(t, err, failure)
+	assert.False(t, json.Valid(recorder.Body.Bytes()), "the response is cut short")
+}